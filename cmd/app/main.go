@@ -2,6 +2,7 @@ package main
 
 import (
 	"log/slog"
+	_ "time/tzdata" // База часовых поясов для образа без системной tzdata
 
 	"github.com/skantay/todo-list/internal/app"
 )
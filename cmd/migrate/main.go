@@ -0,0 +1,16 @@
+package main
+
+import (
+	"log/slog"
+	"os"
+
+	"github.com/skantay/todo-list/internal/app"
+)
+
+// Применение миграций схемы MongoDB: migrate up|down|status
+func main() {
+	if err := app.Migrate(os.Args[1:]); err != nil {
+		slog.Error("migration failed", "error", err)
+		os.Exit(1)
+	}
+}
@@ -1,15 +1,91 @@
 package config
 
 import (
+	"errors"
 	"fmt"
+	"regexp"
+	"time"
 
 	"github.com/spf13/viper"
 )
 
+// Поддерживаемые бэкенды хранилища задач
+const (
+	StorageMongoDB = "mongodb"
+	StorageMemory  = "memory"
+	StorageSQLite  = "sqlite"
+)
+
+// Режимы выполнения задачи с открытыми пунктами чек-листа или подзадачами
+const (
+	CompletionRefuse  = "refuse"
+	CompletionCascade = "cascade"
+)
+
+// Способы разделения задач рабочих пространств
+const (
+	IsolationField      = "field"
+	IsolationCollection = "collection"
+)
+
+// tenantID - допустимый id рабочего пространства, он же суффикс имени коллекции
+var tenantID = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,62}$`)
+
 // Config представляет конфигурацию приложения.
 type Config struct {
 	Server  Server  `yaml:"server"`
+	Storage Storage `yaml:"storage"`
 	MongoDB MongoDB `yaml:"mongodb"`
+	SQLite  SQLite  `yaml:"sqlite"`
+	Tasks   Tasks   `yaml:"tasks"`
+	Auth    Auth    `yaml:"auth"`
+	Tenants Tenants `yaml:"tenants"`
+}
+
+// Tenants настраивает рабочие пространства команд.
+// Без Workspaces сервис работает в одном пространстве по умолчанию.
+// Isolation определяет, как разделяются задачи пространств: полем tenantId в общей коллекции
+// (field, по умолчанию) или отдельной коллекцией задач на каждое пространство (collection, только MongoDB).
+type Tenants struct {
+	Isolation  string      `yaml:"isolation"`
+	Workspaces []Workspace `yaml:"workspaces"`
+}
+
+// Workspace - рабочее пространство и его настройки.
+// TimeZone - часовой пояс запросов без X-Time-Zone, по умолчанию UTC.
+// WeekendPrefix - префикс заголовка задач на выходные, без него обычный, пустая строка отключает префикс.
+type Workspace struct {
+	ID            string  `yaml:"id"`
+	TimeZone      string  `yaml:"timeZone"`
+	WeekendPrefix *string `yaml:"weekendPrefix"`
+}
+
+// Auth настраивает выдачу токенов доступа.
+// Secret - ключ подписи JWT, обязателен, можно задать переменной окружения AUTH_SECRET.
+// TokenTTL - время жизни токена, по умолчанию 24h.
+// LegacyOwner - email пользователя, которому миграция передаёт задачи и проекты, созданные до появления пользователей.
+type Auth struct {
+	Secret      string        `yaml:"secret"`
+	TokenTTL    time.Duration `yaml:"tokenTTL"`
+	LegacyOwner string        `yaml:"legacyOwner"`
+}
+
+// placeholderSecret - ключ подписи из примера конфига, с ним токены может подделать любой
+const placeholderSecret = "change-me"
+
+// Storage определяет, какой бэкенд хранилища использовать.
+// Пустое значение означает MongoDB.
+type Storage struct {
+	Driver string `yaml:"driver"`
+}
+
+// Tasks настраивает бизнес-логику задач.
+// Completion определяет, что делать при выполнении задачи с открытыми подзадачами:
+// отклонить (refuse, по умолчанию) или выполнить их вместе с задачей (cascade).
+// MaxBatch - сколько операций можно передать в одном пакетном запросе, по умолчанию 100.
+type Tasks struct {
+	Completion string `yaml:"completion"`
+	MaxBatch   int    `yaml:"maxBatch"`
 }
 
 type MongoDB struct {
@@ -19,6 +95,10 @@ type MongoDB struct {
 	Port     string `yaml:"port"`
 }
 
+type SQLite struct {
+	Path string `yaml:"path"`
+}
+
 type Server struct {
 	Host string `yaml:"host"`
 	Port string `yaml:"port"`
@@ -33,9 +113,71 @@ func New(path string) (Config, error) {
 		return config, fmt.Errorf("failed to read config: %w", err)
 	}
 
+	// Ключ подписи не обязательно хранить в файле конфига
+	if err := viper.BindEnv("auth.secret", "AUTH_SECRET"); err != nil {
+		return config, fmt.Errorf("failed to bind auth secret: %w", err)
+	}
+
 	if err := viper.Unmarshal(&config); err != nil {
 		return config, fmt.Errorf("failed to unmarshal config: %w", err)
 	}
 
+	if config.Storage.Driver == "" {
+		config.Storage.Driver = StorageMongoDB
+	}
+
+	switch config.Tasks.Completion {
+	case "":
+		config.Tasks.Completion = CompletionRefuse
+	case CompletionRefuse, CompletionCascade:
+	default:
+		return config, fmt.Errorf("unknown tasks completion mode %q", config.Tasks.Completion)
+	}
+
+	if config.Tasks.MaxBatch < 0 {
+		return config, fmt.Errorf("tasks max batch must not be negative, got %d", config.Tasks.MaxBatch)
+	}
+
+	switch config.Auth.Secret {
+	case "":
+		return config, errors.New("auth secret is required, set auth.secret or AUTH_SECRET")
+	case placeholderSecret:
+		return config, fmt.Errorf("auth secret must not be the placeholder %q, set auth.secret or AUTH_SECRET", placeholderSecret)
+	}
+
+	if err := validateTenants(&config); err != nil {
+		return config, err
+	}
+
 	return config, nil
 }
+
+// validateTenants проверяет рабочие пространства и задаёт способ изоляции по умолчанию.
+func validateTenants(config *Config) error {
+	switch config.Tenants.Isolation {
+	case "":
+		config.Tenants.Isolation = IsolationField
+	case IsolationField:
+	case IsolationCollection:
+		if config.Storage.Driver != StorageMongoDB {
+			return fmt.Errorf("tenants isolation %q requires storage driver %q", IsolationCollection, StorageMongoDB)
+		}
+	default:
+		return fmt.Errorf("unknown tenants isolation %q", config.Tenants.Isolation)
+	}
+
+	seen := make(map[string]bool, len(config.Tenants.Workspaces))
+
+	for _, workspace := range config.Tenants.Workspaces {
+		if !tenantID.MatchString(workspace.ID) {
+			return fmt.Errorf("invalid workspace id %q: use lowercase letters, digits, - and _", workspace.ID)
+		}
+
+		if seen[workspace.ID] {
+			return fmt.Errorf("duplicate workspace id %q", workspace.ID)
+		}
+		seen[workspace.ID] = true
+	}
+
+	return nil
+}
@@ -12,17 +12,21 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/skantay/todo-list/config"
 	v1 "github.com/skantay/todo-list/internal/controller/http/v1"
+	"github.com/skantay/todo-list/internal/entity"
 	"github.com/skantay/todo-list/internal/repository"
 	"github.com/skantay/todo-list/internal/usecase"
 	"github.com/skantay/todo-list/pkg/httpserver"
 	"github.com/skantay/todo-list/pkg/log"
 	"github.com/skantay/todo-list/pkg/mongodb"
+	"github.com/skantay/todo-list/pkg/sqlite"
 
+	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 const (
-	configPath = "config/config.yaml"
+	configPath    = "config/config.yaml"
+	mongoDatabase = "taskdb"
 )
 
 func Run() error {
@@ -32,39 +36,34 @@ func Run() error {
 		return fmt.Errorf("error getting config: %w", err)
 	}
 
-	// Указываем параметры для установки соединения с MongoDB
-	options := options.Client().ApplyURI(
-		fmt.Sprintf("mongodb://%s:%s@%s",
-			cfg.MongoDB.User,
-			cfg.MongoDB.Password,
-			net.JoinHostPort(cfg.MongoDB.Host, cfg.MongoDB.Port),
-		),
-	)
+	opts := &slog.HandlerOptions{
+		Level: slog.LevelWarn,
+	}
+	// Инициализация логгера
+	logger := log.InitSlog(opts)
 
-	// Прокидываем контекст в MongoDB
+	// Прокидываем контекст в хранилище
 	ctx, cancel := context.WithTimeout(context.Background(), mongodb.DefaultTimeout)
 	defer cancel()
 
-	// Установка соединения с MongoDB
-	client, err := mongodb.Connect(ctx, options)
+	// Dependecy Injection
+	repository, err := newRepository(ctx, cfg, logger)
 	if err != nil {
-		return fmt.Errorf("error connecting to mongodb: %w", err)
+		return fmt.Errorf("error initializing repository: %w", err)
 	}
-
-	// Здесь указываются с какими коллекциями будет репозиторный слой работать
-	collections := repository.Collections{
-		Task: "task",
+	tenants, err := newTenants(cfg.Tenants)
+	if err != nil {
+		return fmt.Errorf("error configuring tenants: %w", err)
 	}
-
-	opts := &slog.HandlerOptions{
-		Level: slog.LevelWarn,
+	usecaseOpts := []usecase.Option{
+		usecase.TokenAuth(cfg.Auth.Secret, cfg.Auth.TokenTTL),
+		usecase.Tenants(tenants...),
+		usecase.MaxBatch(cfg.Tasks.MaxBatch),
 	}
-	// Инициализация логгера
-	logger := log.InitSlog(opts)
-
-	// Dependecy Injection
-	repository := repository.New(client, "taskdb", collections, logger)
-	usecase := usecase.New(repository, logger)
+	if cfg.Tasks.Completion == config.CompletionCascade {
+		usecaseOpts = append(usecaseOpts, usecase.CascadeCompletion())
+	}
+	usecase := usecase.New(repository, logger, usecaseOpts...)
 
 	router := gin.Default()
 	v1.Set(router, usecase, logger)
@@ -88,7 +87,7 @@ func Run() error {
 	case s := <-interrupt:
 		logger.Info("app - Run - signal: " + s.String())
 	case err = <-httpServer.Notify():
-		logger.Error("app - Run - httpServer.Notify: %w", err)
+		logger.Error("app - Run - httpServer.Notify", "error", err)
 	}
 
 	logger.Info("Shutting down...")
@@ -96,10 +95,110 @@ func Run() error {
 	err = httpServer.Shutdown()
 
 	if err != nil {
-		logger.Error("app - Run - httpServer.Shutdown: %w", err)
+		logger.Error("app - Run - httpServer.Shutdown", "error", err)
 
 		return fmt.Errorf("failed to shutdown: %w", err)
 	}
 
 	return nil
 }
+
+// newRepository создаёт репозиторий в зависимости от бэкенда, указанного в конфиге
+func newRepository(ctx context.Context, cfg config.Config, logger *slog.Logger) (repository.Repository, error) {
+	switch cfg.Storage.Driver {
+	case config.StorageMongoDB:
+		client, err := connectMongoDB(ctx, cfg)
+		if err != nil {
+			return repository.Repository{}, err
+		}
+
+		collections := mongoCollections(cfg)
+
+		migrator := newMigrator(client.Database(mongoDatabase), cfg, logger)
+
+		if err := migrateOnStart(ctx, migrator, logger); err != nil {
+			return repository.Repository{}, fmt.Errorf("error migrating mongodb: %w", err)
+		}
+
+		// Коллекции задач рабочих пространств, добавленных в конфиг после миграций,
+		// получают индексы применённой версии схемы
+		if err := migrator.EnsureTaskIndexes(ctx, collections.TaskCollections()); err != nil {
+			return repository.Repository{}, fmt.Errorf("error creating mongodb indexes: %w", err)
+		}
+
+		return repository.New(client, mongoDatabase, collections, logger), nil
+	case config.StorageSQLite:
+		db, err := sqlite.Open(ctx, cfg.SQLite.Path)
+		if err != nil {
+			return repository.Repository{}, fmt.Errorf("error opening sqlite: %w", err)
+		}
+
+		return repository.NewSQLite(ctx, db, logger)
+	case config.StorageMemory:
+		return repository.NewMemory(logger), nil
+	default:
+		return repository.Repository{}, fmt.Errorf("unknown storage driver %q", cfg.Storage.Driver)
+	}
+}
+
+// connectMongoDB устанавливает соединение с MongoDB из конфига
+func connectMongoDB(ctx context.Context, cfg config.Config) (*mongo.Client, error) {
+	// Указываем параметры для установки соединения с MongoDB
+	options := options.Client().ApplyURI(
+		fmt.Sprintf("mongodb://%s:%s@%s",
+			cfg.MongoDB.User,
+			cfg.MongoDB.Password,
+			net.JoinHostPort(cfg.MongoDB.Host, cfg.MongoDB.Port),
+		),
+	)
+
+	client, err := mongodb.Connect(ctx, options)
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to mongodb: %w", err)
+	}
+
+	return client, nil
+}
+
+// mongoCollections указывает, с какими коллекциями будет работать репозиторный слой
+func mongoCollections(cfg config.Config) repository.Collections {
+	collections := repository.Collections{
+		Task:    "task",
+		Project: "project",
+		User:    "user",
+		APIKey:  "api_key",
+		Share:   "share",
+		// Задачи каждого рабочего пространства в своей коллекции task_<id>
+		TaskPerTenant: cfg.Tenants.Isolation == config.IsolationCollection,
+	}
+	for _, workspace := range cfg.Tenants.Workspaces {
+		collections.Tenants = append(collections.Tenants, workspace.ID)
+	}
+
+	return collections
+}
+
+// newTenants создаёт рабочие пространства из конфига
+func newTenants(cfg config.Tenants) ([]entity.Tenant, error) {
+	tenants := make([]entity.Tenant, 0, len(cfg.Workspaces))
+
+	for _, workspace := range cfg.Workspaces {
+		loc, err := entity.LoadLocation(workspace.TimeZone)
+		if err != nil {
+			return nil, fmt.Errorf("workspace %q: %w", workspace.ID, err)
+		}
+
+		tenant := entity.Tenant{
+			ID:            workspace.ID,
+			Location:      loc,
+			WeekendPrefix: entity.DefaultWeekendPrefix,
+		}
+		if workspace.WeekendPrefix != nil {
+			tenant.WeekendPrefix = *workspace.WeekendPrefix
+		}
+
+		tenants = append(tenants, tenant)
+	}
+
+	return tenants, nil
+}
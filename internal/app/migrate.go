@@ -0,0 +1,115 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/skantay/todo-list/config"
+	"github.com/skantay/todo-list/internal/migrations"
+	"github.com/skantay/todo-list/pkg/log"
+	"github.com/skantay/todo-list/pkg/mongodb"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	// migrateUsage - подсказка для cmd/migrate
+	migrateUsage = "usage: migrate up|down|status"
+	// migrateTimeout ограничивает применение миграций, заполнение полей может быть долгим
+	migrateTimeout = 5 * time.Minute
+)
+
+// Migrate выполняет команду cmd/migrate: up применяет все миграции,
+// down откатывает последнюю применённую, status выводит состояние миграций.
+func Migrate(args []string) error {
+	if len(args) != 1 {
+		return errors.New(migrateUsage)
+	}
+
+	cfg, err := config.New(configPath)
+	if err != nil {
+		return fmt.Errorf("error getting config: %w", err)
+	}
+
+	if cfg.Storage.Driver != config.StorageMongoDB {
+		return fmt.Errorf("migrations require the %q storage driver, got %q", config.StorageMongoDB, cfg.Storage.Driver)
+	}
+
+	logger := log.InitSlog(&slog.HandlerOptions{Level: slog.LevelInfo})
+
+	connectCtx, cancel := context.WithTimeout(context.Background(), mongodb.DefaultTimeout)
+	defer cancel()
+
+	client, err := connectMongoDB(connectCtx, cfg)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := client.Disconnect(context.Background()); err != nil {
+			logger.Error("failed to disconnect from mongodb", "error", err)
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), migrateTimeout)
+	defer cancel()
+
+	migrator := newMigrator(client.Database(mongoDatabase), cfg, logger)
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("applied %d migrations\n", len(applied))
+	case "down":
+		migration, err := migrator.Down(ctx)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("rolled back %d %s\n", migration.Version, migration.Name)
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if status.AppliedAt != nil {
+				appliedAt = status.AppliedAt.Format(time.RFC3339)
+			}
+
+			fmt.Fprintf(w, "%d\t%s\t%s\n", status.Version, status.Name, appliedAt)
+		}
+
+		return w.Flush()
+	default:
+		return fmt.Errorf("unknown command %q, %s", args[0], migrateUsage)
+	}
+
+	return nil
+}
+
+// newMigrator создаёт мигратор схемы для коллекций приложения
+func newMigrator(db *mongo.Database, cfg config.Config, logger *slog.Logger) *migrations.Migrator {
+	collections := mongoCollections(cfg)
+
+	return migrations.New(db, migrations.List(migrations.Collections{
+		Tasks:   collections.TaskCollections(),
+		Project: collections.Project,
+		User:    collections.User,
+		APIKey:  collections.APIKey,
+		Share:   collections.Share,
+	}, cfg.Auth.LegacyOwner), logger)
+}
@@ -0,0 +1,27 @@
+//go:build !migrate
+
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/skantay/todo-list/internal/migrations"
+)
+
+// migrateOnStart проверяет, что схема актуальна, и саму схему не меняет.
+// Без тега migrate миграции применяются командой cmd/migrate: с неприменёнными миграциями
+// приложение не запускается, иначе оно работало бы без индексов и заполненных полей.
+func migrateOnStart(ctx context.Context, migrator *migrations.Migrator, _ *slog.Logger) error {
+	pending, err := migrator.Pending(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to check migrations: %w", err)
+	}
+
+	if pending > 0 {
+		return fmt.Errorf("%d schema migrations are pending, run: go run ./cmd/migrate up", pending)
+	}
+
+	return nil
+}
@@ -0,0 +1,26 @@
+//go:build migrate
+
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/skantay/todo-list/internal/migrations"
+)
+
+// migrateOnStart применяет неприменённые миграции. Сборка с тегом migrate.
+func migrateOnStart(ctx context.Context, migrator *migrations.Migrator, logger *slog.Logger) error {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), migrateTimeout)
+	defer cancel()
+
+	applied, err := migrator.Up(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to apply migrations: %w", err)
+	}
+
+	logger.Info("migrations applied", "count", len(applied))
+
+	return nil
+}
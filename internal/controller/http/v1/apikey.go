@@ -0,0 +1,135 @@
+package v1
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/skantay/todo-list/internal/entity"
+
+	"github.com/gin-gonic/gin"
+)
+
+// apiKeyUsecase определяет методы бизнес-логики для управления API-ключами.
+type apiKeyUsecase interface {
+	CreateAPIKey(ctx context.Context, name, scope string, expiresAt *time.Time) (entity.CreatedAPIKey, error)
+	ListAPIKeys(ctx context.Context) ([]entity.APIKey, error)
+	RevokeAPIKey(ctx context.Context, id string) error
+}
+
+// apiKeyRoutes определяет маршруты и их обработчики для API-ключей.
+type apiKeyRoutes struct {
+	apiKeyUsecase apiKeyUsecase // Использование usecase-ов
+	log           *slog.Logger  // Логгер
+}
+
+// newAPIKeyRoutes регистрирует эндпоинты для API-ключей.
+func newAPIKeyRoutes(router *gin.RouterGroup, apiKeyUsecase apiKeyUsecase, log *slog.Logger) {
+	apiKeyRoutes := apiKeyRoutes{
+		apiKeyUsecase: apiKeyUsecase,
+		log:           log,
+	}
+
+	router.GET("/api-keys", apiKeyRoutes.list) // Получение списка API-ключей
+
+	router.POST("/api-keys", apiKeyRoutes.create) // Создание API-ключа
+
+	router.DELETE("/api-keys/:id", apiKeyRoutes.revoke) // Отзыв API-ключа
+}
+
+// requestAPIKey определяет структуру тела запроса для создания API-ключа.
+type requestAPIKey struct {
+	Name string `json:"name" example:"CI"`
+	// Scope - область действия ключа, по умолчанию read_write
+	Scope string `json:"scope" enums:"read_write,read"`
+	// ExpiresAt - момент истечения ключа, без него ключ бессрочный
+	ExpiresAt *time.Time `json:"expiresAt"`
+}
+
+// list обрабатывает запрос на получение списка API-ключей.
+
+// @Summary List API keys
+// @Description API keys are managed only with an access token, an API key gets 403.
+// @Description Get API keys of the current user in creation order, including revoked and expired ones. Keys themselves are never shown again.
+// @Produce json
+// @Success 200 {array} entity.APIKey
+// @Failure 401 {object} problem
+// @Failure 500 {object} problem
+// @Security BearerAuth
+// @Router /api/v1/todo-list/api-keys [get]
+func (a apiKeyRoutes) list(c *gin.Context) {
+	keys, err := a.apiKeyUsecase.ListAPIKeys(c.Request.Context())
+	if err != nil {
+		a.respondError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, keys)
+}
+
+// create обрабатывает запрос на создание API-ключа.
+
+// @Summary Create API key
+// @Description API keys are managed only with an access token, an API key gets 403.
+// @Description Create an API key for scripts and services. The key is returned only in this response, store it right away.
+// @Description Pass it in the Authorization header as "ApiKey <key>". A read-scoped key may only send GET, HEAD and OPTIONS requests.
+// @Accept json
+// @Produce json
+// @Param requestAPIKey body requestAPIKey true "API key details"
+// @Success 201 {object} entity.CreatedAPIKey
+// @Failure 400 {object} problem
+// @Failure 422 {object} problem
+// @Failure 401 {object} problem
+// @Failure 403 {object} problem
+// @Failure 500 {object} problem
+// @Security BearerAuth
+// @Router /api/v1/todo-list/api-keys [post]
+func (a apiKeyRoutes) create(c *gin.Context) {
+	var req requestAPIKey
+
+	if err := bindJSON(c, &req); err != nil {
+		a.respondError(c, err)
+
+		return
+	}
+
+	key, err := a.apiKeyUsecase.CreateAPIKey(c.Request.Context(), req.Name, req.Scope, req.ExpiresAt)
+	if err != nil {
+		a.respondError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusCreated, key)
+}
+
+// revoke обрабатывает запрос на отзыв API-ключа.
+
+// @Summary Revoke API key
+// @Description API keys are managed only with an access token, an API key gets 403.
+// @Description Revoke an API key. It stops working immediately. Revoking a revoked key changes nothing.
+// @Param id path string true "API key ID"
+// @Success 204
+// @Failure 400 {object} problem
+// @Failure 404 {object} problem
+// @Failure 401 {object} problem
+// @Failure 403 {object} problem
+// @Failure 500 {object} problem
+// @Security BearerAuth
+// @Router /api/v1/todo-list/api-keys/{id} [delete]
+func (a apiKeyRoutes) revoke(c *gin.Context) {
+	if err := a.apiKeyUsecase.RevokeAPIKey(c.Request.Context(), c.Param("id")); err != nil {
+		a.respondError(c, err)
+
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// respondError логирует ошибку и отвечает телом application/problem+json.
+func (a apiKeyRoutes) respondError(c *gin.Context, err error) {
+	abortWithProblem(c, a.log, err)
+}
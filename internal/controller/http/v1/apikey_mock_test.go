@@ -0,0 +1,81 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/controller/http/v1/apikey.go
+
+// Package v1 is a generated GoMock package.
+package v1
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	entity "github.com/skantay/todo-list/internal/entity"
+)
+
+// MockapiKeyUsecase is a mock of apiKeyUsecase interface.
+type MockapiKeyUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockapiKeyUsecaseMockRecorder
+}
+
+// MockapiKeyUsecaseMockRecorder is the mock recorder for MockapiKeyUsecase.
+type MockapiKeyUsecaseMockRecorder struct {
+	mock *MockapiKeyUsecase
+}
+
+// NewMockapiKeyUsecase creates a new mock instance.
+func NewMockapiKeyUsecase(ctrl *gomock.Controller) *MockapiKeyUsecase {
+	mock := &MockapiKeyUsecase{ctrl: ctrl}
+	mock.recorder = &MockapiKeyUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockapiKeyUsecase) EXPECT() *MockapiKeyUsecaseMockRecorder {
+	return m.recorder
+}
+
+// CreateAPIKey mocks base method.
+func (m *MockapiKeyUsecase) CreateAPIKey(ctx context.Context, name, scope string, expiresAt *time.Time) (entity.CreatedAPIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAPIKey", ctx, name, scope, expiresAt)
+	ret0, _ := ret[0].(entity.CreatedAPIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAPIKey indicates an expected call of CreateAPIKey.
+func (mr *MockapiKeyUsecaseMockRecorder) CreateAPIKey(ctx, name, scope, expiresAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAPIKey", reflect.TypeOf((*MockapiKeyUsecase)(nil).CreateAPIKey), ctx, name, scope, expiresAt)
+}
+
+// ListAPIKeys mocks base method.
+func (m *MockapiKeyUsecase) ListAPIKeys(ctx context.Context) ([]entity.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAPIKeys", ctx)
+	ret0, _ := ret[0].([]entity.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAPIKeys indicates an expected call of ListAPIKeys.
+func (mr *MockapiKeyUsecaseMockRecorder) ListAPIKeys(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAPIKeys", reflect.TypeOf((*MockapiKeyUsecase)(nil).ListAPIKeys), ctx)
+}
+
+// RevokeAPIKey mocks base method.
+func (m *MockapiKeyUsecase) RevokeAPIKey(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAPIKey", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAPIKey indicates an expected call of RevokeAPIKey.
+func (mr *MockapiKeyUsecaseMockRecorder) RevokeAPIKey(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAPIKey", reflect.TypeOf((*MockapiKeyUsecase)(nil).RevokeAPIKey), ctx, id)
+}
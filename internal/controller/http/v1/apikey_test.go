@@ -0,0 +1,144 @@
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/skantay/todo-list/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const apiKeysPath = "/api/v1/todo-list/api-keys"
+
+// newAPIKeyTestRouter регистрирует маршруты API-ключей поверх мока usecase.
+func newAPIKeyTestRouter(t *testing.T) (*gin.Engine, *MockapiKeyUsecase) {
+	t.Helper()
+
+	gin.SetMode(gin.TestMode)
+	useJSONFieldNames()
+
+	ctrl := gomock.NewController(t)
+	apiKeyUsecase := NewMockapiKeyUsecase(ctrl)
+
+	router := gin.New()
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	newAPIKeyRoutes(router.Group("/api/v1/todo-list", requestID()), apiKeyUsecase, log)
+
+	return router, apiKeyUsecase
+}
+
+func Test_APIKeys(t *testing.T) {
+	createdAt := time.Date(2024, 4, 1, 9, 0, 0, 0, time.UTC)
+	expiresAt := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		setup      func(m *MockapiKeyUsecase)
+		wantStatus int
+		wantBody   string
+		wantCode   string
+		wantFields []fieldError
+	}{
+		{
+			name:   "#1 create",
+			method: http.MethodPost,
+			path:   apiKeysPath,
+			body:   `{"name":"CI","scope":"read","expiresAt":"2025-04-01T00:00:00Z"}`,
+			setup: func(m *MockapiKeyUsecase) {
+				m.EXPECT().CreateAPIKey(gomock.Any(), "CI", entity.ScopeRead, &expiresAt).Return(entity.CreatedAPIKey{
+					APIKey: entity.APIKey{ID: "661fbb485131cd932a981b29", Name: "CI", Prefix: "tdl_abcdefgh", Hash: "hash", Scope: entity.ScopeRead, CreatedAt: createdAt, ExpiresAt: &expiresAt},
+					Key:    "tdl_abcdefgh123",
+				}, nil)
+			},
+			wantStatus: http.StatusCreated,
+			wantBody:   `{"id":"661fbb485131cd932a981b29","name":"CI","prefix":"tdl_abcdefgh","scope":"read","createdAt":"2024-04-01T09:00:00Z","expiresAt":"2025-04-01T00:00:00Z","key":"tdl_abcdefgh123"}`,
+		},
+		{
+			name:   "#2 create with invalid scope",
+			method: http.MethodPost,
+			path:   apiKeysPath,
+			body:   `{"name":"CI","scope":"admin"}`,
+			setup: func(m *MockapiKeyUsecase) {
+				m.EXPECT().CreateAPIKey(gomock.Any(), "CI", "admin", nil).Return(entity.CreatedAPIKey{}, entity.NewValidationError("scope", "must be one of read_write, read", entity.ErrInvalidAPIKey))
+			},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantCode:   codeInvalidAPIKey,
+			wantFields: []fieldError{{Field: "scope", Message: "must be one of read_write, read"}},
+		},
+		{
+			name:   "#3 list",
+			method: http.MethodGet,
+			path:   apiKeysPath,
+			setup: func(m *MockapiKeyUsecase) {
+				m.EXPECT().ListAPIKeys(gomock.Any()).Return([]entity.APIKey{
+					{ID: "661fbb485131cd932a981b29", Name: "CI", Prefix: "tdl_abcdefgh", Hash: "hash", Scope: entity.ScopeReadWrite, CreatedAt: createdAt, RevokedAt: &expiresAt},
+				}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   `[{"id":"661fbb485131cd932a981b29","name":"CI","prefix":"tdl_abcdefgh","scope":"read_write","createdAt":"2024-04-01T09:00:00Z","revokedAt":"2025-04-01T00:00:00Z"}]`,
+		},
+		{
+			name:   "#4 revoke",
+			method: http.MethodDelete,
+			path:   apiKeysPath + "/661fbb485131cd932a981b29",
+			setup: func(m *MockapiKeyUsecase) {
+				m.EXPECT().RevokeAPIKey(gomock.Any(), "661fbb485131cd932a981b29").Return(nil)
+			},
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:   "#5 revoke missing key",
+			method: http.MethodDelete,
+			path:   apiKeysPath + "/661fbb485131cd932a981b29",
+			setup: func(m *MockapiKeyUsecase) {
+				m.EXPECT().RevokeAPIKey(gomock.Any(), gomock.Any()).Return(fmt.Errorf("failed: %w", entity.ErrAPIKeyNotFound))
+			},
+			wantStatus: http.StatusNotFound,
+			wantCode:   codeAPIKeyNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, apiKeyUsecase := newAPIKeyTestRouter(t)
+
+			if tt.setup != nil {
+				tt.setup(apiKeyUsecase)
+			}
+
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+
+			if tt.wantBody != "" {
+				assert.JSONEq(t, tt.wantBody, rec.Body.String())
+			}
+
+			if tt.wantCode == "" {
+				return
+			}
+
+			var p problem
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &p))
+			assert.Equal(t, tt.wantCode, p.Code)
+			assert.Equal(t, tt.wantFields, p.Errors)
+		})
+	}
+}
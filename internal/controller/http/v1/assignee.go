@@ -0,0 +1,79 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/skantay/todo-list/internal/entity"
+
+	"github.com/gin-gonic/gin"
+)
+
+// requestAssignees определяет тело запроса на назначение исполнителей.
+type requestAssignees struct {
+	// Assignees - id исполнителей, "me" - текущий пользователь, пустой список снимает исполнителей
+	Assignees []string `json:"assignees" example:"me"`
+}
+
+// assign обрабатывает запрос на назначение исполнителей задачи.
+
+// @Summary Assign task
+// @Description Replace the assignees of the task and record the change in the assignment history.
+// @Description An assignee must be the task owner or a user the task or its project is shared with. me stands for the current user.
+// @Description Requires the editor role. Sending the current assignees again changes nothing.
+// @Accept json
+// @Param id path string true "Task ID"
+// @Param requestAssignees body requestAssignees true "New assignees"
+// @Success 204
+// @Failure 400 {object} problem
+// @Failure 403 {object} problem
+// @Failure 404 {object} problem
+// @Failure 422 {object} problem
+// @Failure 401 {object} problem
+// @Failure 500 {object} problem
+// @Security BearerAuth
+// @Router /api/v1/todo-list/tasks/{id}/assignees [put]
+func (t taskRoutes) assign(c *gin.Context) {
+	var req requestAssignees
+
+	if err := bindJSON(c, &req); err != nil {
+		t.respondError(c, err)
+
+		return
+	}
+
+	if err := t.taskUsecase.Assign(c.Request.Context(), c.Param("id"), req.Assignees); err != nil {
+		t.respondError(c, err)
+
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// assignments обрабатывает запрос на получение истории назначений задачи.
+
+// @Summary Assignment history
+// @Description Get every change of the task assignees, from the oldest to the newest
+// @Produce json
+// @Param id path string true "Task ID"
+// @Success 200 {array} entity.Assignment
+// @Failure 400 {object} problem
+// @Failure 404 {object} problem
+// @Failure 401 {object} problem
+// @Failure 500 {object} problem
+// @Security BearerAuth
+// @Router /api/v1/todo-list/tasks/{id}/assignments [get]
+func (t taskRoutes) assignments(c *gin.Context) {
+	assignments, err := t.taskUsecase.Assignments(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		t.respondError(c, err)
+
+		return
+	}
+
+	if len(assignments) == 0 {
+		assignments = []entity.Assignment{}
+	}
+
+	c.JSON(http.StatusOK, assignments)
+}
@@ -0,0 +1,194 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/skantay/todo-list/internal/entity"
+
+	"github.com/gin-gonic/gin"
+)
+
+// userUsecase определяет методы бизнес-логики для регистрации и аутентификации пользователей.
+type userUsecase interface {
+	Register(ctx context.Context, email, password string) (string, error)
+	Login(ctx context.Context, email, password string) (entity.Token, error)
+	Authenticate(ctx context.Context, token string) (entity.Identity, error)
+	AuthenticateAPIKey(ctx context.Context, key string) (entity.APIKey, error)
+}
+
+// authRoutes определяет маршруты и их обработчики для регистрации и входа.
+type authRoutes struct {
+	userUsecase userUsecase  // Использование usecase-ов
+	log         *slog.Logger // Логгер
+}
+
+// newAuthRoutes регистрирует эндпоинты регистрации и входа, они доступны без токена.
+func newAuthRoutes(router *gin.RouterGroup, userUsecase userUsecase, log *slog.Logger) {
+	authRoutes := authRoutes{
+		userUsecase: userUsecase,
+		log:         log,
+	}
+
+	router.POST("/auth/register", authRoutes.register) // Регистрация пользователя
+
+	router.POST("/auth/login", authRoutes.login) // Вход и получение токена
+}
+
+// requestCredentials определяет структуру тела запроса регистрации и входа.
+type requestCredentials struct {
+	Email    string `json:"email" binding:"required" example:"user@example.com"`
+	Password string `json:"password" binding:"required" example:"correct horse battery staple"`
+}
+
+// register обрабатывает запрос на регистрацию пользователя.
+
+// @Summary Register
+// @Description Register a user with an email and a password of 8 to 72 bytes. Emails are case-insensitive and unique.
+// @Description The user becomes a member of the workspace from X-Tenant-ID.
+// @Accept json
+// @Produce json
+// @Param requestCredentials body requestCredentials true "Email and password"
+// @Success 201 {object} resp
+// @Failure 400 {object} problem
+// @Failure 409 {object} problem
+// @Failure 422 {object} problem
+// @Failure 500 {object} problem
+// @Router /api/v1/todo-list/auth/register [post]
+func (a authRoutes) register(c *gin.Context) {
+	var req requestCredentials
+
+	if err := bindJSON(c, &req); err != nil {
+		a.respondError(c, err)
+
+		return
+	}
+
+	id, err := a.userUsecase.Register(c.Request.Context(), req.Email, req.Password)
+	if err != nil {
+		a.respondError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusCreated, resp{ID: id})
+}
+
+// login обрабатывает запрос на вход пользователя.
+
+// @Summary Log in
+// @Description Exchange an email and a password for a signed access token (JWT).
+// @Description Pass the token in the Authorization header as "Bearer <token>" to every other endpoint.
+// @Description With X-Tenant-ID the token is issued for that workspace, logging into a workspace the user was neither registered in nor invited to gets 403.
+// @Accept json
+// @Produce json
+// @Param requestCredentials body requestCredentials true "Email and password"
+// @Success 200 {object} entity.Token
+// @Failure 400 {object} problem
+// @Failure 401 {object} problem
+// @Failure 403 {object} problem
+// @Failure 500 {object} problem
+// @Router /api/v1/todo-list/auth/login [post]
+func (a authRoutes) login(c *gin.Context) {
+	var req requestCredentials
+
+	if err := bindJSON(c, &req); err != nil {
+		a.respondError(c, err)
+
+		return
+	}
+
+	token, err := a.userUsecase.Login(c.Request.Context(), req.Email, req.Password)
+	if err != nil {
+		a.respondError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, token)
+}
+
+// respondError логирует ошибку и отвечает телом application/problem+json.
+func (a authRoutes) respondError(c *gin.Context, err error) {
+	abortWithProblem(c, a.log, err)
+}
+
+// authenticate пропускает только запросы с действительным токеном (Authorization: Bearer)
+// или API-ключом (Authorization: ApiKey). id пользователя и рабочее пространство сохраняются в контексте запроса,
+// по ним репозиторий ограничивает данные. Ключ только для чтения допускает лишь безопасные методы.
+func authenticate(userUsecase userUsecase, tenantUsecase tenantUsecase, log *slog.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		scheme, credentials, ok := strings.Cut(c.GetHeader(authorizationHeader), " ")
+		if !ok || credentials == "" {
+			abortWithProblem(c, log, entity.ErrUnauthorized)
+
+			return
+		}
+
+		var identity entity.Identity
+
+		switch {
+		case strings.EqualFold(scheme, bearerScheme):
+			var err error
+			if identity, err = userUsecase.Authenticate(c.Request.Context(), credentials); err != nil {
+				abortWithProblem(c, log, err)
+
+				return
+			}
+		case strings.EqualFold(scheme, apiKeyScheme):
+			key, err := userUsecase.AuthenticateAPIKey(c.Request.Context(), credentials)
+			if err != nil {
+				abortWithProblem(c, log, err)
+
+				return
+			}
+
+			if key.Scope == entity.ScopeRead && !isSafeMethod(c.Request.Method) {
+				abortWithProblem(c, log, fmt.Errorf("%w: api key is read-only", entity.ErrForbidden))
+
+				return
+			}
+
+			identity = entity.Identity{UserID: key.OwnerID, TenantID: key.TenantID}
+		default:
+			abortWithProblem(c, log, entity.ErrUnauthorized)
+
+			return
+		}
+
+		tenant, err := resolveTenant(c.Request.Context(), tenantUsecase, identity.TenantID)
+		if err != nil {
+			abortWithProblem(c, log, err)
+
+			return
+		}
+
+		ctx := entity.WithTenant(entity.WithUserID(c.Request.Context(), identity.UserID), tenant)
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+	}
+}
+
+// bearerOnly пропускает только запросы, прошедшие authenticate с токеном доступа.
+// Так API-ключом нельзя выпустить новый ключ или отозвать другие, и утёкший ключ не переживёт свой отзыв.
+func bearerOnly(log *slog.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		scheme, _, _ := strings.Cut(c.GetHeader(authorizationHeader), " ")
+		if !strings.EqualFold(scheme, bearerScheme) {
+			abortWithProblem(c, log, fmt.Errorf("%w: api keys are managed only with an access token", entity.ErrForbidden))
+
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// isSafeMethod сообщает, что метод только читает данные.
+func isSafeMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
@@ -0,0 +1,96 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/controller/http/v1/auth.go
+
+// Package v1 is a generated GoMock package.
+package v1
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entity "github.com/skantay/todo-list/internal/entity"
+)
+
+// MockuserUsecase is a mock of userUsecase interface.
+type MockuserUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockuserUsecaseMockRecorder
+}
+
+// MockuserUsecaseMockRecorder is the mock recorder for MockuserUsecase.
+type MockuserUsecaseMockRecorder struct {
+	mock *MockuserUsecase
+}
+
+// NewMockuserUsecase creates a new mock instance.
+func NewMockuserUsecase(ctrl *gomock.Controller) *MockuserUsecase {
+	mock := &MockuserUsecase{ctrl: ctrl}
+	mock.recorder = &MockuserUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockuserUsecase) EXPECT() *MockuserUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Authenticate mocks base method.
+func (m *MockuserUsecase) Authenticate(ctx context.Context, token string) (entity.Identity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authenticate", ctx, token)
+	ret0, _ := ret[0].(entity.Identity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Authenticate indicates an expected call of Authenticate.
+func (mr *MockuserUsecaseMockRecorder) Authenticate(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authenticate", reflect.TypeOf((*MockuserUsecase)(nil).Authenticate), ctx, token)
+}
+
+// AuthenticateAPIKey mocks base method.
+func (m *MockuserUsecase) AuthenticateAPIKey(ctx context.Context, key string) (entity.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthenticateAPIKey", ctx, key)
+	ret0, _ := ret[0].(entity.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthenticateAPIKey indicates an expected call of AuthenticateAPIKey.
+func (mr *MockuserUsecaseMockRecorder) AuthenticateAPIKey(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthenticateAPIKey", reflect.TypeOf((*MockuserUsecase)(nil).AuthenticateAPIKey), ctx, key)
+}
+
+// Login mocks base method.
+func (m *MockuserUsecase) Login(ctx context.Context, email, password string) (entity.Token, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Login", ctx, email, password)
+	ret0, _ := ret[0].(entity.Token)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Login indicates an expected call of Login.
+func (mr *MockuserUsecaseMockRecorder) Login(ctx, email, password interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockuserUsecase)(nil).Login), ctx, email, password)
+}
+
+// Register mocks base method.
+func (m *MockuserUsecase) Register(ctx context.Context, email, password string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Register", ctx, email, password)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Register indicates an expected call of Register.
+func (mr *MockuserUsecaseMockRecorder) Register(ctx, email, password interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockuserUsecase)(nil).Register), ctx, email, password)
+}
@@ -0,0 +1,356 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/skantay/todo-list/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const authPath = "/api/v1/todo-list/auth"
+
+// newAuthTestRouter регистрирует маршруты входа и защищённый маршрут, который отвечает id пользователя
+// и возвращает рабочее пространство запроса в заголовке X-Tenant-ID. Известны пространства platform и ops.
+func newAuthTestRouter(t *testing.T) (*gin.Engine, *MockuserUsecase) {
+	t.Helper()
+
+	gin.SetMode(gin.TestMode)
+	useJSONFieldNames()
+
+	ctrl := gomock.NewController(t)
+	userUsecase := NewMockuserUsecase(ctrl)
+	tenantUsecase := NewMocktenantUsecase(ctrl)
+
+	tenantUsecase.EXPECT().Tenant(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, id string) (entity.Tenant, error) {
+		switch id {
+		case "":
+			return entity.DefaultTenant(), nil
+		case "platform", "ops":
+			return entity.Tenant{ID: id}, nil
+		default:
+			return entity.Tenant{}, fmt.Errorf("%w: unknown workspace %q", entity.ErrInvalidTenant, id)
+		}
+	}).AnyTimes()
+
+	router := gin.New()
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	newAuthRoutes(router.Group("/api/v1/todo-list", requestID()), userUsecase, log)
+
+	router.Any("/private", tenant(tenantUsecase, log), authenticate(userUsecase, tenantUsecase, log), func(c *gin.Context) {
+		c.Header(tenantHeader, entity.TenantIDFromContext(c.Request.Context()))
+		c.String(http.StatusOK, entity.UserIDFromContext(c.Request.Context()))
+	})
+
+	router.Any("/private/bearer", tenant(tenantUsecase, log), authenticate(userUsecase, tenantUsecase, log), bearerOnly(log), func(c *gin.Context) {
+		c.Header(tenantHeader, entity.TenantIDFromContext(c.Request.Context()))
+		c.String(http.StatusOK, entity.UserIDFromContext(c.Request.Context()))
+	})
+
+	return router, userUsecase
+}
+
+func Test_Authenticate(t *testing.T) {
+	tests := []struct {
+		name          string
+		method        string
+		path          string
+		authorization string
+		tenant        string
+		setup         func(m *MockuserUsecase)
+		wantStatus    int
+		wantCode      string
+		wantTenant    string
+	}{
+		{
+			name:          "#1 valid token",
+			authorization: "Bearer token",
+			setup: func(m *MockuserUsecase) {
+				m.EXPECT().Authenticate(gomock.Any(), "token").Return(entity.Identity{UserID: "661fbb485131cd932a981b28"}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "#2 missing header",
+			wantStatus: http.StatusUnauthorized,
+			wantCode:   codeUnauthorized,
+		},
+		{
+			name:          "#3 another scheme",
+			authorization: "Basic dXNlcjpwYXNz",
+			wantStatus:    http.StatusUnauthorized,
+			wantCode:      codeUnauthorized,
+		},
+		{
+			name:          "#4 invalid token",
+			authorization: "Bearer expired",
+			setup: func(m *MockuserUsecase) {
+				m.EXPECT().Authenticate(gomock.Any(), "expired").Return(entity.Identity{}, fmt.Errorf("%w: token is expired", entity.ErrUnauthorized))
+			},
+			wantStatus: http.StatusUnauthorized,
+			wantCode:   codeUnauthorized,
+		},
+		{
+			name:          "#5 valid api key",
+			method:        http.MethodPost,
+			authorization: "ApiKey tdl_key",
+			setup: func(m *MockuserUsecase) {
+				m.EXPECT().AuthenticateAPIKey(gomock.Any(), "tdl_key").Return(entity.APIKey{OwnerID: "661fbb485131cd932a981b28", Scope: entity.ScopeReadWrite}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:          "#6 read-only api key reads",
+			authorization: "apikey tdl_key",
+			setup: func(m *MockuserUsecase) {
+				m.EXPECT().AuthenticateAPIKey(gomock.Any(), "tdl_key").Return(entity.APIKey{OwnerID: "661fbb485131cd932a981b28", Scope: entity.ScopeRead}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:          "#7 read-only api key writes",
+			method:        http.MethodDelete,
+			authorization: "ApiKey tdl_key",
+			setup: func(m *MockuserUsecase) {
+				m.EXPECT().AuthenticateAPIKey(gomock.Any(), "tdl_key").Return(entity.APIKey{OwnerID: "661fbb485131cd932a981b28", Scope: entity.ScopeRead}, nil)
+			},
+			wantStatus: http.StatusForbidden,
+			wantCode:   codeForbidden,
+		},
+		{
+			name:          "#8 revoked api key",
+			authorization: "ApiKey tdl_revoked",
+			setup: func(m *MockuserUsecase) {
+				m.EXPECT().AuthenticateAPIKey(gomock.Any(), "tdl_revoked").Return(entity.APIKey{}, fmt.Errorf("%w: api key is revoked or expired", entity.ErrUnauthorized))
+			},
+			wantStatus: http.StatusUnauthorized,
+			wantCode:   codeUnauthorized,
+		},
+		{
+			name:          "#9 token of workspace",
+			authorization: "Bearer token",
+			setup: func(m *MockuserUsecase) {
+				m.EXPECT().Authenticate(gomock.Any(), "token").Return(entity.Identity{UserID: "661fbb485131cd932a981b28", TenantID: "platform"}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantTenant: "platform",
+		},
+		{
+			name:          "#10 workspace from header",
+			authorization: "Bearer token",
+			tenant:        "ops",
+			setup: func(m *MockuserUsecase) {
+				m.EXPECT().Authenticate(gomock.Any(), "token").Return(entity.Identity{UserID: "661fbb485131cd932a981b28"}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantTenant: "ops",
+		},
+		{
+			name:          "#11 header of another workspace",
+			authorization: "Bearer token",
+			tenant:        "ops",
+			setup: func(m *MockuserUsecase) {
+				m.EXPECT().Authenticate(gomock.Any(), "token").Return(entity.Identity{UserID: "661fbb485131cd932a981b28", TenantID: "platform"}, nil)
+			},
+			wantStatus: http.StatusForbidden,
+			wantCode:   codeForbidden,
+		},
+		{
+			name:          "#12 unknown workspace",
+			authorization: "Bearer token",
+			tenant:        "sales",
+			wantStatus:    http.StatusBadRequest,
+			wantCode:      codeInvalidTenant,
+		},
+		{
+			name:          "#13 api key of workspace",
+			authorization: "ApiKey tdl_key",
+			setup: func(m *MockuserUsecase) {
+				m.EXPECT().AuthenticateAPIKey(gomock.Any(), "tdl_key").
+					Return(entity.APIKey{OwnerID: "661fbb485131cd932a981b28", TenantID: "ops", Scope: entity.ScopeRead}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantTenant: "ops",
+		},
+		{
+			name:          "#14 token on bearer only route",
+			method:        http.MethodPost,
+			path:          "/private/bearer",
+			authorization: "Bearer token",
+			setup: func(m *MockuserUsecase) {
+				m.EXPECT().Authenticate(gomock.Any(), "token").Return(entity.Identity{UserID: "661fbb485131cd932a981b28"}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:          "#15 api key on bearer only route",
+			method:        http.MethodPost,
+			path:          "/private/bearer",
+			authorization: "ApiKey tdl_key",
+			setup: func(m *MockuserUsecase) {
+				m.EXPECT().AuthenticateAPIKey(gomock.Any(), "tdl_key").Return(entity.APIKey{OwnerID: "661fbb485131cd932a981b28", Scope: entity.ScopeReadWrite}, nil)
+			},
+			wantStatus: http.StatusForbidden,
+			wantCode:   codeForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, userUsecase := newAuthTestRouter(t)
+
+			if tt.setup != nil {
+				tt.setup(userUsecase)
+			}
+
+			if tt.method == "" {
+				tt.method = http.MethodGet
+			}
+
+			if tt.path == "" {
+				tt.path = "/private"
+			}
+
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.authorization != "" {
+				req.Header.Set(authorizationHeader, tt.authorization)
+			}
+			if tt.tenant != "" {
+				req.Header.Set(tenantHeader, tt.tenant)
+			}
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+
+			if tt.wantCode == "" {
+				assert.Equal(t, "661fbb485131cd932a981b28", rec.Body.String())
+				assert.Equal(t, tt.wantTenant, rec.Header().Get(tenantHeader))
+
+				return
+			}
+
+			if tt.wantStatus == http.StatusUnauthorized {
+				assert.Equal(t, bearerScheme, rec.Header().Get("WWW-Authenticate"))
+			}
+
+			var p problem
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &p))
+			assert.Equal(t, tt.wantCode, p.Code)
+		})
+	}
+}
+
+func Test_AuthRoutes(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		body       string
+		setup      func(m *MockuserUsecase)
+		wantStatus int
+		wantBody   string
+		wantCode   string
+	}{
+		{
+			name: "#1 register",
+			path: authPath + "/register",
+			body: `{"email":"user@example.com","password":"password"}`,
+			setup: func(m *MockuserUsecase) {
+				m.EXPECT().Register(gomock.Any(), "user@example.com", "password").Return("661fbb485131cd932a981b28", nil)
+			},
+			wantStatus: http.StatusCreated,
+			wantBody:   `{"id":"661fbb485131cd932a981b28"}`,
+		},
+		{
+			name: "#2 register existing email",
+			path: authPath + "/register",
+			body: `{"email":"user@example.com","password":"password"}`,
+			setup: func(m *MockuserUsecase) {
+				m.EXPECT().Register(gomock.Any(), gomock.Any(), gomock.Any()).Return("", fmt.Errorf("failed: %w", entity.ErrUserExists))
+			},
+			wantStatus: http.StatusConflict,
+			wantCode:   codeUserExists,
+		},
+		{
+			name:       "#3 register without password",
+			path:       authPath + "/register",
+			body:       `{"email":"user@example.com"}`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   codeInvalidRequest,
+		},
+		{
+			name: "#4 login",
+			path: authPath + "/login",
+			body: `{"email":"user@example.com","password":"password"}`,
+			setup: func(m *MockuserUsecase) {
+				m.EXPECT().Login(gomock.Any(), "user@example.com", "password").Return(entity.Token{
+					AccessToken: "token",
+					TokenType:   "Bearer",
+					ExpiresAt:   time.Date(2024, 4, 2, 0, 0, 0, 0, time.UTC),
+				}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"accessToken":"token","tokenType":"Bearer","expiresAt":"2024-04-02T00:00:00Z"}`,
+		},
+		{
+			name: "#5 login wrong password",
+			path: authPath + "/login",
+			body: `{"email":"user@example.com","password":"wrong password"}`,
+			setup: func(m *MockuserUsecase) {
+				m.EXPECT().Login(gomock.Any(), gomock.Any(), gomock.Any()).Return(entity.Token{}, entity.ErrInvalidCredentials)
+			},
+			wantStatus: http.StatusUnauthorized,
+			wantCode:   codeInvalidCredentials,
+		},
+		{
+			name: "#6 login to a foreign workspace",
+			path: authPath + "/login",
+			body: `{"email":"user@example.com","password":"password"}`,
+			setup: func(m *MockuserUsecase) {
+				m.EXPECT().Login(gomock.Any(), gomock.Any(), gomock.Any()).Return(entity.Token{}, fmt.Errorf("%w: not a member of workspace \"platform\"", entity.ErrForbidden))
+			},
+			wantStatus: http.StatusForbidden,
+			wantCode:   codeForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, userUsecase := newAuthTestRouter(t)
+
+			if tt.setup != nil {
+				tt.setup(userUsecase)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+
+			if tt.wantCode == "" {
+				assert.JSONEq(t, tt.wantBody, rec.Body.String())
+
+				return
+			}
+
+			var p problem
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &p))
+			assert.Equal(t, tt.wantCode, p.Code)
+		})
+	}
+}
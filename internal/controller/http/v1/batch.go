@@ -0,0 +1,171 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/skantay/todo-list/internal/entity"
+
+	"github.com/gin-gonic/gin"
+)
+
+// batchAction - метод коллекции задач в пути /tasks:batch
+const batchAction = ":batch"
+
+// requestBatch определяет тело пакетного запроса.
+type requestBatch struct {
+	// Atomic - выполнить все операции или ни одной
+	Atomic     bool               `json:"atomic"`
+	Operations []requestOperation `json:"operations" binding:"required"`
+}
+
+// requestOperation определяет одну операцию пакета.
+type requestOperation struct {
+	Op string `json:"op" enums:"create,update,done,delete"`
+	// ID - id задачи для update, done и delete
+	ID string `json:"id"`
+	// Version - ожидаемая версия задачи как в If-Match, 0 - любая
+	Version int64 `json:"version" example:"3"`
+	// Task - поля задачи для create и update
+	Task *requestTask `json:"task"`
+}
+
+// operation преобразует операцию из тела запроса в операцию пакета.
+func (r requestOperation) operation() (entity.BatchOperation, error) {
+	operation := entity.BatchOperation{
+		Op:      r.Op,
+		ID:      r.ID,
+		Version: r.Version,
+	}
+
+	if r.Version < entity.AnyVersion {
+		return operation, entity.NewValidationError("version", "must be a positive task version", entity.ErrInvalidVersion)
+	}
+
+	if r.Op != entity.BatchCreate && r.Op != entity.BatchUpdate {
+		return operation, nil
+	}
+
+	if r.Task == nil {
+		return operation, entity.NewValidationError("task", "is required for "+r.Op, errInvalidRequest)
+	}
+
+	task, err := r.Task.task()
+	if err != nil {
+		return operation, err
+	}
+	operation.Task = task
+
+	return operation, nil
+}
+
+// batchResult определяет результат операции пакета.
+// Status - HTTP статус, который вернул бы одиночный запрос, Code - код ошибки как в problem.
+type batchResult struct {
+	Index  int    `json:"index" example:"0"`
+	ID     string `json:"id,omitempty" example:"661fbb485131cd932a981b26"`
+	Status int    `json:"status" example:"404"`
+	Code   string `json:"code,omitempty" example:"task_not_found"`
+	Detail string `json:"detail,omitempty" example:"task not found"`
+}
+
+// batchResponse определяет ответ на пакетный запрос.
+type batchResponse struct {
+	Results []batchResult `json:"results"`
+}
+
+// action разбирает метод коллекции задач из пути /tasks:<метод>.
+// gin не умеет экранировать двоеточие в пути, поэтому метод приходит параметром вместе с двоеточием.
+func (t taskRoutes) action(c *gin.Context) {
+	switch c.Param("action") {
+	case batchAction:
+		t.batch(c)
+	default:
+		c.AbortWithStatus(http.StatusNotFound)
+	}
+}
+
+// batch обрабатывает пакетный запрос.
+
+// @Summary Batch tasks
+// @Description Run a list of create, update, done and delete operations with one bulk write.
+// @Description Every operation is checked as the single request would be and gets its own result with the HTTP status and error code that request would return.
+// @Description Without atomic the other operations run when one fails; with atomic either all of them are applied or none, and the rest get 424 batch_aborted.
+// @Description Atomic batches in MongoDB run in a transaction and need a replica set.
+// @Description version works as If-Match. A task can be changed by only one operation of the batch.
+// @Description done refuses tasks with open checklist items or subtasks even with cascading completion; the next occurrence of a recurring task is created after the batch is written.
+// @Description The number of operations is limited by the tasks.maxBatch setting, 100 by default.
+// @Accept json
+// @Produce json
+// @Param requestBatch body requestBatch true "Operations"
+// @Success 200 {object} batchResponse
+// @Failure 400 {object} problem
+// @Failure 401 {object} problem
+// @Failure 413 {object} problem
+// @Failure 422 {object} problem
+// @Failure 500 {object} problem
+// @Security BearerAuth
+// @Router /api/v1/todo-list/tasks:batch [post]
+func (t taskRoutes) batch(c *gin.Context) {
+	var req requestBatch
+
+	if err := bindJSON(c, &req); err != nil {
+		t.respondError(c, err)
+
+		return
+	}
+
+	operations := make([]entity.BatchOperation, 0, len(req.Operations))
+
+	for i, op := range req.Operations {
+		operation, err := op.operation()
+		if err != nil {
+			t.respondError(c, atOperation(i, err))
+
+			return
+		}
+
+		operations = append(operations, operation)
+	}
+
+	results, err := t.taskUsecase.Batch(c.Request.Context(), operations, req.Atomic)
+	if err != nil {
+		t.respondError(c, err)
+
+		return
+	}
+
+	response := batchResponse{Results: make([]batchResult, 0, len(results))}
+
+	for i, result := range results {
+		item := batchResult{Index: i, ID: result.ID, Status: http.StatusNoContent}
+		if operations[i].Op == entity.BatchCreate {
+			item.Status = http.StatusCreated
+		}
+
+		if result.Err != nil {
+			item.Status, item.Code, item.Detail = lookupError(result.Err)
+
+			if item.Status >= http.StatusInternalServerError {
+				t.log.Error("batch operation failed", "index", i, "error", result.Err, "requestId", c.GetString(requestIDKey))
+			}
+		}
+
+		response.Results = append(response.Results, item)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// atOperation указывает в ошибке номер операции пакета, с которой она связана.
+func atOperation(i int, err error) error {
+	var validationError entity.ValidationError
+	if errors.As(err, &validationError) {
+		validationError.Field = fmt.Sprintf("operations[%d].%s", i, validationError.Field)
+
+		return validationError
+	}
+
+	return fmt.Errorf("operations[%d]: %w", i, err)
+}
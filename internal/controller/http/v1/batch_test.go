@@ -0,0 +1,143 @@
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/skantay/todo-list/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Batch(t *testing.T) {
+	activeAt := entity.DateOf(time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC))
+
+	// Приоритет по умолчанию подставляет usecase
+	created := entity.NewTask("new", activeAt)
+	created.Priority = ""
+
+	tests := []struct {
+		name        string
+		path        string
+		body        string
+		setup       func(m *MocktaskUsecase)
+		wantStatus  int
+		wantCode    string
+		wantFields  []fieldError
+		wantResults []batchResult
+	}{
+		{
+			name: "#1 results",
+			path: tasksPath + batchAction,
+			body: `{"operations":[
+				{"op":"create","task":{"title":"new","activeAt":"2024-04-01"}},
+				{"op":"done","id":"` + taskID + `","version":2},
+				{"op":"delete","id":"missing"}
+			]}`,
+			setup: func(m *MocktaskUsecase) {
+				m.EXPECT().Batch(gomock.Any(), []entity.BatchOperation{
+					{Op: entity.BatchCreate, Task: created},
+					{Op: entity.BatchDone, ID: taskID, Version: 2},
+					{Op: entity.BatchDelete, ID: "missing"},
+				}, false).Return([]entity.BatchResult{
+					{ID: "1"},
+					{ID: taskID},
+					{Err: fmt.Errorf("failed to delete task: %w", entity.ErrTaskNotFound)},
+				}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantResults: []batchResult{
+				{Index: 0, ID: "1", Status: http.StatusCreated},
+				{Index: 1, ID: taskID, Status: http.StatusNoContent},
+				{Index: 2, Status: http.StatusNotFound, Code: codeTaskNotFound, Detail: entity.ErrTaskNotFound.Error()},
+			},
+		},
+		{
+			name: "#2 atomic batch aborted",
+			path: tasksPath + batchAction,
+			body: `{"atomic":true,"operations":[{"op":"delete","id":"1"},{"op":"delete","id":"2"}]}`,
+			setup: func(m *MocktaskUsecase) {
+				m.EXPECT().Batch(gomock.Any(), gomock.Any(), true).Return([]entity.BatchResult{
+					{Err: entity.ErrBatchAborted},
+					{Err: entity.ErrVersionMismatch},
+				}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantResults: []batchResult{
+				{Index: 0, Status: http.StatusFailedDependency, Code: codeBatchAborted, Detail: entity.ErrBatchAborted.Error()},
+				{Index: 1, Status: http.StatusPreconditionFailed, Code: codeVersionMismatch, Detail: entity.ErrVersionMismatch.Error()},
+			},
+		},
+		{
+			name:       "#3 invalid date",
+			path:       tasksPath + batchAction,
+			body:       `{"operations":[{"op":"delete","id":"1"},{"op":"update","id":"2","task":{"title":"t","activeAt":"01.04.2024"}}]}`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   codeInvalidDate,
+		},
+		{
+			name:       "#4 task is required",
+			path:       tasksPath + batchAction,
+			body:       `{"operations":[{"op":"create"}]}`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   codeInvalidRequest,
+			wantFields: []fieldError{{Field: "operations[0].task", Message: "is required for create"}},
+		},
+		{
+			name: "#5 too large",
+			path: tasksPath + batchAction,
+			body: `{"operations":[{"op":"delete","id":"1"}]}`,
+			setup: func(m *MocktaskUsecase) {
+				m.EXPECT().Batch(gomock.Any(), gomock.Any(), false).
+					Return(nil, fmt.Errorf("%w: operations must not contain more than 100 operations", entity.ErrBatchTooLarge))
+			},
+			wantStatus: http.StatusRequestEntityTooLarge,
+			wantCode:   codeBatchTooLarge,
+		},
+		{
+			name:       "#6 unknown action",
+			path:       tasksPath + ":purge",
+			body:       `{"operations":[]}`,
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, taskUsecase := newTestRouter(t)
+			if tt.setup != nil {
+				tt.setup(taskUsecase)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+
+			if tt.wantResults != nil {
+				var response batchResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+				assert.Equal(t, tt.wantResults, response.Results)
+			}
+
+			if tt.wantCode != "" {
+				var p problem
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &p))
+				assert.Equal(t, tt.wantCode, p.Code)
+
+				if tt.wantFields != nil {
+					assert.Equal(t, tt.wantFields, p.Errors)
+				}
+			}
+		})
+	}
+}
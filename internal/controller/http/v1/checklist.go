@@ -0,0 +1,142 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/skantay/todo-list/internal/entity"
+
+	"github.com/gin-gonic/gin"
+)
+
+// requestChecklistItem определяет тело запроса на добавление пункта чек-листа.
+type requestChecklistItem struct {
+	Title string `json:"title" binding:"required"`
+}
+
+// requestChecklistOrder определяет тело запроса на изменение порядка пунктов чек-листа.
+type requestChecklistOrder struct {
+	// Items - id всех пунктов чек-листа в новом порядке
+	Items []string `json:"items" binding:"required"`
+}
+
+// addChecklistItem обрабатывает запрос на добавление пункта в конец чек-листа.
+
+// @Summary Add checklist item
+// @Description Append a new unchecked item to the task checklist
+// @Accept json
+// @Produce json
+// @Param id path string true "Task ID"
+// @Param requestChecklistItem body requestChecklistItem true "Checklist item"
+// @Success 201 {object} entity.ChecklistItem
+// @Failure 400 {object} problem
+// @Failure 404 {object} problem
+// @Failure 422 {object} problem
+// @Failure 401 {object} problem
+// @Failure 500 {object} problem
+// @Security BearerAuth
+// @Router /api/v1/todo-list/tasks/{id}/checklist [post]
+func (t taskRoutes) addChecklistItem(c *gin.Context) {
+	var req requestChecklistItem
+
+	if err := bindJSON(c, &req); err != nil {
+		t.respondError(c, err)
+
+		return
+	}
+
+	item, err := t.taskUsecase.AddChecklistItem(c.Request.Context(), c.Param("id"), req.Title)
+	if err != nil {
+		t.respondError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusCreated, item)
+}
+
+// reorderChecklist обрабатывает запрос на изменение порядка пунктов чек-листа.
+
+// @Summary Reorder checklist
+// @Description Put the checklist items in the given order. items must list every item of the checklist exactly once.
+// @Accept json
+// @Produce json
+// @Param id path string true "Task ID"
+// @Param requestChecklistOrder body requestChecklistOrder true "Item IDs in the new order"
+// @Success 200 {array} entity.ChecklistItem
+// @Failure 400 {object} problem
+// @Failure 404 {object} problem
+// @Failure 422 {object} problem
+// @Failure 401 {object} problem
+// @Failure 500 {object} problem
+// @Security BearerAuth
+// @Router /api/v1/todo-list/tasks/{id}/checklist [put]
+func (t taskRoutes) reorderChecklist(c *gin.Context) {
+	var req requestChecklistOrder
+
+	if err := bindJSON(c, &req); err != nil {
+		t.respondError(c, err)
+
+		return
+	}
+
+	checklist, err := t.taskUsecase.ReorderChecklist(c.Request.Context(), c.Param("id"), req.Items)
+	if err != nil {
+		t.respondError(c, err)
+
+		return
+	}
+
+	if len(checklist) == 0 {
+		checklist = []entity.ChecklistItem{}
+	}
+
+	c.JSON(http.StatusOK, checklist)
+}
+
+// toggleChecklistItem обрабатывает запрос на смену отметки пункта чек-листа.
+
+// @Summary Toggle checklist item
+// @Description Check an unchecked checklist item or uncheck a checked one
+// @Produce json
+// @Param id path string true "Task ID"
+// @Param itemId path string true "Checklist item ID"
+// @Success 200 {object} entity.ChecklistItem
+// @Failure 400 {object} problem
+// @Failure 404 {object} problem
+// @Failure 401 {object} problem
+// @Failure 500 {object} problem
+// @Security BearerAuth
+// @Router /api/v1/todo-list/tasks/{id}/checklist/{itemId}/toggle [put]
+func (t taskRoutes) toggleChecklistItem(c *gin.Context) {
+	item, err := t.taskUsecase.ToggleChecklistItem(c.Request.Context(), c.Param("id"), c.Param("itemId"))
+	if err != nil {
+		t.respondError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, item)
+}
+
+// deleteChecklistItem обрабатывает запрос на удаление пункта чек-листа.
+
+// @Summary Delete checklist item
+// @Description Remove an item from the task checklist
+// @Param id path string true "Task ID"
+// @Param itemId path string true "Checklist item ID"
+// @Success 204
+// @Failure 400 {object} problem
+// @Failure 404 {object} problem
+// @Failure 401 {object} problem
+// @Failure 500 {object} problem
+// @Security BearerAuth
+// @Router /api/v1/todo-list/tasks/{id}/checklist/{itemId} [delete]
+func (t taskRoutes) deleteChecklistItem(c *gin.Context) {
+	if err := t.taskUsecase.DeleteChecklistItem(c.Request.Context(), c.Param("id"), c.Param("itemId")); err != nil {
+		t.respondError(c, err)
+
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,97 @@
+package v1
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/skantay/todo-list/internal/entity"
+
+	"github.com/gin-gonic/gin"
+)
+
+// next обрабатывает запрос на получение задач, которые можно начать.
+
+// @Summary Next actionable tasks
+// @Description Get active tasks whose activeAt has come and whose blockers are all done or cancelled, ordered by activeAt.
+// @Param limit query int false "Maximum number of tasks, from 1 to 100" default(50)
+// @Param X-Time-Zone header string false "IANA time zone used for dates without time, e.g. Asia/Almaty" default(UTC)
+// @Produce json
+// @Success 200 {array} entity.Task
+// @Failure 400 {object} problem
+// @Failure 401 {object} problem
+// @Failure 500 {object} problem
+// @Security BearerAuth
+// @Router /api/v1/todo-list/tasks/next [get]
+func (t taskRoutes) next(c *gin.Context) {
+	var limit int
+
+	if raw := c.Query("limit"); raw != "" {
+		var err error
+		if limit, err = strconv.Atoi(raw); err != nil || limit < 1 {
+			t.respondError(c, entity.NewValidationError("limit", "must be a positive integer", entity.ErrInvalidLimit))
+
+			return
+		}
+	}
+
+	tasks, err := t.taskUsecase.Next(c.Request.Context(), limit)
+	if err != nil {
+		t.respondError(c, err)
+
+		return
+	}
+
+	if len(tasks) == 0 {
+		tasks = []entity.Task{}
+	}
+
+	c.JSON(http.StatusOK, tasks)
+}
+
+// addBlocker обрабатывает запрос на добавление блокирующей задачи.
+
+// @Summary Add blocker
+// @Description Declare that the task blockerId blocks the task id: the task cannot be marked as done while the blocker is open.
+// @Description A dependency that would create a cycle is refused. Adding an existing blocker again changes nothing.
+// @Param id path string true "Task ID"
+// @Param blockerId path string true "Blocking task ID"
+// @Success 204
+// @Failure 400 {object} problem
+// @Failure 404 {object} problem
+// @Failure 422 {object} problem
+// @Failure 401 {object} problem
+// @Failure 500 {object} problem
+// @Security BearerAuth
+// @Router /api/v1/todo-list/tasks/{id}/blockers/{blockerId} [put]
+func (t taskRoutes) addBlocker(c *gin.Context) {
+	if err := t.taskUsecase.AddBlocker(c.Request.Context(), c.Param("id"), c.Param("blockerId")); err != nil {
+		t.respondError(c, err)
+
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// removeBlocker обрабатывает запрос на снятие блокировки.
+
+// @Summary Remove blocker
+// @Description Remove the dependency on the task blockerId. Removing a missing blocker changes nothing.
+// @Param id path string true "Task ID"
+// @Param blockerId path string true "Blocking task ID"
+// @Success 204
+// @Failure 400 {object} problem
+// @Failure 404 {object} problem
+// @Failure 401 {object} problem
+// @Failure 500 {object} problem
+// @Security BearerAuth
+// @Router /api/v1/todo-list/tasks/{id}/blockers/{blockerId} [delete]
+func (t taskRoutes) removeBlocker(c *gin.Context) {
+	if err := t.taskUsecase.RemoveBlocker(c.Request.Context(), c.Param("id"), c.Param("blockerId")); err != nil {
+		t.respondError(c, err)
+
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,278 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/skantay/todo-list/internal/entity"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// problemContentType - тип содержимого ответа об ошибке согласно RFC 7807.
+const problemContentType = "application/problem+json"
+
+// Стабильные машиночитаемые коды ошибок API
+const (
+	codeAlreadyExists      = "task_already_exists"
+	codeTaskNotFound       = "task_not_found"
+	codeInvalidTitle       = "invalid_title"
+	codeInvalidDescription = "invalid_description"
+	codeInvalidPriority    = "invalid_priority"
+	codeInvalidTags        = "invalid_tags"
+	codeInvalidStatus      = "invalid_status"
+	codeInvalidTransition  = "invalid_transition"
+	codeOpenSubtasks       = "open_subtasks"
+	codeTaskBlocked        = "task_blocked"
+	codeInvalidBlocker     = "invalid_blocker"
+	codeDependencyCycle    = "dependency_cycle"
+	codeInvalidParent      = "invalid_parent"
+	codeInvalidChecklist   = "invalid_checklist"
+	codeChecklistNotFound  = "checklist_item_not_found"
+	codeProjectExists      = "project_already_exists"
+	codeProjectNotFound    = "project_not_found"
+	codeInvalidProject     = "invalid_project"
+	codeInvalidMode        = "invalid_mode"
+	codeUnauthorized       = "unauthorized"
+	codeInvalidCredentials = "invalid_credentials"
+	codeUserExists         = "user_already_exists"
+	codeInvalidEmail       = "invalid_email"
+	codeInvalidPassword    = "invalid_password"
+	codeForbidden          = "forbidden"
+	codeAPIKeyNotFound     = "api_key_not_found"
+	codeInvalidAPIKey      = "invalid_api_key"
+	codeShareNotFound      = "share_not_found"
+	codeShareExists        = "share_already_exists"
+	codeInvalidShare       = "invalid_share"
+	codeInvalidAssignee    = "invalid_assignee"
+	codeInvalidTenant      = "invalid_tenant"
+	codeVersionMismatch    = "version_mismatch"
+	codeInvalidVersion     = "invalid_version"
+	codeInvalidPatch       = "invalid_patch"
+	codeInvalidBatch       = "invalid_batch"
+	codeBatchTooLarge      = "batch_too_large"
+	codeBatchAborted       = "batch_aborted"
+	codeUnsupportedMedia   = "unsupported_media_type"
+	codeInvalidID          = "invalid_id"
+	codeInvalidDate        = "invalid_date"
+	codeInvalidTimeZone    = "invalid_time_zone"
+	codeInvalidDueDate     = "invalid_due_date"
+	codeInvalidRecurrence  = "invalid_recurrence"
+	codeInvalidCursor      = "invalid_cursor"
+	codeInvalidLimit       = "invalid_limit"
+	codeInvalidSort        = "invalid_sort"
+	codeInvalidFilter      = "invalid_filter"
+	codeInvalidQuery       = "invalid_query"
+	codeInvalidRequest     = "invalid_request"
+	codeInternal           = "internal_error"
+)
+
+// errInvalidRequest оборачивает ошибки разбора и валидации тела запроса.
+var errInvalidRequest = errors.New("invalid request")
+
+// errUnsupportedMediaType - тело запроса в формате, который эндпоинт не принимает.
+var errUnsupportedMediaType = errors.New("unsupported media type")
+
+// errorMapping - единая таблица соответствия ошибок кодам API и HTTP статусам:
+// 400 - некорректный ввод, 401 - нет аутентификации, 403 - нет прав, 404 - не найдено, 409 - конфликт,
+// 412 - не совпала версия из If-Match, 413 - слишком большой пакет, 415 - неподдерживаемый формат тела,
+// 422 - семантическая валидация, 424 - операция атомарного пакета не выполнена из-за другой.
+var errorMapping = []struct {
+	err    error
+	code   string
+	status int
+}{
+	{entity.ErrUnauthorized, codeUnauthorized, http.StatusUnauthorized},
+	{entity.ErrInvalidCredentials, codeInvalidCredentials, http.StatusUnauthorized},
+	{entity.ErrUserExists, codeUserExists, http.StatusConflict},
+	{entity.ErrInvalidEmail, codeInvalidEmail, http.StatusUnprocessableEntity},
+	{entity.ErrInvalidPassword, codeInvalidPassword, http.StatusUnprocessableEntity},
+	{entity.ErrForbidden, codeForbidden, http.StatusForbidden},
+	{entity.ErrAPIKeyNotFound, codeAPIKeyNotFound, http.StatusNotFound},
+	{entity.ErrInvalidAPIKey, codeInvalidAPIKey, http.StatusUnprocessableEntity},
+	{entity.ErrShareNotFound, codeShareNotFound, http.StatusNotFound},
+	{entity.ErrShareExists, codeShareExists, http.StatusConflict},
+	{entity.ErrInvalidShare, codeInvalidShare, http.StatusUnprocessableEntity},
+	{entity.ErrInvalidAssignee, codeInvalidAssignee, http.StatusUnprocessableEntity},
+	{entity.ErrInvalidTenant, codeInvalidTenant, http.StatusBadRequest},
+	{entity.ErrAlreadyExists, codeAlreadyExists, http.StatusConflict},
+	{entity.ErrTaskNotFound, codeTaskNotFound, http.StatusNotFound},
+	{entity.ErrVersionMismatch, codeVersionMismatch, http.StatusPreconditionFailed},
+	{entity.ErrInvalidVersion, codeInvalidVersion, http.StatusBadRequest},
+	{entity.ErrInvalidPatch, codeInvalidPatch, http.StatusUnprocessableEntity},
+	{entity.ErrInvalidBatch, codeInvalidBatch, http.StatusUnprocessableEntity},
+	{entity.ErrBatchTooLarge, codeBatchTooLarge, http.StatusRequestEntityTooLarge},
+	{entity.ErrBatchAborted, codeBatchAborted, http.StatusFailedDependency},
+	{entity.ErrChecklistItemNotFound, codeChecklistNotFound, http.StatusNotFound},
+	{entity.ErrInvalidTransition, codeInvalidTransition, http.StatusConflict},
+	{entity.ErrOpenSubtasks, codeOpenSubtasks, http.StatusConflict},
+	{entity.ErrBlocked, codeTaskBlocked, http.StatusConflict},
+	{entity.ErrInvalidBlocker, codeInvalidBlocker, http.StatusUnprocessableEntity},
+	{entity.ErrDependencyCycle, codeDependencyCycle, http.StatusUnprocessableEntity},
+	{entity.ErrInvalidParent, codeInvalidParent, http.StatusUnprocessableEntity},
+	{entity.ErrInvalidChecklist, codeInvalidChecklist, http.StatusUnprocessableEntity},
+	{entity.ErrProjectExists, codeProjectExists, http.StatusConflict},
+	{entity.ErrProjectNotFound, codeProjectNotFound, http.StatusNotFound},
+	{entity.ErrInvalidProject, codeInvalidProject, http.StatusUnprocessableEntity},
+	{entity.ErrInvalidDeleteMode, codeInvalidMode, http.StatusBadRequest},
+	{entity.ErrInvalidTitle, codeInvalidTitle, http.StatusUnprocessableEntity},
+	{entity.ErrInvalidDueDate, codeInvalidDueDate, http.StatusUnprocessableEntity},
+	{entity.ErrInvalidRecurrence, codeInvalidRecurrence, http.StatusUnprocessableEntity},
+	{entity.ErrInvalidDescription, codeInvalidDescription, http.StatusUnprocessableEntity},
+	{entity.ErrInvalidPriority, codeInvalidPriority, http.StatusUnprocessableEntity},
+	{entity.ErrInvalidTags, codeInvalidTags, http.StatusUnprocessableEntity},
+	{entity.ErrInvalidStatus, codeInvalidStatus, http.StatusBadRequest},
+	{entity.ErrInvalidID, codeInvalidID, http.StatusBadRequest},
+	{entity.ErrInvalidDate, codeInvalidDate, http.StatusBadRequest},
+	{entity.ErrInvalidTimeZone, codeInvalidTimeZone, http.StatusBadRequest},
+	{entity.ErrInvalidCursor, codeInvalidCursor, http.StatusBadRequest},
+	{entity.ErrInvalidLimit, codeInvalidLimit, http.StatusBadRequest},
+	{entity.ErrInvalidSort, codeInvalidSort, http.StatusBadRequest},
+	{entity.ErrInvalidFilter, codeInvalidFilter, http.StatusBadRequest},
+	{entity.ErrInvalidQuery, codeInvalidQuery, http.StatusBadRequest},
+	{errInvalidRequest, codeInvalidRequest, http.StatusBadRequest},
+	{errUnsupportedMediaType, codeUnsupportedMedia, http.StatusUnsupportedMediaType},
+}
+
+// dateMessage - сообщение об ошибке в поле даты.
+const dateMessage = "must be a YYYY-MM-DD date or an RFC 3339 timestamp"
+
+// problem определяет тело ответа об ошибке в формате RFC 7807 (application/problem+json).
+// CompletedSubtasks перечисляет подзадачи, которые каскадное выполнение успело выполнить до ошибки.
+type problem struct {
+	Type              string       `json:"type" example:"about:blank"`
+	Title             string       `json:"title" example:"Bad Request"`
+	Status            int          `json:"status" example:"400"`
+	Detail            string       `json:"detail,omitempty" example:"invalid title"`
+	Instance          string       `json:"instance,omitempty" example:"/api/v1/todo-list/tasks"`
+	Code              string       `json:"code" example:"invalid_title"`
+	RequestID         string       `json:"requestId,omitempty" example:"6620d1a8b3f5c9e1d2a4f0b7"`
+	Errors            []fieldError `json:"errors,omitempty"`
+	CompletedSubtasks []string     `json:"completedSubtasks,omitempty" example:"661fbb485131cd932a981b27"`
+}
+
+// fieldError описывает ошибку валидации отдельного поля запроса.
+type fieldError struct {
+	Field   string `json:"field" example:"title"`
+	Message string `json:"message" example:"must not be empty"`
+}
+
+// newProblem собирает problem для ошибки err, определяя статус и код по errorMapping.
+func newProblem(c *gin.Context, err error) problem {
+	p := problem{
+		Type:      "about:blank",
+		Instance:  c.Request.URL.Path,
+		RequestID: c.GetString(requestIDKey),
+	}
+
+	p.Status, p.Code, p.Detail = lookupError(err)
+	p.Title = http.StatusText(p.Status)
+	p.Errors = fieldErrors(err)
+
+	var cascadeError entity.CascadeError
+	if errors.As(err, &cascadeError) {
+		p.CompletedSubtasks = cascadeError.Completed
+	}
+
+	return p
+}
+
+// lookupError находит HTTP статус, код и описание ошибки err в errorMapping.
+// Неизвестные ошибки считаются внутренними, их текст клиенту не раскрывается.
+func lookupError(err error) (status int, code, detail string) {
+	for _, known := range errorMapping {
+		if errors.Is(err, known.err) {
+			return known.status, known.code, known.err.Error()
+		}
+	}
+
+	return http.StatusInternalServerError, codeInternal, "internal server error"
+}
+
+// abortWithProblem логирует ошибку и отвечает телом application/problem+json.
+// Статус и код ответа определяются по ошибке в newProblem.
+func abortWithProblem(c *gin.Context, log *slog.Logger, err error) {
+	p := newProblem(c, err)
+
+	if p.Status >= http.StatusInternalServerError {
+		log.Error(p.Title, "error", err, "requestId", p.RequestID)
+	} else {
+		log.Warn(p.Title, "error", err, "requestId", p.RequestID)
+	}
+
+	// Ответ 401 сообщает клиенту схему аутентификации (RFC 7235)
+	if p.Status == http.StatusUnauthorized {
+		c.Header("WWW-Authenticate", bearerScheme)
+	}
+
+	c.Header("Content-Type", problemContentType)
+	c.AbortWithStatusJSON(p.Status, p)
+}
+
+// fieldErrors извлекает из ошибки детали валидации по полям.
+func fieldErrors(err error) []fieldError {
+	var validationErrors validator.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		result := make([]fieldError, 0, len(validationErrors))
+		for _, fe := range validationErrors {
+			result = append(result, fieldError{
+				Field:   fe.Field(),
+				Message: validationMessage(fe),
+			})
+		}
+
+		return result
+	}
+
+	var typeError *json.UnmarshalTypeError
+	if errors.As(err, &typeError) && typeError.Field != "" {
+		return []fieldError{{
+			Field:   typeError.Field,
+			Message: "must be a " + typeError.Type.Kind().String(),
+		}}
+	}
+
+	var validationError entity.ValidationError
+	if errors.As(err, &validationError) {
+		return []fieldError{{
+			Field:   validationError.Field,
+			Message: validationError.Reason,
+		}}
+	}
+
+	return nil
+}
+
+// validationMessage переводит правило валидатора в понятное сообщение.
+func validationMessage(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	default:
+		return fmt.Sprintf("failed on the '%s' rule", fe.Tag())
+	}
+}
+
+// useJSONFieldNames настраивает валидатор gin так, чтобы в ошибках были имена полей JSON.
+func useJSONFieldNames() {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return
+	}
+
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+
+		return name
+	})
+}
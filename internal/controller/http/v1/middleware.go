@@ -0,0 +1,78 @@
+package v1
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+
+	"github.com/skantay/todo-list/internal/entity"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	requestIDHeader = "X-Request-ID"
+	requestIDKey    = "requestID"
+	timeZoneHeader  = "X-Time-Zone"
+	timeZoneQuery   = "tz"
+	tenantHeader    = "X-Tenant-ID"
+	etagHeader      = "ETag"
+	ifMatchHeader   = "If-Match"
+
+	authorizationHeader = "Authorization"
+	bearerScheme        = "Bearer"
+	apiKeyScheme        = "ApiKey"
+)
+
+// requestID присваивает каждому запросу идентификатор.
+// Если клиент передал X-Request-ID, используется он, иначе генерируется новый.
+func requestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(requestIDHeader)
+		if id == "" {
+			id = newRequestID()
+		}
+
+		c.Set(requestIDKey, id)
+		c.Header(requestIDHeader, id)
+
+		c.Next()
+	}
+}
+
+// newRequestID генерирует случайный идентификатор запроса.
+func newRequestID() string {
+	b := make([]byte, 12)
+	_, _ = rand.Read(b)
+
+	return hex.EncodeToString(b)
+}
+
+// timeZone сохраняет в контексте запроса часовой пояс пользователя.
+// Пояс берётся из заголовка X-Time-Zone или параметра tz,
+// по умолчанию действует пояс рабочего пространства, а без него UTC.
+func timeZone(log *slog.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		name := c.GetHeader(timeZoneHeader)
+		if name == "" {
+			name = c.Query(timeZoneQuery)
+		}
+
+		if name == "" {
+			c.Next()
+
+			return
+		}
+
+		loc, err := entity.LoadLocation(name)
+		if err != nil {
+			abortWithProblem(c, log, entity.NewValidationError(timeZoneHeader, "must be an IANA time zone name, e.g. Asia/Almaty", err))
+
+			return
+		}
+
+		c.Request = c.Request.WithContext(entity.WithLocation(c.Request.Context(), loc))
+
+		c.Next()
+	}
+}
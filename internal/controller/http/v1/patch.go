@@ -0,0 +1,241 @@
+package v1
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/skantay/todo-list/internal/entity"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Форматы тела запроса частичного обновления задачи
+const (
+	mergePatchContentType = "application/merge-patch+json" // RFC 7396
+	jsonPatchContentType  = "application/json-patch+json"  // RFC 6902
+
+	acceptPatchHeader = "Accept-Patch"
+)
+
+// Операции JSON Patch, которые можно применить к полю задачи
+const (
+	patchOpAdd     = "add"
+	patchOpReplace = "replace"
+	patchOpRemove  = "remove"
+)
+
+// patchOperation - одна операция документа JSON Patch.
+type patchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	Value json.RawMessage `json:"value"`
+}
+
+// patch обрабатывает запрос на частичное обновление задачи.
+
+// @Summary Patch task
+// @Description Change only some fields of an existing task.
+// @Description application/merge-patch+json (RFC 7396) takes an object with the fields to change, null removes an optional field.
+// @Description application/json-patch+json (RFC 6902) takes a list of add, replace and remove operations on top-level fields like /title.
+// @Description Fields that can be changed: title, description, activeAt, dueAt, priority, tags, recurrence, parentId, projectId.
+// @Description title and activeAt can not be removed; a removed priority becomes normal.
+// @Description The task with the changes applied is validated as in the full update.
+// @Description With If-Match the task is patched only in that version, otherwise 412 is returned.
+// @Accept application/merge-patch+json
+// @Accept application/json-patch+json
+// @Produce json
+// @Param id path string true "Task ID"
+// @Param If-Match header string false "ETag of the task version being patched"
+// @Param patch body object true "Merge patch object or a list of JSON Patch operations"
+// @Success 204
+// @Failure 400 {object} problem
+// @Failure 404 {object} problem
+// @Failure 409 {object} problem
+// @Failure 412 {object} problem
+// @Failure 415 {object} problem
+// @Failure 422 {object} problem
+// @Failure 401 {object} problem
+// @Failure 500 {object} problem
+// @Security BearerAuth
+// @Router /api/v1/todo-list/tasks/{id} [patch]
+func (t taskRoutes) patch(c *gin.Context) {
+	if err := withIfMatch(c); err != nil {
+		t.respondError(c, err)
+
+		return
+	}
+
+	var (
+		patch entity.TaskPatch
+		err   error
+	)
+
+	switch c.ContentType() {
+	case mergePatchContentType:
+		patch, err = decodeMergePatch(c.Request.Body)
+	case jsonPatchContentType:
+		patch, err = decodeJSONPatch(c.Request.Body)
+	default:
+		// Клиент узнаёт из Accept-Patch, какие форматы принимаются (RFC 5789)
+		c.Header(acceptPatchHeader, mergePatchContentType+", "+jsonPatchContentType)
+		err = errUnsupportedMediaType
+	}
+
+	if err != nil {
+		t.respondError(c, err)
+
+		return
+	}
+
+	if err := t.taskUsecase.PatchTask(c.Request.Context(), c.Param("id"), patch); err != nil {
+		t.respondError(c, err)
+
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// decodeMergePatch разбирает документ JSON Merge Patch: каждое поле объекта меняет поле задачи.
+func decodeMergePatch(body io.Reader) (entity.TaskPatch, error) {
+	var fields map[string]json.RawMessage
+	if err := json.NewDecoder(body).Decode(&fields); err != nil {
+		return entity.TaskPatch{}, fmt.Errorf("%w: %w", errInvalidRequest, err)
+	}
+
+	// Документ null или не объект заменил бы задачу целиком
+	if fields == nil {
+		return entity.TaskPatch{}, fmt.Errorf("%w: merge patch must be an object", errInvalidRequest)
+	}
+
+	var patch entity.TaskPatch
+
+	for field, value := range fields {
+		if err := setPatchField(&patch, field, value); err != nil {
+			return entity.TaskPatch{}, err
+		}
+	}
+
+	return patch, nil
+}
+
+// decodeJSONPatch разбирает документ JSON Patch. Операции применяются по порядку,
+// поэтому при нескольких операциях над одним полем побеждает последняя.
+func decodeJSONPatch(body io.Reader) (entity.TaskPatch, error) {
+	var operations []patchOperation
+	if err := json.NewDecoder(body).Decode(&operations); err != nil {
+		return entity.TaskPatch{}, fmt.Errorf("%w: %w", errInvalidRequest, err)
+	}
+
+	var patch entity.TaskPatch
+
+	for _, operation := range operations {
+		// Меняются только поля задачи верхнего уровня: /title, /tags
+		field, ok := strings.CutPrefix(operation.Path, "/")
+		if !ok || field == "" || strings.Contains(field, "/") {
+			return entity.TaskPatch{}, entity.NewValidationError("path", "must point to a top-level field like /title", entity.ErrInvalidPatch)
+		}
+
+		value := operation.Value
+
+		switch operation.Op {
+		case patchOpAdd, patchOpReplace:
+			if value == nil {
+				return entity.TaskPatch{}, entity.NewValidationError("value", "is required for "+operation.Op, entity.ErrInvalidPatch)
+			}
+		case patchOpRemove:
+			value = json.RawMessage("null")
+		default:
+			return entity.TaskPatch{}, entity.NewValidationError("op", "must be add, replace or remove", entity.ErrInvalidPatch)
+		}
+
+		if err := setPatchField(&patch, field, value); err != nil {
+			return entity.TaskPatch{}, err
+		}
+	}
+
+	return patch, nil
+}
+
+// setPatchField записывает в patch изменение поля field на значение value, null удаляет поле.
+func setPatchField(patch *entity.TaskPatch, field string, value json.RawMessage) error {
+	remove := bytes.Equal(bytes.TrimSpace(value), []byte("null"))
+
+	switch field {
+	case "title":
+		if remove {
+			return entity.NewValidationError(field, "must not be removed", entity.ErrInvalidPatch)
+		}
+
+		title, err := decodePatchValue[string](field, value, "string")
+		patch.Title = entity.Change(title)
+
+		return err
+	case "activeAt":
+		if remove {
+			return entity.NewValidationError(field, "must not be removed", entity.ErrInvalidPatch)
+		}
+
+		activeAt, err := decodePatchDate(field, value)
+		patch.ActiveAt = entity.Change(activeAt)
+
+		return err
+	case "dueAt":
+		if remove {
+			patch.DueAt = entity.Change[*entity.TaskDate](nil)
+
+			return nil
+		}
+
+		dueAt, err := decodePatchDate(field, value)
+		patch.DueAt = entity.Change(&dueAt)
+
+		return err
+	case "tags":
+		tags, err := decodePatchValue[[]string](field, value, "list of strings")
+		patch.Tags = entity.Change(tags)
+
+		return err
+	}
+
+	// Остальные поля - строки, null удаляет значение
+	target := map[string]*entity.PatchField[string]{
+		"description": &patch.Description,
+		"priority":    &patch.Priority,
+		"recurrence":  &patch.Recurrence,
+		"parentId":    &patch.ParentID,
+		"projectId":   &patch.ProjectID,
+	}[field]
+	if target == nil {
+		return entity.NewValidationError(field, "is not a field that can be patched", entity.ErrInvalidPatch)
+	}
+
+	text, err := decodePatchValue[string](field, value, "string")
+	*target = entity.Change(text)
+
+	return err
+}
+
+// decodePatchValue разбирает значение поля field, null даёт нулевое значение.
+func decodePatchValue[T any](field string, value json.RawMessage, kind string) (T, error) {
+	var result T
+	if err := json.Unmarshal(value, &result); err != nil {
+		return result, entity.NewValidationError(field, "must be a "+kind, errInvalidRequest)
+	}
+
+	return result, nil
+}
+
+// decodePatchDate разбирает дату поля field.
+func decodePatchDate(field string, value json.RawMessage) (entity.TaskDate, error) {
+	raw, err := decodePatchValue[string](field, value, "string")
+	if err != nil {
+		return entity.TaskDate{}, err
+	}
+
+	return parseDate(field, raw)
+}
@@ -0,0 +1,179 @@
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/skantay/todo-list/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Patch(t *testing.T) {
+	dueAt := entity.DateOf(time.Date(2024, 4, 5, 0, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		setup       func(m *MocktaskUsecase)
+		wantStatus  int
+		wantCode    string
+		wantFields  []fieldError
+	}{
+		{
+			name:        "#1 merge patch",
+			contentType: mergePatchContentType,
+			body:        `{"title":"new title","dueAt":"2024-04-05","description":null,"tags":["home"]}`,
+			setup: func(m *MocktaskUsecase) {
+				m.EXPECT().PatchTask(gomock.Any(), taskID, entity.TaskPatch{
+					Title:       entity.Change("new title"),
+					DueAt:       entity.Change(&dueAt),
+					Description: entity.Change(""),
+					Tags:        entity.Change([]string{"home"}),
+				}).Return(nil)
+			},
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:        "#2 json patch",
+			contentType: jsonPatchContentType,
+			body:        `[{"op":"replace","path":"/priority","value":"high"},{"op":"remove","path":"/dueAt"},{"op":"add","path":"/projectId","value":"p1"}]`,
+			setup: func(m *MocktaskUsecase) {
+				m.EXPECT().PatchTask(gomock.Any(), taskID, entity.TaskPatch{
+					Priority:  entity.Change("high"),
+					DueAt:     entity.Change[*entity.TaskDate](nil),
+					ProjectID: entity.Change("p1"),
+				}).Return(nil)
+			},
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:        "#3 content type with charset",
+			contentType: mergePatchContentType + "; charset=utf-8",
+			body:        `{"recurrence":null}`,
+			setup: func(m *MocktaskUsecase) {
+				m.EXPECT().PatchTask(gomock.Any(), taskID, entity.TaskPatch{Recurrence: entity.Change("")}).Return(nil)
+			},
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:        "#4 unsupported content type",
+			contentType: "application/json",
+			body:        `{"title":"new title"}`,
+			wantStatus:  http.StatusUnsupportedMediaType,
+			wantCode:    codeUnsupportedMedia,
+		},
+		{
+			name:        "#5 remove title",
+			contentType: mergePatchContentType,
+			body:        `{"title":null}`,
+			wantStatus:  http.StatusUnprocessableEntity,
+			wantCode:    codeInvalidPatch,
+			wantFields:  []fieldError{{Field: "title", Message: "must not be removed"}},
+		},
+		{
+			name:        "#6 unknown field",
+			contentType: mergePatchContentType,
+			body:        `{"status":"done"}`,
+			wantStatus:  http.StatusUnprocessableEntity,
+			wantCode:    codeInvalidPatch,
+			wantFields:  []fieldError{{Field: "status", Message: "is not a field that can be patched"}},
+		},
+		{
+			name:        "#7 unsupported operation",
+			contentType: jsonPatchContentType,
+			body:        `[{"op":"move","from":"/title","path":"/description"}]`,
+			wantStatus:  http.StatusUnprocessableEntity,
+			wantCode:    codeInvalidPatch,
+			wantFields:  []fieldError{{Field: "op", Message: "must be add, replace or remove"}},
+		},
+		{
+			name:        "#8 nested path",
+			contentType: jsonPatchContentType,
+			body:        `[{"op":"add","path":"/tags/-","value":"home"}]`,
+			wantStatus:  http.StatusUnprocessableEntity,
+			wantCode:    codeInvalidPatch,
+		},
+		{
+			name:        "#9 wrong value type",
+			contentType: mergePatchContentType,
+			body:        `{"tags":"home"}`,
+			wantStatus:  http.StatusBadRequest,
+			wantCode:    codeInvalidRequest,
+			wantFields:  []fieldError{{Field: "tags", Message: "must be a list of strings"}},
+		},
+		{
+			name:        "#10 invalid date",
+			contentType: jsonPatchContentType,
+			body:        `[{"op":"replace","path":"/activeAt","value":"01.04.2024"}]`,
+			wantStatus:  http.StatusBadRequest,
+			wantCode:    codeInvalidDate,
+		},
+		{
+			name:        "#11 merge patch not an object",
+			contentType: mergePatchContentType,
+			body:        `null`,
+			wantStatus:  http.StatusBadRequest,
+			wantCode:    codeInvalidRequest,
+		},
+		{
+			name:        "#12 usecase validation",
+			contentType: mergePatchContentType,
+			body:        `{"dueAt":"2024-03-01"}`,
+			setup: func(m *MocktaskUsecase) {
+				m.EXPECT().PatchTask(gomock.Any(), taskID, gomock.Any()).
+					Return(entity.NewValidationError("dueAt", "must not be before activeAt", entity.ErrInvalidDueDate))
+			},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantCode:   codeInvalidDueDate,
+		},
+		{
+			name:        "#13 stale version",
+			contentType: mergePatchContentType,
+			body:        `{"title":"new title"}`,
+			setup: func(m *MocktaskUsecase) {
+				m.EXPECT().PatchTask(gomock.Any(), taskID, gomock.Any()).Return(fmt.Errorf("failed to patch task: %w", entity.ErrVersionMismatch))
+			},
+			wantStatus: http.StatusPreconditionFailed,
+			wantCode:   codeVersionMismatch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, taskUsecase := newTestRouter(t)
+			if tt.setup != nil {
+				tt.setup(taskUsecase)
+			}
+
+			req := httptest.NewRequest(http.MethodPatch, tasksPath+"/"+taskID, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+
+			if tt.wantStatus == http.StatusUnsupportedMediaType {
+				assert.Equal(t, mergePatchContentType+", "+jsonPatchContentType, rec.Header().Get(acceptPatchHeader))
+			}
+
+			if tt.wantCode != "" {
+				var p problem
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &p))
+				assert.Equal(t, tt.wantCode, p.Code)
+
+				if tt.wantFields != nil {
+					assert.Equal(t, tt.wantFields, p.Errors)
+				}
+			}
+		})
+	}
+}
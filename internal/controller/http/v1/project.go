@@ -0,0 +1,316 @@
+package v1
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/skantay/todo-list/internal/entity"
+
+	"github.com/gin-gonic/gin"
+)
+
+// projectUsecase определяет методы бизнес-логики для работы с проектами.
+type projectUsecase interface {
+	Create(ctx context.Context, project entity.Project) (string, error)
+	Get(ctx context.Context, id string) (entity.Project, error)
+	List(ctx context.Context, includeArchived bool) ([]entity.Project, error)
+	Update(ctx context.Context, project entity.Project) error
+	Delete(ctx context.Context, id, mode string) error
+	Restore(ctx context.Context, id string) error
+}
+
+// projectRoutes определяет маршруты и их обработчики для проектов.
+type projectRoutes struct {
+	projectUsecase projectUsecase // Использование usecase-ов
+	log            *slog.Logger   // Логгер
+}
+
+// newProjectRoutes регистрирует эндпоинты для проектов.
+func newProjectRoutes(router *gin.RouterGroup, projectUsecase projectUsecase, log *slog.Logger) {
+	projectRoutes := projectRoutes{
+		projectUsecase: projectUsecase,
+		log:            log,
+	}
+
+	router.GET("/projects", projectRoutes.list) // Получение списка проектов
+
+	router.GET("/projects/:id", projectRoutes.get) // Получение проекта по id
+
+	router.POST("/projects", projectRoutes.create) // Создание проекта
+
+	router.PUT("/projects/:id", projectRoutes.update) // Обновление проекта
+
+	router.DELETE("/projects/:id", projectRoutes.delete) // Архивация или удаление проекта
+
+	router.PUT("/projects/:id/restore", projectRoutes.restore) // Возврат проекта из архива
+}
+
+// requestProject определяет структуру тела запроса для создания или обновления проекта.
+type requestProject struct {
+	Name string `json:"name" example:"Release"`
+	// Description - описание проекта в формате markdown
+	Description string `json:"description"`
+}
+
+// list обрабатывает запрос на получение списка проектов.
+
+// @Summary List projects
+// @Description Get projects ordered by name. Archived projects are left out unless includeArchived is set.
+// @Param includeArchived query bool false "Include archived projects" default(false)
+// @Produce json
+// @Success 200 {array} entity.Project
+// @Failure 400 {object} problem
+// @Failure 401 {object} problem
+// @Failure 500 {object} problem
+// @Security BearerAuth
+// @Router /api/v1/todo-list/projects [get]
+func (p projectRoutes) list(c *gin.Context) {
+	var includeArchived bool
+
+	if raw := c.Query("includeArchived"); raw != "" {
+		var err error
+		if includeArchived, err = strconv.ParseBool(raw); err != nil {
+			p.respondError(c, entity.NewValidationError("includeArchived", "must be true or false", entity.ErrInvalidFilter))
+
+			return
+		}
+	}
+
+	projects, err := p.projectUsecase.List(c.Request.Context(), includeArchived)
+	if err != nil {
+		p.respondError(c, err)
+
+		return
+	}
+
+	if len(projects) == 0 {
+		projects = []entity.Project{}
+	}
+
+	c.JSON(http.StatusOK, projects)
+}
+
+// get обрабатывает запрос на получение проекта по id.
+
+// @Summary Get project
+// @Description Get a single project by ID
+// @Param id path string true "Project ID"
+// @Produce json
+// @Success 200 {object} entity.Project
+// @Failure 400 {object} problem
+// @Failure 404 {object} problem
+// @Failure 401 {object} problem
+// @Failure 500 {object} problem
+// @Security BearerAuth
+// @Router /api/v1/todo-list/projects/{id} [get]
+func (p projectRoutes) get(c *gin.Context) {
+	project, err := p.projectUsecase.Get(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		p.respondError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, project)
+}
+
+// create обрабатывает запрос на создание нового проекта.
+
+// @Summary Create project
+// @Description Create a new project. Project names are unique per user.
+// @Accept json
+// @Produce json
+// @Param requestProject body requestProject true "Project details"
+// @Success 201 {object} resp
+// @Failure 400 {object} problem
+// @Failure 409 {object} problem
+// @Failure 422 {object} problem
+// @Failure 401 {object} problem
+// @Failure 500 {object} problem
+// @Security BearerAuth
+// @Router /api/v1/todo-list/projects [post]
+func (p projectRoutes) create(c *gin.Context) {
+	var req requestProject
+
+	if err := bindJSON(c, &req); err != nil {
+		p.respondError(c, err)
+
+		return
+	}
+
+	id, err := p.projectUsecase.Create(c.Request.Context(), entity.Project{
+		Name:        req.Name,
+		Description: req.Description,
+	})
+	if err != nil {
+		p.respondError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusCreated, resp{ID: id})
+}
+
+// update обрабатывает запрос на обновление существующего проекта.
+
+// @Summary Update project
+// @Description Update the name and description of an existing project
+// @Accept json
+// @Param id path string true "Project ID"
+// @Param requestProject body requestProject true "Project details"
+// @Success 204
+// @Failure 400 {object} problem
+// @Failure 404 {object} problem
+// @Failure 409 {object} problem
+// @Failure 422 {object} problem
+// @Failure 401 {object} problem
+// @Failure 500 {object} problem
+// @Security BearerAuth
+// @Router /api/v1/todo-list/projects/{id} [put]
+func (p projectRoutes) update(c *gin.Context) {
+	var req requestProject
+
+	if err := bindJSON(c, &req); err != nil {
+		p.respondError(c, err)
+
+		return
+	}
+
+	err := p.projectUsecase.Update(c.Request.Context(), entity.Project{
+		ID:          c.Param("id"),
+		Name:        req.Name,
+		Description: req.Description,
+	})
+	if err != nil {
+		p.respondError(c, err)
+
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// delete обрабатывает запрос на удаление проекта.
+
+// @Summary Delete project
+// @Description By default the project is archived: its tasks stay in it, but no new tasks can be added. Archiving again changes nothing.
+// @Description With mode=cascade the project is deleted together with all its tasks.
+// @Param id path string true "Project ID"
+// @Param mode query string false "Delete mode (archive, cascade)" default(archive)
+// @Success 204
+// @Failure 400 {object} problem
+// @Failure 404 {object} problem
+// @Failure 401 {object} problem
+// @Failure 500 {object} problem
+// @Security BearerAuth
+// @Router /api/v1/todo-list/projects/{id} [delete]
+func (p projectRoutes) delete(c *gin.Context) {
+	if err := p.projectUsecase.Delete(c.Request.Context(), c.Param("id"), c.Query("mode")); err != nil {
+		p.respondError(c, err)
+
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// restore обрабатывает запрос на возврат проекта из архива.
+
+// @Summary Restore project
+// @Description Take a project out of the archive. Restoring a project that is not archived changes nothing.
+// @Param id path string true "Project ID"
+// @Success 204
+// @Failure 400 {object} problem
+// @Failure 404 {object} problem
+// @Failure 401 {object} problem
+// @Failure 500 {object} problem
+// @Security BearerAuth
+// @Router /api/v1/todo-list/projects/{id}/restore [put]
+func (p projectRoutes) restore(c *gin.Context) {
+	if err := p.projectUsecase.Restore(c.Request.Context(), c.Param("id")); err != nil {
+		p.respondError(c, err)
+
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// respondError логирует ошибку и отвечает телом application/problem+json.
+func (p projectRoutes) respondError(c *gin.Context, err error) {
+	abortWithProblem(c, p.log, err)
+}
+
+// requestTaskProject определяет структуру тела запроса на перенос задачи в проект.
+type requestTaskProject struct {
+	// ProjectID - id проекта, пусто - убрать задачу из проекта
+	ProjectID string `json:"projectId" example:"661fbb485131cd932a981b26"`
+}
+
+// projectTasks обрабатывает запрос на получение задач проекта.
+
+// @Summary List project tasks
+// @Description Get a page of tasks of the project. Takes the same filters and pagination parameters as the task list.
+// @Param id path string true "Project ID"
+// @Param status query string false "Status of the tasks (active, in_progress, done, cancelled, all)" default(active)
+// @Param X-Time-Zone header string false "IANA time zone used for dates without time, e.g. Asia/Almaty" default(UTC)
+// @Param limit query int false "Page size, from 1 to 100" default(50)
+// @Param cursor query string false "Opaque page cursor taken from the Link header"
+// @Param sort query string false "Sort key (activeAt, title, id)" default(activeAt)
+// @Param order query string false "Sort direction (asc, desc)" default(asc)
+// @Produce json
+// @Success 200 {array} entity.Task
+// @Header 200 {integer} X-Total-Count "Number of tasks matching the filter across all pages"
+// @Header 200 {string} Link "Links to the next and previous pages (RFC 8288)"
+// @Failure 400 {object} problem
+// @Failure 404 {object} problem
+// @Failure 401 {object} problem
+// @Failure 500 {object} problem
+// @Security BearerAuth
+// @Router /api/v1/todo-list/projects/{id}/tasks [get]
+func (t taskRoutes) projectTasks(c *gin.Context) {
+	filter, err := getFilter(c)
+	if err != nil {
+		t.respondError(c, err)
+
+		return
+	}
+	filter.ProjectID = c.Param("id")
+
+	t.listTasks(c, filter)
+}
+
+// moveTask обрабатывает запрос на перенос задачи в другой проект.
+
+// @Summary Move task to project
+// @Description Move the task to an existing project that is not archived. An empty projectId takes the task out of its project.
+// @Accept json
+// @Param id path string true "Task ID"
+// @Param requestTaskProject body requestTaskProject true "Target project"
+// @Success 204
+// @Failure 400 {object} problem
+// @Failure 404 {object} problem
+// @Failure 422 {object} problem
+// @Failure 401 {object} problem
+// @Failure 500 {object} problem
+// @Security BearerAuth
+// @Router /api/v1/todo-list/tasks/{id}/project [put]
+func (t taskRoutes) moveTask(c *gin.Context) {
+	var req requestTaskProject
+
+	if err := bindJSON(c, &req); err != nil {
+		t.respondError(c, err)
+
+		return
+	}
+
+	if err := t.taskUsecase.MoveTask(c.Request.Context(), c.Param("id"), req.ProjectID); err != nil {
+		t.respondError(c, err)
+
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
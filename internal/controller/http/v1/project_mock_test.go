@@ -0,0 +1,123 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/controller/http/v1/project.go
+
+// Package v1 is a generated GoMock package.
+package v1
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entity "github.com/skantay/todo-list/internal/entity"
+)
+
+// MockprojectUsecase is a mock of projectUsecase interface.
+type MockprojectUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockprojectUsecaseMockRecorder
+}
+
+// MockprojectUsecaseMockRecorder is the mock recorder for MockprojectUsecase.
+type MockprojectUsecaseMockRecorder struct {
+	mock *MockprojectUsecase
+}
+
+// NewMockprojectUsecase creates a new mock instance.
+func NewMockprojectUsecase(ctrl *gomock.Controller) *MockprojectUsecase {
+	mock := &MockprojectUsecase{ctrl: ctrl}
+	mock.recorder = &MockprojectUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockprojectUsecase) EXPECT() *MockprojectUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockprojectUsecase) Create(ctx context.Context, project entity.Project) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, project)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockprojectUsecaseMockRecorder) Create(ctx, project interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockprojectUsecase)(nil).Create), ctx, project)
+}
+
+// Delete mocks base method.
+func (m *MockprojectUsecase) Delete(ctx context.Context, id, mode string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id, mode)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockprojectUsecaseMockRecorder) Delete(ctx, id, mode interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockprojectUsecase)(nil).Delete), ctx, id, mode)
+}
+
+// Get mocks base method.
+func (m *MockprojectUsecase) Get(ctx context.Context, id string) (entity.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(entity.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockprojectUsecaseMockRecorder) Get(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockprojectUsecase)(nil).Get), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockprojectUsecase) List(ctx context.Context, includeArchived bool) ([]entity.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, includeArchived)
+	ret0, _ := ret[0].([]entity.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockprojectUsecaseMockRecorder) List(ctx, includeArchived interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockprojectUsecase)(nil).List), ctx, includeArchived)
+}
+
+// Restore mocks base method.
+func (m *MockprojectUsecase) Restore(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockprojectUsecaseMockRecorder) Restore(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockprojectUsecase)(nil).Restore), ctx, id)
+}
+
+// Update mocks base method.
+func (m *MockprojectUsecase) Update(ctx context.Context, project entity.Project) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, project)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockprojectUsecaseMockRecorder) Update(ctx, project interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockprojectUsecase)(nil).Update), ctx, project)
+}
@@ -0,0 +1,162 @@
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/skantay/todo-list/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	projectsPath = "/api/v1/todo-list/projects"
+	projectID    = "661fbb485131cd932a981b27"
+)
+
+// newProjectTestRouter регистрирует маршруты проектов поверх мока usecase.
+func newProjectTestRouter(t *testing.T) (*gin.Engine, *MockprojectUsecase) {
+	t.Helper()
+
+	gin.SetMode(gin.TestMode)
+	useJSONFieldNames()
+
+	ctrl := gomock.NewController(t)
+	projectUsecase := NewMockprojectUsecase(ctrl)
+
+	router := gin.New()
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	newProjectRoutes(router.Group("/api/v1/todo-list", requestID(), timeZone(log)), projectUsecase, log)
+
+	return router, projectUsecase
+}
+
+func Test_ProjectErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		setup      func(m *MockprojectUsecase)
+		wantStatus int
+		wantCode   string
+		wantFields []fieldError
+	}{
+		{
+			name:   "#1 already exists",
+			method: http.MethodPost,
+			path:   projectsPath,
+			body:   `{"name":"work"}`,
+			setup: func(m *MockprojectUsecase) {
+				m.EXPECT().Create(gomock.Any(), entity.Project{Name: "work"}).Return("", fmt.Errorf("failed: %w", entity.ErrProjectExists))
+			},
+			wantStatus: http.StatusConflict,
+			wantCode:   codeProjectExists,
+		},
+		{
+			name:   "#2 empty name",
+			method: http.MethodPut,
+			path:   projectsPath + "/" + projectID,
+			body:   `{"name":""}`,
+			setup: func(m *MockprojectUsecase) {
+				m.EXPECT().Update(gomock.Any(), entity.Project{ID: projectID}).
+					Return(entity.NewValidationError("name", "must not be empty", entity.ErrInvalidProject))
+			},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantCode:   codeInvalidProject,
+			wantFields: []fieldError{{Field: "name", Message: "must not be empty"}},
+		},
+		{
+			name:   "#3 not found",
+			method: http.MethodGet,
+			path:   projectsPath + "/" + projectID,
+			setup: func(m *MockprojectUsecase) {
+				m.EXPECT().Get(gomock.Any(), projectID).Return(entity.Project{}, fmt.Errorf("failed: %w", entity.ErrProjectNotFound))
+			},
+			wantStatus: http.StatusNotFound,
+			wantCode:   codeProjectNotFound,
+		},
+		{
+			name:   "#4 invalid delete mode",
+			method: http.MethodDelete,
+			path:   projectsPath + "/" + projectID + "?mode=purge",
+			setup: func(m *MockprojectUsecase) {
+				m.EXPECT().Delete(gomock.Any(), projectID, "purge").
+					Return(entity.NewValidationError("mode", "must be archive or cascade", entity.ErrInvalidDeleteMode))
+			},
+			wantStatus: http.StatusBadRequest,
+			wantCode:   codeInvalidMode,
+			wantFields: []fieldError{{Field: "mode", Message: "must be archive or cascade"}},
+		},
+		{
+			name:       "#5 invalid includeArchived",
+			method:     http.MethodGet,
+			path:       projectsPath + "?includeArchived=maybe",
+			wantStatus: http.StatusBadRequest,
+			wantCode:   codeInvalidFilter,
+			wantFields: []fieldError{{Field: "includeArchived", Message: "must be true or false"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, projectUsecase := newProjectTestRouter(t)
+
+			if tt.setup != nil {
+				tt.setup(projectUsecase)
+			}
+
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+
+			var p problem
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &p))
+
+			assert.Equal(t, tt.wantCode, p.Code)
+			assert.Equal(t, tt.wantFields, p.Errors)
+		})
+	}
+}
+
+func Test_Projects(t *testing.T) {
+	router, projectUsecase := newProjectTestRouter(t)
+
+	archivedAt := time.Date(2024, 4, 1, 12, 0, 0, 0, time.UTC)
+
+	projectUsecase.EXPECT().Create(gomock.Any(), entity.Project{Name: "work", Description: "office"}).Return(projectID, nil)
+	projectUsecase.EXPECT().List(gomock.Any(), true).Return([]entity.Project{{ID: projectID, Name: "work", ArchivedAt: &archivedAt}}, nil)
+	projectUsecase.EXPECT().Delete(gomock.Any(), projectID, entity.DeleteCascade).Return(nil)
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, projectsPath, strings.NewReader(`{"name":"work","description":"office"}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.JSONEq(t, `{"id":"`+projectID+`"}`, rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, projectsPath+"?includeArchived=true", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `[{"id":"`+projectID+`","name":"work","archivedAt":"2024-04-01T12:00:00Z"}]`, rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, projectsPath+"/"+projectID+"?mode=cascade", nil))
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+}
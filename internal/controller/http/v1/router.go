@@ -17,12 +17,33 @@ import (
 
 // @title Todo List API
 // @version 1
-// @description API for managing todo list tasks
+// @description API for managing todo list tasks.
+// @description Send the X-Tenant-ID header to work in a workspace when workspaces are configured; a token or API key issued in a workspace is bound to it.
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description Access token from /auth/login as "Bearer <token>"
+// @securityDefinitions.apikey ApiKeyAuth
+// @in header
+// @name Authorization
+// @description API key from /api-keys as "ApiKey <key>"
 func Set(router *gin.Engine, usecase usecase.Usecase, log *slog.Logger) {
+	useJSONFieldNames() // Имена полей JSON в ошибках валидации
+
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler)) // Обработка Swagger UI
 
-	apiV1 := router.Group("/api/v1") // Группировка маршрутов по версии API
+	apiV1 := router.Group("/api/v1", requestID(), tenant(usecase.TenantUsecase, log), timeZone(log)) // Группировка маршрутов по версии API
 	{
-		newTaskRoutes(apiV1.Group("/todo-list"), usecase.TaskUsecase, log) // Настройка маршрутов для операций с задачами
+		newAuthRoutes(apiV1.Group("/todo-list"), usecase.UserUsecase, log) // Регистрация и вход, доступны без токена
+
+		todoList := apiV1.Group("/todo-list", authenticate(usecase.UserUsecase, usecase.TenantUsecase, log)) // Остальные маршруты требуют токен или API-ключ
+
+		newAPIKeyRoutes(todoList.Group("", bearerOnly(log)), usecase.UserUsecase, log) // Настройка маршрутов для управления API-ключами, только по токену
+
+		newTaskRoutes(todoList, usecase.TaskUsecase, log) // Настройка маршрутов для операций с задачами
+
+		newProjectRoutes(todoList, usecase.ProjectUsecase, log) // Настройка маршрутов для операций с проектами
+
+		newShareRoutes(todoList, usecase.ShareUsecase, log) // Настройка маршрутов для совместного доступа
 	}
 }
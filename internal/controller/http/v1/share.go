@@ -0,0 +1,264 @@
+package v1
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/skantay/todo-list/internal/entity"
+
+	"github.com/gin-gonic/gin"
+)
+
+// shareUsecase определяет методы бизнес-логики для совместного доступа к задачам и проектам.
+type shareUsecase interface {
+	Share(ctx context.Context, resourceType, resourceID, email, role string) (string, error)
+	Shares(ctx context.Context, resourceType, resourceID string) ([]entity.Share, error)
+	Invitations(ctx context.Context) ([]entity.Share, error)
+	Accept(ctx context.Context, id string) error
+	Revoke(ctx context.Context, id string) error
+	SharedTasks(ctx context.Context) ([]entity.Task, error)
+}
+
+// shareRoutes определяет маршруты и их обработчики для совместного доступа.
+type shareRoutes struct {
+	shareUsecase shareUsecase // Использование usecase-ов
+	log          *slog.Logger // Логгер
+}
+
+// newShareRoutes регистрирует эндпоинты приглашений и задач, доступных по приглашению.
+func newShareRoutes(router *gin.RouterGroup, shareUsecase shareUsecase, log *slog.Logger) {
+	shareRoutes := shareRoutes{
+		shareUsecase: shareUsecase,
+		log:          log,
+	}
+
+	router.GET("/tasks/shared", shareRoutes.sharedTasks) // Задачи, к которым пригласили пользователя
+
+	router.POST("/tasks/:id/shares", shareRoutes.shareTask) // Пригласить к задаче
+
+	router.GET("/tasks/:id/shares", shareRoutes.taskShares) // Приглашения к задаче
+
+	router.POST("/projects/:id/shares", shareRoutes.shareProject) // Пригласить к проекту
+
+	router.GET("/projects/:id/shares", shareRoutes.projectShares) // Приглашения к проекту
+
+	router.GET("/invitations", shareRoutes.invitations) // Непринятые приглашения пользователя
+
+	router.PUT("/invitations/:id/accept", shareRoutes.accept) // Принять приглашение
+
+	router.DELETE("/shares/:id", shareRoutes.revoke) // Отклонить или отозвать приглашение
+}
+
+// requestShare определяет структуру тела запроса приглашения.
+type requestShare struct {
+	Email string `json:"email" binding:"required" example:"colleague@example.com"`
+	Role  string `json:"role" binding:"required" enums:"viewer,editor,owner"`
+}
+
+// shareTask обрабатывает запрос на приглашение к задаче.
+
+// @Summary Share task
+// @Description Invite a registered user to a task as viewer, editor or owner. The invitation takes effect once the user accepts it.
+// @Description Viewers can read the task, editors can also update it and change its status, owners can also delete and share it.
+// @Accept json
+// @Produce json
+// @Param id path string true "Task ID"
+// @Param requestShare body requestShare true "Invited user and role"
+// @Success 201 {object} resp
+// @Failure 400 {object} problem
+// @Failure 401 {object} problem
+// @Failure 403 {object} problem
+// @Failure 404 {object} problem
+// @Failure 409 {object} problem
+// @Failure 422 {object} problem
+// @Failure 500 {object} problem
+// @Security BearerAuth
+// @Router /api/v1/todo-list/tasks/{id}/shares [post]
+func (s shareRoutes) shareTask(c *gin.Context) {
+	s.share(c, entity.ResourceTask)
+}
+
+// shareProject обрабатывает запрос на приглашение к проекту.
+
+// @Summary Share project
+// @Description Invite a registered user to a project as viewer, editor or owner. The role applies to the project and all its tasks.
+// @Accept json
+// @Produce json
+// @Param id path string true "Project ID"
+// @Param requestShare body requestShare true "Invited user and role"
+// @Success 201 {object} resp
+// @Failure 400 {object} problem
+// @Failure 401 {object} problem
+// @Failure 403 {object} problem
+// @Failure 404 {object} problem
+// @Failure 409 {object} problem
+// @Failure 422 {object} problem
+// @Failure 500 {object} problem
+// @Security BearerAuth
+// @Router /api/v1/todo-list/projects/{id}/shares [post]
+func (s shareRoutes) shareProject(c *gin.Context) {
+	s.share(c, entity.ResourceProject)
+}
+
+// share приглашает пользователя к ресурсу вида resourceType с id из пути.
+func (s shareRoutes) share(c *gin.Context, resourceType string) {
+	var req requestShare
+
+	if err := bindJSON(c, &req); err != nil {
+		s.respondError(c, err)
+
+		return
+	}
+
+	id, err := s.shareUsecase.Share(c.Request.Context(), resourceType, c.Param("id"), req.Email, req.Role)
+	if err != nil {
+		s.respondError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusCreated, resp{ID: id})
+}
+
+// taskShares обрабатывает запрос на получение приглашений к задаче.
+
+// @Summary List task shares
+// @Description Get invitations to a task, pending and accepted. Available to the owner and users with the owner role.
+// @Produce json
+// @Param id path string true "Task ID"
+// @Success 200 {array} entity.Share
+// @Failure 400 {object} problem
+// @Failure 401 {object} problem
+// @Failure 403 {object} problem
+// @Failure 404 {object} problem
+// @Failure 500 {object} problem
+// @Security BearerAuth
+// @Router /api/v1/todo-list/tasks/{id}/shares [get]
+func (s shareRoutes) taskShares(c *gin.Context) {
+	s.shares(c, entity.ResourceTask)
+}
+
+// projectShares обрабатывает запрос на получение приглашений к проекту.
+
+// @Summary List project shares
+// @Description Get invitations to a project, pending and accepted. Available to the owner and users with the owner role.
+// @Produce json
+// @Param id path string true "Project ID"
+// @Success 200 {array} entity.Share
+// @Failure 400 {object} problem
+// @Failure 401 {object} problem
+// @Failure 403 {object} problem
+// @Failure 404 {object} problem
+// @Failure 500 {object} problem
+// @Security BearerAuth
+// @Router /api/v1/todo-list/projects/{id}/shares [get]
+func (s shareRoutes) projectShares(c *gin.Context) {
+	s.shares(c, entity.ResourceProject)
+}
+
+// shares отвечает списком приглашений к ресурсу вида resourceType с id из пути.
+func (s shareRoutes) shares(c *gin.Context, resourceType string) {
+	shares, err := s.shareUsecase.Shares(c.Request.Context(), resourceType, c.Param("id"))
+	if err != nil {
+		s.respondError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, shares)
+}
+
+// invitations обрабатывает запрос на получение непринятых приглашений.
+
+// @Summary List invitations
+// @Description Get pending invitations sent to the current user.
+// @Produce json
+// @Success 200 {array} entity.Share
+// @Failure 401 {object} problem
+// @Failure 500 {object} problem
+// @Security BearerAuth
+// @Router /api/v1/todo-list/invitations [get]
+func (s shareRoutes) invitations(c *gin.Context) {
+	shares, err := s.shareUsecase.Invitations(c.Request.Context())
+	if err != nil {
+		s.respondError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, shares)
+}
+
+// accept обрабатывает запрос на принятие приглашения.
+
+// @Summary Accept invitation
+// @Description Accept an invitation sent to the current user. Accepting it again changes nothing.
+// @Param id path string true "Share ID"
+// @Success 204
+// @Failure 400 {object} problem
+// @Failure 401 {object} problem
+// @Failure 404 {object} problem
+// @Failure 500 {object} problem
+// @Security BearerAuth
+// @Router /api/v1/todo-list/invitations/{id}/accept [put]
+func (s shareRoutes) accept(c *gin.Context) {
+	if err := s.shareUsecase.Accept(c.Request.Context(), c.Param("id")); err != nil {
+		s.respondError(c, err)
+
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// revoke обрабатывает запрос на удаление приглашения.
+
+// @Summary Delete share
+// @Description The invited user declines the invitation or gives up access, the owner revokes access.
+// @Param id path string true "Share ID"
+// @Success 204
+// @Failure 400 {object} problem
+// @Failure 401 {object} problem
+// @Failure 403 {object} problem
+// @Failure 404 {object} problem
+// @Failure 500 {object} problem
+// @Security BearerAuth
+// @Router /api/v1/todo-list/shares/{id} [delete]
+func (s shareRoutes) revoke(c *gin.Context) {
+	if err := s.shareUsecase.Revoke(c.Request.Context(), c.Param("id")); err != nil {
+		s.respondError(c, err)
+
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// sharedTasks обрабатывает запрос на получение задач, доступных по приглашениям.
+
+// @Summary Shared with me
+// @Description Get tasks of other users shared with the current user directly or through a project, by accepted invitations.
+// @Param X-Time-Zone header string false "IANA time zone used for dates without time, e.g. Asia/Almaty" default(UTC)
+// @Produce json
+// @Success 200 {array} entity.Task
+// @Failure 400 {object} problem
+// @Failure 401 {object} problem
+// @Failure 500 {object} problem
+// @Security BearerAuth
+// @Router /api/v1/todo-list/tasks/shared [get]
+func (s shareRoutes) sharedTasks(c *gin.Context) {
+	tasks, err := s.shareUsecase.SharedTasks(c.Request.Context())
+	if err != nil {
+		s.respondError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, tasks)
+}
+
+// respondError логирует ошибку и отвечает телом application/problem+json.
+func (s shareRoutes) respondError(c *gin.Context, err error) {
+	abortWithProblem(c, s.log, err)
+}
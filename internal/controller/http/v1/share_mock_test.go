@@ -0,0 +1,124 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/controller/http/v1/share.go
+
+// Package v1 is a generated GoMock package.
+package v1
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entity "github.com/skantay/todo-list/internal/entity"
+)
+
+// MockshareUsecase is a mock of shareUsecase interface.
+type MockshareUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockshareUsecaseMockRecorder
+}
+
+// MockshareUsecaseMockRecorder is the mock recorder for MockshareUsecase.
+type MockshareUsecaseMockRecorder struct {
+	mock *MockshareUsecase
+}
+
+// NewMockshareUsecase creates a new mock instance.
+func NewMockshareUsecase(ctrl *gomock.Controller) *MockshareUsecase {
+	mock := &MockshareUsecase{ctrl: ctrl}
+	mock.recorder = &MockshareUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockshareUsecase) EXPECT() *MockshareUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Accept mocks base method.
+func (m *MockshareUsecase) Accept(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Accept", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Accept indicates an expected call of Accept.
+func (mr *MockshareUsecaseMockRecorder) Accept(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Accept", reflect.TypeOf((*MockshareUsecase)(nil).Accept), ctx, id)
+}
+
+// Invitations mocks base method.
+func (m *MockshareUsecase) Invitations(ctx context.Context) ([]entity.Share, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Invitations", ctx)
+	ret0, _ := ret[0].([]entity.Share)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Invitations indicates an expected call of Invitations.
+func (mr *MockshareUsecaseMockRecorder) Invitations(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Invitations", reflect.TypeOf((*MockshareUsecase)(nil).Invitations), ctx)
+}
+
+// Revoke mocks base method.
+func (m *MockshareUsecase) Revoke(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockshareUsecaseMockRecorder) Revoke(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockshareUsecase)(nil).Revoke), ctx, id)
+}
+
+// Share mocks base method.
+func (m *MockshareUsecase) Share(ctx context.Context, resourceType, resourceID, email, role string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Share", ctx, resourceType, resourceID, email, role)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Share indicates an expected call of Share.
+func (mr *MockshareUsecaseMockRecorder) Share(ctx, resourceType, resourceID, email, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Share", reflect.TypeOf((*MockshareUsecase)(nil).Share), ctx, resourceType, resourceID, email, role)
+}
+
+// SharedTasks mocks base method.
+func (m *MockshareUsecase) SharedTasks(ctx context.Context) ([]entity.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SharedTasks", ctx)
+	ret0, _ := ret[0].([]entity.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SharedTasks indicates an expected call of SharedTasks.
+func (mr *MockshareUsecaseMockRecorder) SharedTasks(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SharedTasks", reflect.TypeOf((*MockshareUsecase)(nil).SharedTasks), ctx)
+}
+
+// Shares mocks base method.
+func (m *MockshareUsecase) Shares(ctx context.Context, resourceType, resourceID string) ([]entity.Share, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Shares", ctx, resourceType, resourceID)
+	ret0, _ := ret[0].([]entity.Share)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Shares indicates an expected call of Shares.
+func (mr *MockshareUsecaseMockRecorder) Shares(ctx, resourceType, resourceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Shares", reflect.TypeOf((*MockshareUsecase)(nil).Shares), ctx, resourceType, resourceID)
+}
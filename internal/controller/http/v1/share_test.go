@@ -0,0 +1,161 @@
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/skantay/todo-list/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const shareID = "661fbb485131cd932a981b2d"
+
+// newShareTestRouter регистрирует маршруты совместного доступа поверх мока usecase.
+func newShareTestRouter(t *testing.T) (*gin.Engine, *MockshareUsecase) {
+	t.Helper()
+
+	gin.SetMode(gin.TestMode)
+	useJSONFieldNames()
+
+	ctrl := gomock.NewController(t)
+	shareUsecase := NewMockshareUsecase(ctrl)
+
+	router := gin.New()
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	newShareRoutes(router.Group("/api/v1/todo-list", requestID(), timeZone(log)), shareUsecase, log)
+
+	return router, shareUsecase
+}
+
+func Test_Shares(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		setup      func(m *MockshareUsecase)
+		wantStatus int
+		wantBody   string
+		wantCode   string
+	}{
+		{
+			name:   "#1 share task",
+			method: http.MethodPost,
+			path:   tasksPath + "/661fbb485131cd932a981b26/shares",
+			body:   `{"email":"bob@example.com","role":"editor"}`,
+			setup: func(m *MockshareUsecase) {
+				m.EXPECT().Share(gomock.Any(), entity.ResourceTask, "661fbb485131cd932a981b26", "bob@example.com", entity.RoleEditor).Return(shareID, nil)
+			},
+			wantStatus: http.StatusCreated,
+			wantBody:   `{"id":"` + shareID + `"}`,
+		},
+		{
+			name:   "#2 share project without permission",
+			method: http.MethodPost,
+			path:   projectsPath + "/" + projectID + "/shares",
+			body:   `{"email":"bob@example.com","role":"viewer"}`,
+			setup: func(m *MockshareUsecase) {
+				m.EXPECT().Share(gomock.Any(), entity.ResourceProject, projectID, "bob@example.com", entity.RoleViewer).Return("", fmt.Errorf("%w: owner role required", entity.ErrForbidden))
+			},
+			wantStatus: http.StatusForbidden,
+			wantCode:   codeForbidden,
+		},
+		{
+			name:       "#3 share without role",
+			method:     http.MethodPost,
+			path:       tasksPath + "/661fbb485131cd932a981b26/shares",
+			body:       `{"email":"bob@example.com"}`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   codeInvalidRequest,
+		},
+		{
+			name:   "#4 share twice",
+			method: http.MethodPost,
+			path:   tasksPath + "/661fbb485131cd932a981b26/shares",
+			body:   `{"email":"bob@example.com","role":"viewer"}`,
+			setup: func(m *MockshareUsecase) {
+				m.EXPECT().Share(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return("", fmt.Errorf("failed: %w", entity.ErrShareExists))
+			},
+			wantStatus: http.StatusConflict,
+			wantCode:   codeShareExists,
+		},
+		{
+			name:   "#5 invitations",
+			method: http.MethodGet,
+			path:   "/api/v1/todo-list/invitations",
+			setup: func(m *MockshareUsecase) {
+				m.EXPECT().Invitations(gomock.Any()).Return([]entity.Share{}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   `[]`,
+		},
+		{
+			name:   "#6 accept someone else's invitation",
+			method: http.MethodPut,
+			path:   "/api/v1/todo-list/invitations/" + shareID + "/accept",
+			setup: func(m *MockshareUsecase) {
+				m.EXPECT().Accept(gomock.Any(), shareID).Return(entity.ErrShareNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+			wantCode:   codeShareNotFound,
+		},
+		{
+			name:   "#7 revoke",
+			method: http.MethodDelete,
+			path:   "/api/v1/todo-list/shares/" + shareID,
+			setup: func(m *MockshareUsecase) {
+				m.EXPECT().Revoke(gomock.Any(), shareID).Return(nil)
+			},
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:   "#8 shared with me",
+			method: http.MethodGet,
+			path:   tasksPath + "/shared",
+			setup: func(m *MockshareUsecase) {
+				m.EXPECT().SharedTasks(gomock.Any()).Return([]entity.Task{}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   `[]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, shareUsecase := newShareTestRouter(t)
+
+			if tt.setup != nil {
+				tt.setup(shareUsecase)
+			}
+
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+
+			if tt.wantBody != "" {
+				assert.JSONEq(t, tt.wantBody, rec.Body.String())
+			}
+
+			if tt.wantCode == "" {
+				return
+			}
+
+			var p problem
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &p))
+			assert.Equal(t, tt.wantCode, p.Code)
+		})
+	}
+}
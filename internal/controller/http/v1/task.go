@@ -3,9 +3,11 @@ package v1
 
 import (
 	"context"
-	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/skantay/todo-list/internal/entity"
 
@@ -14,11 +16,25 @@ import (
 
 // taskUsecase определяет методы бизнес-логики для работы с задачами.
 type taskUsecase interface {
-	Create(ctx context.Context, title string, activeAt entity.TaskDate) (string, error)
-	List(ctx context.Context, status string) ([]entity.Task, error)
+	Create(ctx context.Context, task entity.Task) (string, error)
+	Get(ctx context.Context, id string) (entity.Task, error)
+	List(ctx context.Context, filter entity.TaskFilter, page entity.PageRequest) (entity.TaskPage, error)
+	Search(ctx context.Context, query string, limit int) ([]entity.SearchResult, error)
 	UpdateTask(ctx context.Context, task entity.Task) error
-	MarkTaskDone(ctx context.Context, id string) error
+	PatchTask(ctx context.Context, id string, patch entity.TaskPatch) error
+	ChangeStatus(ctx context.Context, id, status string) error
 	Delete(ctx context.Context, id string) error
+	AddChecklistItem(ctx context.Context, taskID, title string) (entity.ChecklistItem, error)
+	ToggleChecklistItem(ctx context.Context, taskID, itemID string) (entity.ChecklistItem, error)
+	ReorderChecklist(ctx context.Context, taskID string, itemIDs []string) ([]entity.ChecklistItem, error)
+	DeleteChecklistItem(ctx context.Context, taskID, itemID string) error
+	AddBlocker(ctx context.Context, id, blockerID string) error
+	RemoveBlocker(ctx context.Context, id, blockerID string) error
+	Next(ctx context.Context, limit int) ([]entity.Task, error)
+	MoveTask(ctx context.Context, id, projectID string) error
+	Batch(ctx context.Context, operations []entity.BatchOperation, atomic bool) ([]entity.BatchResult, error)
+	Assign(ctx context.Context, id string, assignees []string) error
+	Assignments(ctx context.Context, id string) ([]entity.Assignment, error)
 }
 
 // taskRoutes определяет маршруты и их обработчики для задач.
@@ -36,21 +52,102 @@ func newTaskRoutes(router *gin.RouterGroup, taskUsecase taskUsecase, log *slog.L
 
 	router.GET("/tasks", taskRoutes.list) // Получение списка задач
 
+	router.GET("/tasks/next", taskRoutes.next) // Задачи, которые можно начать
+
+	router.GET("/tasks/:id", taskRoutes.get) // Получение задачи по id
+
+	router.GET("/search", taskRoutes.search) // Полнотекстовый поиск задач
+
 	router.POST("/tasks", taskRoutes.create) // Создание задачи
 
+	router.POST("/tasks:action", taskRoutes.action) // Методы коллекции задач: /tasks:batch
+
 	router.PUT("/tasks/:id", taskRoutes.update) // Обновление задачи
 
+	router.PATCH("/tasks/:id", taskRoutes.patch) // Частичное обновление задачи
+
 	router.DELETE("/tasks/:id", taskRoutes.delete) // Удаление задачи
 
 	router.PUT("/tasks/:id/done", taskRoutes.markDone) // Пометить задачу как выполненную
+
+	router.PUT("/tasks/:id/start", taskRoutes.start) // Начать работу над задачей
+
+	router.PUT("/tasks/:id/reopen", taskRoutes.reopen) // Вернуть задачу в работу
+
+	router.PUT("/tasks/:id/cancel", taskRoutes.cancel) // Отменить задачу
+
+	router.POST("/tasks/:id/checklist", taskRoutes.addChecklistItem) // Добавить пункт чек-листа
+
+	router.PUT("/tasks/:id/checklist", taskRoutes.reorderChecklist) // Изменить порядок пунктов чек-листа
+
+	router.PUT("/tasks/:id/checklist/:itemId/toggle", taskRoutes.toggleChecklistItem) // Отметить пункт чек-листа
+
+	router.DELETE("/tasks/:id/checklist/:itemId", taskRoutes.deleteChecklistItem) // Удалить пункт чек-листа
+
+	router.PUT("/tasks/:id/blockers/:blockerId", taskRoutes.addBlocker) // Добавить блокирующую задачу
+
+	router.DELETE("/tasks/:id/blockers/:blockerId", taskRoutes.removeBlocker) // Снять блокировку
+
+	router.PUT("/tasks/:id/project", taskRoutes.moveTask) // Перенести задачу в другой проект
+
+	router.PUT("/tasks/:id/assignees", taskRoutes.assign) // Назначить исполнителей
+
+	router.GET("/tasks/:id/assignments", taskRoutes.assignments) // История назначений
+
+	router.GET("/projects/:id/tasks", taskRoutes.projectTasks) // Получение задач проекта
 }
 
 // requestTask определяет структуру тела запроса для создания или обновления задачи.
+// Даты принимаются строками и разбираются в task, чтобы ошибка указывала на конкретное поле.
 type requestTask struct {
-	Title    string          `json:"title" binding:"required"`
-	ActiveAt entity.TaskDate `json:"activeAt" binding:"required"`
+	Title string `json:"title"`
+	// ActiveAt - дата YYYY-MM-DD или момент времени RFC 3339
+	ActiveAt string `json:"activeAt" binding:"required" example:"2024-04-01"`
+	// DueAt - необязательный срок, дата YYYY-MM-DD или момент времени RFC 3339
+	DueAt string `json:"dueAt" example:"2024-04-05"`
+	// Description - описание задачи в формате markdown
+	Description string `json:"description"`
+	// Priority - приоритет задачи, по умолчанию normal
+	Priority string   `json:"priority" enums:"low,normal,high,urgent"`
+	Tags     []string `json:"tags"`
+	// Recurrence - правило повторения iCalendar RRULE, пусто - задача не повторяется
+	Recurrence string `json:"recurrence" example:"FREQ=WEEKLY;BYDAY=MO"`
+	// ParentID - id родительской задачи, пусто - задача верхнего уровня
+	ParentID string `json:"parentId"`
+	// ProjectID - id проекта задачи, пусто - задача вне проектов
+	ProjectID string `json:"projectId"`
+}
+
+// task преобразует тело запроса в задачу.
+func (r requestTask) task() (entity.Task, error) {
+	activeAt, err := parseDate("activeAt", r.ActiveAt)
+	if err != nil {
+		return entity.Task{}, err
+	}
+
+	task := entity.NewTask(r.Title, activeAt)
+	task.Description = r.Description
+	task.Priority = r.Priority
+	task.Tags = r.Tags
+	task.Recurrence = r.Recurrence
+	task.ParentID = r.ParentID
+	task.ProjectID = r.ProjectID
+
+	if r.DueAt != "" {
+		dueAt, err := parseDate("dueAt", r.DueAt)
+		if err != nil {
+			return entity.Task{}, err
+		}
+
+		task.DueAt = &dueAt
+	}
+
+	return task, nil
 }
 
+// totalCountHeader - заголовок с общим количеством задач под фильтром.
+const totalCountHeader = "X-Total-Count"
+
 // resp определяет структуру ответа на успешное создание задачи.
 type resp struct {
 	ID string `json:"id"`
@@ -59,28 +156,71 @@ type resp struct {
 // list обрабатывает запрос на получение списка задач.
 
 // @Summary List tasks
-// @Description Get a list of tasks based on the provided status
-// @Param status query string false "Status of the tasks (active, done)"
+// @Description Get a page of tasks based on the provided filters.
+// @Description progress counts done checklist items and subtasks out of all of them, cancelled subtasks are left out.
+// @Description Active tasks are listed only once their activeAt has come, unless includeFuture is set.
+// @Description A date without time comes at midnight in the time zone from X-Time-Zone (or the tz query parameter).
+// @Description Pagination is keyset based: follow the cursors from the Link header to get the next or previous page.
+// @Param status query string false "Status of the tasks (active, in_progress, done, cancelled, all)" default(active)
+// @Param from query string false "Earliest activeAt, inclusive (YYYY-MM-DD or RFC 3339)"
+// @Param to query string false "Latest activeAt, inclusive (YYYY-MM-DD or RFC 3339)"
+// @Param X-Time-Zone header string false "IANA time zone used for dates without time, e.g. Asia/Almaty" default(UTC)
+// @Param includeFuture query bool false "Include active tasks whose activeAt is in the future" default(false)
+// @Param overdue query bool false "Only active tasks past their dueAt (true) or all the others (false)"
+// @Param title query string false "Case-insensitive title search"
+// @Param titleMatch query string false "How title is matched (contains, prefix)" default(contains)
+// @Param priority query []string false "Priorities of the tasks (low, normal, high, urgent), any of them" collectionFormat(multi)
+// @Param tag query []string false "Tags the tasks must all have" collectionFormat(multi)
+// @Param parentId query string false "Only subtasks of this task"
+// @Param projectId query string false "Only tasks of this project"
+// @Param assignee query string false "Only tasks of this assignee, me for the current user. Tasks of other owners assigned to the current user are included"
+// @Param limit query int false "Page size, from 1 to 100" default(50)
+// @Param cursor query string false "Opaque page cursor taken from the Link header"
+// @Param sort query string false "Sort key (activeAt, title, id)" default(activeAt)
+// @Param order query string false "Sort direction (asc, desc)" default(asc)
 // @Produce json
 // @Success 200 {array} entity.Task
-// @Failure 400
-// @Failure 500
+// @Header 200 {integer} X-Total-Count "Number of tasks matching the filter across all pages"
+// @Header 200 {string} Link "Links to the next and previous pages (RFC 8288)"
+// @Failure 400 {object} problem
+// @Failure 401 {object} problem
+// @Failure 500 {object} problem
+// @Security BearerAuth
 // @Router /api/v1/todo-list/tasks [get]
 func (t taskRoutes) list(c *gin.Context) {
-	status := getStatus(c)
+	filter, err := getFilter(c)
+	if err != nil {
+		t.respondError(c, err)
+
+		return
+	}
 
-	tasks, err := t.taskUsecase.List(c.Request.Context(), status)
+	t.listTasks(c, filter)
+}
+
+// listTasks отвечает страницей задач под фильтром filter.
+func (t taskRoutes) listTasks(c *gin.Context, filter entity.TaskFilter) {
+	page, err := getPage(c)
 	if err != nil {
-		t.log.Warn("", "error", err)
-		if errors.Is(err, entity.ErrInvalidStatus) {
-			t.respondStatus(c, http.StatusBadRequest, err)
-		} else {
-			t.respondStatus(c, http.StatusInternalServerError, err)
-		}
+		t.respondError(c, err)
+
+		return
+	}
+
+	result, err := t.taskUsecase.List(c.Request.Context(), filter, page)
+	if err != nil {
+		t.respondError(c, err)
 
 		return
 	}
 
+	c.Header(totalCountHeader, strconv.FormatInt(result.Total, 10))
+
+	if links := pageLinks(c, result); links != "" {
+		c.Header("Link", links)
+	}
+
+	tasks := result.Tasks
 	if len(tasks) == 0 {
 		tasks = []entity.Task{}
 	}
@@ -88,40 +228,228 @@ func (t taskRoutes) list(c *gin.Context) {
 	c.JSON(http.StatusOK, tasks)
 }
 
-// getStatus извлекает статус из параметра запроса.
-func getStatus(c *gin.Context) string {
-	return c.Query("status")
+// getPage извлекает параметры пагинации из запроса.
+func getPage(c *gin.Context) (entity.PageRequest, error) {
+	page := entity.PageRequest{
+		SortBy: c.Query("sort"),
+		Order:  c.Query("order"),
+	}
+
+	if raw := c.Query("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit < 1 {
+			return page, entity.NewValidationError("limit", "must be a positive integer", entity.ErrInvalidLimit)
+		}
+
+		page.Limit = limit
+	}
+
+	if raw := c.Query("cursor"); raw != "" {
+		cursor, err := entity.DecodeCursor(raw)
+		if err != nil {
+			return page, entity.NewValidationError("cursor", "is malformed", entity.ErrInvalidCursor)
+		}
+
+		page.Cursor = cursor
+	}
+
+	return page, nil
+}
+
+// pageLinks формирует заголовок Link со ссылками на соседние страницы.
+func pageLinks(c *gin.Context, result entity.TaskPage) string {
+	var links []string
+
+	if result.Next != nil {
+		links = append(links, pageLink(c, result.Next, "next"))
+	}
+
+	if result.Prev != nil {
+		links = append(links, pageLink(c, result.Prev, "prev"))
+	}
+
+	return strings.Join(links, ", ")
+}
+
+// pageLink повторяет текущий запрос с другим курсором.
+func pageLink(c *gin.Context, cursor *entity.Cursor, rel string) string {
+	u := *c.Request.URL
+
+	query := u.Query()
+	query.Set("cursor", cursor.Encode())
+	u.RawQuery = query.Encode()
+
+	return fmt.Sprintf("<%s>; rel=%q", u.RequestURI(), rel)
+}
+
+// search обрабатывает запрос полнотекстового поиска задач.
+
+// @Summary Search tasks
+// @Description Full-text search over task titles and descriptions, ranked by relevance; title matches weigh more.
+// @Description Matched words are wrapped in <mark> in highlights; the rest of the text is HTML-escaped.
+// @Param q query string true "Search query, words prefixed with - are excluded"
+// @Param limit query int false "Maximum number of results, from 1 to 100" default(20)
+// @Produce json
+// @Success 200 {array} entity.SearchResult
+// @Failure 400 {object} problem
+// @Failure 401 {object} problem
+// @Failure 500 {object} problem
+// @Security BearerAuth
+// @Router /api/v1/todo-list/search [get]
+func (t taskRoutes) search(c *gin.Context) {
+	var limit int
+
+	if raw := c.Query("limit"); raw != "" {
+		var err error
+		if limit, err = strconv.Atoi(raw); err != nil || limit < 1 {
+			t.respondError(c, entity.NewValidationError("limit", "must be a positive integer", entity.ErrInvalidLimit))
+
+			return
+		}
+	}
+
+	results, err := t.taskUsecase.Search(c.Request.Context(), c.Query("q"), limit)
+	if err != nil {
+		t.respondError(c, err)
+
+		return
+	}
+
+	if len(results) == 0 {
+		results = []entity.SearchResult{}
+	}
+
+	c.JSON(http.StatusOK, results)
+}
+
+// getFilter извлекает фильтр задач из параметров запроса.
+func getFilter(c *gin.Context) (entity.TaskFilter, error) {
+	filter := entity.TaskFilter{
+		Status:     c.Query("status"),
+		Title:      c.Query("title"),
+		TitleMatch: c.Query("titleMatch"),
+		Priorities: c.QueryArray("priority"),
+		Tags:       c.QueryArray("tag"),
+		ParentID:   c.Query("parentId"),
+		ProjectID:  c.Query("projectId"),
+		Assignee:   c.Query("assignee"),
+	}
+
+	var err error
+
+	if filter.From, err = getDate(c, "from"); err != nil {
+		return filter, err
+	}
+
+	if filter.To, err = getDate(c, "to"); err != nil {
+		return filter, err
+	}
+
+	if raw := c.Query("includeFuture"); raw != "" {
+		if filter.IncludeFuture, err = strconv.ParseBool(raw); err != nil {
+			return filter, entity.NewValidationError("includeFuture", "must be true or false", entity.ErrInvalidFilter)
+		}
+	}
+
+	if raw := c.Query("overdue"); raw != "" {
+		overdue, err := strconv.ParseBool(raw)
+		if err != nil {
+			return filter, entity.NewValidationError("overdue", "must be true or false", entity.ErrInvalidFilter)
+		}
+
+		filter.Overdue = &overdue
+	}
+
+	return filter, nil
+}
+
+// getDate извлекает дату из параметра запроса, отсутствующий параметр даёт нулевую дату.
+func getDate(c *gin.Context, param string) (entity.TaskDate, error) {
+	raw := c.Query(param)
+	if raw == "" {
+		return entity.TaskDate{}, nil
+	}
+
+	return parseDate(param, raw)
+}
+
+// parseDate разбирает дату поля field, ошибка указывает на это поле.
+func parseDate(field, raw string) (entity.TaskDate, error) {
+	date, err := entity.ParseTaskDate(raw)
+	if err != nil {
+		return entity.TaskDate{}, entity.NewValidationError(field, dateMessage, entity.ErrInvalidDate)
+	}
+
+	return date, nil
+}
+
+// get обрабатывает запрос на получение задачи по id.
+
+// @Summary Get task
+// @Description Get a single task, including its status, by ID
+// @Description The ETag header carries the task version; send it back in If-Match to change the task only if nobody changed it since.
+// @Param id path string true "Task ID"
+// @Produce json
+// @Success 200 {object} entity.Task
+// @Header 200 {string} ETag "Quoted task version"
+// @Failure 400 {object} problem
+// @Failure 404 {object} problem
+// @Failure 401 {object} problem
+// @Failure 500 {object} problem
+// @Security BearerAuth
+// @Router /api/v1/todo-list/tasks/{id} [get]
+func (t taskRoutes) get(c *gin.Context) {
+	task, err := t.taskUsecase.Get(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		t.respondError(c, err)
+
+		return
+	}
+
+	c.Header(etagHeader, entity.ETag(task.Version))
+	c.JSON(http.StatusOK, task)
 }
 
 // create обрабатывает запрос на создание новой задачи.
 
 // @Summary Create task
-// @Description Create a new task with the provided title and activeAt date
+// @Description Create a new task with the provided title and activeAt.
+// @Description activeAt and the optional dueAt are either a YYYY-MM-DD date for the whole day or an RFC 3339 timestamp.
+// @Description dueAt must not be before activeAt.
+// @Description recurrence is an iCalendar RRULE subset: FREQ=DAILY|WEEKLY|MONTHLY with INTERVAL, BYDAY, BYMONTHDAY and UNTIL or COUNT.
+// @Description Description, priority (normal by default) and tags are optional; tags are lowercased and deduplicated.
+// @Description parentId makes the task a subtask of an existing task.
+// @Description projectId puts the task into an existing project that is not archived.
 // @Accept json
 // @Produce json
 // @Param requestTask body requestTask true "Task details"
 // @Success 201 {object} resp
-// @Failure 400
-// @Failure 404
-// @Failure 500
+// @Failure 400 {object} problem
+// @Failure 409 {object} problem
+// @Failure 422 {object} problem
+// @Failure 401 {object} problem
+// @Failure 500 {object} problem
+// @Security BearerAuth
 // @Router /api/v1/todo-list/tasks [post]
 func (t taskRoutes) create(c *gin.Context) {
 	var req requestTask
 
-	if err := c.BindJSON(&req); err != nil {
-		t.respondStatus(c, http.StatusInternalServerError, err)
+	if err := bindJSON(c, &req); err != nil {
+		t.respondError(c, err)
+
 		return
 	}
 
-	id, err := t.taskUsecase.Create(c.Request.Context(), req.Title, req.ActiveAt)
+	task, err := req.task()
 	if err != nil {
-		if errors.Is(err, entity.ErrInvalidTitle) || errors.Is(err, entity.ErrInvalidID) {
-			t.respondStatus(c, http.StatusBadRequest, err)
-		} else if errors.Is(err, entity.ErrAlreadyExists) {
-			t.respondStatus(c, http.StatusNotFound, err)
-		} else {
-			t.respondStatus(c, http.StatusInternalServerError, err)
-		}
+		t.respondError(c, err)
+
+		return
+	}
+
+	id, err := t.taskUsecase.Create(c.Request.Context(), task)
+	if err != nil {
+		t.respondError(c, err)
 
 		return
 	}
@@ -138,37 +466,50 @@ func (t taskRoutes) create(c *gin.Context) {
 
 // @Summary Update task
 // @Description Update the details of an existing task
+// @Description The checklist is kept as is, it is changed through the checklist endpoints.
+// @Description With If-Match the task is updated only in that version, otherwise 412 is returned.
 // @Accept json
 // @Produce json
 // @Param id path string true "Task ID"
+// @Param If-Match header string false "ETag of the task version being updated"
 // @Param requestTask body requestTask true "Task details"
 // @Success 204
-// @Failure 400
-// @Failure 404
-// @Failure 500
+// @Failure 400 {object} problem
+// @Failure 404 {object} problem
+// @Failure 409 {object} problem
+// @Failure 412 {object} problem
+// @Failure 422 {object} problem
+// @Failure 401 {object} problem
+// @Failure 500 {object} problem
+// @Security BearerAuth
 // @Router /api/v1/todo-list/tasks/{id} [put]
 func (t taskRoutes) update(c *gin.Context) {
 	var req requestTask
 
-	if err := c.BindJSON(&req); err != nil {
-		t.respondStatus(c, http.StatusInternalServerError, err)
+	if err := withIfMatch(c); err != nil {
+		t.respondError(c, err)
+
+		return
+	}
+
+	if err := bindJSON(c, &req); err != nil {
+		t.respondError(c, err)
 
 		return
 	}
 
 	id := c.Param("id")
 	t.log.Debug(id)
-	task := entity.NewTask(req.Title, req.ActiveAt)
+	task, err := req.task()
+	if err != nil {
+		t.respondError(c, err)
+
+		return
+	}
 	task.ID = id
 
 	if err := t.taskUsecase.UpdateTask(c.Request.Context(), task); err != nil {
-		if errors.Is(err, entity.ErrAlreadyExists) || errors.Is(err, entity.ErrInvalidID) || errors.Is(err, entity.ErrInvalidTitle) {
-			t.respondStatus(c, http.StatusBadRequest, err)
-		} else if errors.Is(err, entity.ErrTaskNotFound) {
-			t.respondStatus(c, http.StatusNotFound, err)
-		} else {
-			t.respondStatus(c, http.StatusInternalServerError, err)
-		}
+		t.respondError(c, err)
 
 		return
 	}
@@ -180,23 +521,28 @@ func (t taskRoutes) update(c *gin.Context) {
 
 // @Summary Delete task
 // @Description Delete an existing task based on its ID
+// @Description With If-Match the task is deleted only in that version, otherwise 412 is returned.
 // @Param id path string true "Task ID"
+// @Param If-Match header string false "ETag of the task version being deleted"
 // @Success 204
-// @Failure 400
-// @Failure 404
-// @Failure 500
+// @Failure 400 {object} problem
+// @Failure 404 {object} problem
+// @Failure 412 {object} problem
+// @Failure 401 {object} problem
+// @Failure 500 {object} problem
+// @Security BearerAuth
 // @Router /api/v1/todo-list/tasks/{id} [delete]
 func (t taskRoutes) delete(c *gin.Context) {
 	id := c.Param("id")
 
+	if err := withIfMatch(c); err != nil {
+		t.respondError(c, err)
+
+		return
+	}
+
 	if err := t.taskUsecase.Delete(c.Request.Context(), id); err != nil {
-		if errors.Is(err, entity.ErrAlreadyExists) || errors.Is(err, entity.ErrInvalidID) {
-			t.respondStatus(c, http.StatusBadRequest, err)
-		} else if errors.Is(err, entity.ErrTaskNotFound) {
-			t.respondStatus(c, http.StatusNotFound, err)
-		} else {
-			t.respondStatus(c, http.StatusInternalServerError, err)
-		}
+		t.respondError(c, err)
 
 		return
 	}
@@ -207,24 +553,93 @@ func (t taskRoutes) delete(c *gin.Context) {
 // markDone обрабатывает запрос на пометку существующей задачи как выполненной.
 
 // @Summary Mark task as done
-// @Description Mark an existing task as done based on its ID
+// @Description Mark an active or in-progress task as done and record its completedAt.
+// @Description For a recurring task the next occurrence is created with activeAt and dueAt moved by the rule.
+// @Description Marking a done task again changes nothing.
+// @Description A task blocked by open tasks is refused with 409.
+// @Description A task with open checklist items or subtasks is refused with 409, or completes them too when cascading completion is configured.
 // @Param id path string true "Task ID"
+// @Param If-Match header string false "ETag of the task version whose status is changed"
 // @Success 204
-// @Failure 400
-// @Failure 404
-// @Failure 500
+// @Failure 400 {object} problem
+// @Failure 404 {object} problem
+// @Failure 412 {object} problem
+// @Failure 409 {object} problem
+// @Failure 401 {object} problem
+// @Failure 500 {object} problem
+// @Security BearerAuth
 // @Router /api/v1/todo-list/tasks/{id}/done [put]
 func (t taskRoutes) markDone(c *gin.Context) {
-	id := c.Param("id")
+	t.changeStatus(c, entity.Done)
+}
 
-	if err := t.taskUsecase.MarkTaskDone(c.Request.Context(), id); err != nil {
-		if errors.Is(err, entity.ErrTaskNotFound) || errors.Is(err, entity.ErrAlreadyExists) {
-			t.respondStatus(c, http.StatusNotFound, err)
-		} else if errors.Is(err, entity.ErrInvalidID) {
-			t.respondStatus(c, http.StatusBadRequest, err)
-		} else {
-			t.respondStatus(c, http.StatusInternalServerError, err)
-		}
+// start обрабатывает запрос на начало работы над задачей.
+
+// @Summary Start task
+// @Description Move an active task to in_progress. Starting an in-progress task again changes nothing.
+// @Param id path string true "Task ID"
+// @Param If-Match header string false "ETag of the task version whose status is changed"
+// @Success 204
+// @Failure 400 {object} problem
+// @Failure 404 {object} problem
+// @Failure 412 {object} problem
+// @Failure 409 {object} problem
+// @Failure 401 {object} problem
+// @Failure 500 {object} problem
+// @Security BearerAuth
+// @Router /api/v1/todo-list/tasks/{id}/start [put]
+func (t taskRoutes) start(c *gin.Context) {
+	t.changeStatus(c, entity.InProgress)
+}
+
+// reopen обрабатывает запрос на возврат задачи в работу.
+
+// @Summary Reopen task
+// @Description Move a done, cancelled or in-progress task back to active and clear its completedAt.
+// @Description Reopening an active task changes nothing.
+// @Param id path string true "Task ID"
+// @Param If-Match header string false "ETag of the task version whose status is changed"
+// @Success 204
+// @Failure 400 {object} problem
+// @Failure 404 {object} problem
+// @Failure 412 {object} problem
+// @Failure 401 {object} problem
+// @Failure 500 {object} problem
+// @Security BearerAuth
+// @Router /api/v1/todo-list/tasks/{id}/reopen [put]
+func (t taskRoutes) reopen(c *gin.Context) {
+	t.changeStatus(c, entity.Active)
+}
+
+// cancel обрабатывает запрос на отмену задачи.
+
+// @Summary Cancel task
+// @Description Cancel an active or in-progress task. Cancelling a cancelled task again changes nothing.
+// @Param id path string true "Task ID"
+// @Param If-Match header string false "ETag of the task version whose status is changed"
+// @Success 204
+// @Failure 400 {object} problem
+// @Failure 404 {object} problem
+// @Failure 412 {object} problem
+// @Failure 409 {object} problem
+// @Failure 401 {object} problem
+// @Failure 500 {object} problem
+// @Security BearerAuth
+// @Router /api/v1/todo-list/tasks/{id}/cancel [put]
+func (t taskRoutes) cancel(c *gin.Context) {
+	t.changeStatus(c, entity.Cancelled)
+}
+
+// changeStatus переводит задачу из пути запроса в статус status.
+func (t taskRoutes) changeStatus(c *gin.Context, status string) {
+	if err := withIfMatch(c); err != nil {
+		t.respondError(c, err)
+
+		return
+	}
+
+	if err := t.taskUsecase.ChangeStatus(c.Request.Context(), c.Param("id"), status); err != nil {
+		t.respondError(c, err)
 
 		return
 	}
@@ -232,7 +647,30 @@ func (t taskRoutes) markDone(c *gin.Context) {
 	c.Status(http.StatusNoContent)
 }
 
-func (t taskRoutes)respondStatus(c *gin.Context, code int, err error) {
-	t.log.Warn(http.StatusText(code), "error", err)
-	c.Status(code)
-} 
\ No newline at end of file
+// withIfMatch сохраняет в контексте запроса версию задачи из заголовка If-Match.
+// Без заголовка задача меняется в любой версии.
+func withIfMatch(c *gin.Context) error {
+	version, err := entity.ParseIfMatch(c.GetHeader(ifMatchHeader))
+	if err != nil {
+		return err
+	}
+
+	c.Request = c.Request.WithContext(entity.WithIfMatch(c.Request.Context(), version))
+
+	return nil
+}
+
+// bindJSON разбирает и валидирует тело запроса.
+// Любая ошибка оборачивается в errInvalidRequest и приводит к ответу 400.
+func bindJSON(c *gin.Context, req any) error {
+	if err := c.ShouldBindJSON(req); err != nil {
+		return fmt.Errorf("%w: %w", errInvalidRequest, err)
+	}
+
+	return nil
+}
+
+// respondError логирует ошибку и отвечает телом application/problem+json.
+func (t taskRoutes) respondError(c *gin.Context, err error) {
+	abortWithProblem(c, t.log, err)
+}
@@ -0,0 +1,312 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/controller/http/v1/task.go
+
+// Package v1 is a generated GoMock package.
+package v1
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entity "github.com/skantay/todo-list/internal/entity"
+)
+
+// MocktaskUsecase is a mock of taskUsecase interface.
+type MocktaskUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MocktaskUsecaseMockRecorder
+}
+
+// MocktaskUsecaseMockRecorder is the mock recorder for MocktaskUsecase.
+type MocktaskUsecaseMockRecorder struct {
+	mock *MocktaskUsecase
+}
+
+// NewMocktaskUsecase creates a new mock instance.
+func NewMocktaskUsecase(ctrl *gomock.Controller) *MocktaskUsecase {
+	mock := &MocktaskUsecase{ctrl: ctrl}
+	mock.recorder = &MocktaskUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocktaskUsecase) EXPECT() *MocktaskUsecaseMockRecorder {
+	return m.recorder
+}
+
+// AddBlocker mocks base method.
+func (m *MocktaskUsecase) AddBlocker(ctx context.Context, id, blockerID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddBlocker", ctx, id, blockerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddBlocker indicates an expected call of AddBlocker.
+func (mr *MocktaskUsecaseMockRecorder) AddBlocker(ctx, id, blockerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddBlocker", reflect.TypeOf((*MocktaskUsecase)(nil).AddBlocker), ctx, id, blockerID)
+}
+
+// AddChecklistItem mocks base method.
+func (m *MocktaskUsecase) AddChecklistItem(ctx context.Context, taskID, title string) (entity.ChecklistItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddChecklistItem", ctx, taskID, title)
+	ret0, _ := ret[0].(entity.ChecklistItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddChecklistItem indicates an expected call of AddChecklistItem.
+func (mr *MocktaskUsecaseMockRecorder) AddChecklistItem(ctx, taskID, title interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddChecklistItem", reflect.TypeOf((*MocktaskUsecase)(nil).AddChecklistItem), ctx, taskID, title)
+}
+
+// Assign mocks base method.
+func (m *MocktaskUsecase) Assign(ctx context.Context, id string, assignees []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Assign", ctx, id, assignees)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Assign indicates an expected call of Assign.
+func (mr *MocktaskUsecaseMockRecorder) Assign(ctx, id, assignees interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Assign", reflect.TypeOf((*MocktaskUsecase)(nil).Assign), ctx, id, assignees)
+}
+
+// Assignments mocks base method.
+func (m *MocktaskUsecase) Assignments(ctx context.Context, id string) ([]entity.Assignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Assignments", ctx, id)
+	ret0, _ := ret[0].([]entity.Assignment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Assignments indicates an expected call of Assignments.
+func (mr *MocktaskUsecaseMockRecorder) Assignments(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Assignments", reflect.TypeOf((*MocktaskUsecase)(nil).Assignments), ctx, id)
+}
+
+// Batch mocks base method.
+func (m *MocktaskUsecase) Batch(ctx context.Context, operations []entity.BatchOperation, atomic bool) ([]entity.BatchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Batch", ctx, operations, atomic)
+	ret0, _ := ret[0].([]entity.BatchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Batch indicates an expected call of Batch.
+func (mr *MocktaskUsecaseMockRecorder) Batch(ctx, operations, atomic interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Batch", reflect.TypeOf((*MocktaskUsecase)(nil).Batch), ctx, operations, atomic)
+}
+
+// ChangeStatus mocks base method.
+func (m *MocktaskUsecase) ChangeStatus(ctx context.Context, id, status string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeStatus", ctx, id, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangeStatus indicates an expected call of ChangeStatus.
+func (mr *MocktaskUsecaseMockRecorder) ChangeStatus(ctx, id, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeStatus", reflect.TypeOf((*MocktaskUsecase)(nil).ChangeStatus), ctx, id, status)
+}
+
+// Create mocks base method.
+func (m *MocktaskUsecase) Create(ctx context.Context, task entity.Task) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, task)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MocktaskUsecaseMockRecorder) Create(ctx, task interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MocktaskUsecase)(nil).Create), ctx, task)
+}
+
+// Delete mocks base method.
+func (m *MocktaskUsecase) Delete(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MocktaskUsecaseMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MocktaskUsecase)(nil).Delete), ctx, id)
+}
+
+// DeleteChecklistItem mocks base method.
+func (m *MocktaskUsecase) DeleteChecklistItem(ctx context.Context, taskID, itemID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteChecklistItem", ctx, taskID, itemID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteChecklistItem indicates an expected call of DeleteChecklistItem.
+func (mr *MocktaskUsecaseMockRecorder) DeleteChecklistItem(ctx, taskID, itemID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteChecklistItem", reflect.TypeOf((*MocktaskUsecase)(nil).DeleteChecklistItem), ctx, taskID, itemID)
+}
+
+// Get mocks base method.
+func (m *MocktaskUsecase) Get(ctx context.Context, id string) (entity.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(entity.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MocktaskUsecaseMockRecorder) Get(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MocktaskUsecase)(nil).Get), ctx, id)
+}
+
+// List mocks base method.
+func (m *MocktaskUsecase) List(ctx context.Context, filter entity.TaskFilter, page entity.PageRequest) (entity.TaskPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, filter, page)
+	ret0, _ := ret[0].(entity.TaskPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MocktaskUsecaseMockRecorder) List(ctx, filter, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MocktaskUsecase)(nil).List), ctx, filter, page)
+}
+
+// MoveTask mocks base method.
+func (m *MocktaskUsecase) MoveTask(ctx context.Context, id, projectID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveTask", ctx, id, projectID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveTask indicates an expected call of MoveTask.
+func (mr *MocktaskUsecaseMockRecorder) MoveTask(ctx, id, projectID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveTask", reflect.TypeOf((*MocktaskUsecase)(nil).MoveTask), ctx, id, projectID)
+}
+
+// Next mocks base method.
+func (m *MocktaskUsecase) Next(ctx context.Context, limit int) ([]entity.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Next", ctx, limit)
+	ret0, _ := ret[0].([]entity.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Next indicates an expected call of Next.
+func (mr *MocktaskUsecaseMockRecorder) Next(ctx, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Next", reflect.TypeOf((*MocktaskUsecase)(nil).Next), ctx, limit)
+}
+
+// PatchTask mocks base method.
+func (m *MocktaskUsecase) PatchTask(ctx context.Context, id string, patch entity.TaskPatch) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PatchTask", ctx, id, patch)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PatchTask indicates an expected call of PatchTask.
+func (mr *MocktaskUsecaseMockRecorder) PatchTask(ctx, id, patch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchTask", reflect.TypeOf((*MocktaskUsecase)(nil).PatchTask), ctx, id, patch)
+}
+
+// RemoveBlocker mocks base method.
+func (m *MocktaskUsecase) RemoveBlocker(ctx context.Context, id, blockerID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveBlocker", ctx, id, blockerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveBlocker indicates an expected call of RemoveBlocker.
+func (mr *MocktaskUsecaseMockRecorder) RemoveBlocker(ctx, id, blockerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveBlocker", reflect.TypeOf((*MocktaskUsecase)(nil).RemoveBlocker), ctx, id, blockerID)
+}
+
+// ReorderChecklist mocks base method.
+func (m *MocktaskUsecase) ReorderChecklist(ctx context.Context, taskID string, itemIDs []string) ([]entity.ChecklistItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReorderChecklist", ctx, taskID, itemIDs)
+	ret0, _ := ret[0].([]entity.ChecklistItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReorderChecklist indicates an expected call of ReorderChecklist.
+func (mr *MocktaskUsecaseMockRecorder) ReorderChecklist(ctx, taskID, itemIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderChecklist", reflect.TypeOf((*MocktaskUsecase)(nil).ReorderChecklist), ctx, taskID, itemIDs)
+}
+
+// Search mocks base method.
+func (m *MocktaskUsecase) Search(ctx context.Context, query string, limit int) ([]entity.SearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, query, limit)
+	ret0, _ := ret[0].([]entity.SearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MocktaskUsecaseMockRecorder) Search(ctx, query, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MocktaskUsecase)(nil).Search), ctx, query, limit)
+}
+
+// ToggleChecklistItem mocks base method.
+func (m *MocktaskUsecase) ToggleChecklistItem(ctx context.Context, taskID, itemID string) (entity.ChecklistItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ToggleChecklistItem", ctx, taskID, itemID)
+	ret0, _ := ret[0].(entity.ChecklistItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ToggleChecklistItem indicates an expected call of ToggleChecklistItem.
+func (mr *MocktaskUsecaseMockRecorder) ToggleChecklistItem(ctx, taskID, itemID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ToggleChecklistItem", reflect.TypeOf((*MocktaskUsecase)(nil).ToggleChecklistItem), ctx, taskID, itemID)
+}
+
+// UpdateTask mocks base method.
+func (m *MocktaskUsecase) UpdateTask(ctx context.Context, task entity.Task) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTask", ctx, task)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTask indicates an expected call of UpdateTask.
+func (mr *MocktaskUsecaseMockRecorder) UpdateTask(ctx, task interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTask", reflect.TypeOf((*MocktaskUsecase)(nil).UpdateTask), ctx, task)
+}
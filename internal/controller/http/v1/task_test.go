@@ -0,0 +1,790 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/skantay/todo-list/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	tasksPath = "/api/v1/todo-list/tasks"
+	taskID    = "661fbb485131cd932a981b26"
+)
+
+// newTestRouter регистрирует маршруты задач поверх мока usecase.
+func newTestRouter(t *testing.T) (*gin.Engine, *MocktaskUsecase) {
+	t.Helper()
+
+	gin.SetMode(gin.TestMode)
+	useJSONFieldNames()
+
+	ctrl := gomock.NewController(t)
+	taskUsecase := NewMocktaskUsecase(ctrl)
+
+	router := gin.New()
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	newTaskRoutes(router.Group("/api/v1/todo-list", requestID(), timeZone(log)), taskUsecase, log)
+
+	return router, taskUsecase
+}
+
+func Test_ErrorMapping(t *testing.T) {
+	errValidation := fmt.Errorf("failed: %w", entity.NewValidationError("title", "must not exceed 200 characters", entity.ErrInvalidTitle))
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		setup      func(m *MocktaskUsecase)
+		wantStatus int
+		wantCode   string
+		wantFields []fieldError
+		// wantCompleted - подзадачи, выполненные каскадом до ошибки
+		wantCompleted []string
+	}{
+		{
+			name:       "#1 create malformed json",
+			method:     http.MethodPost,
+			path:       tasksPath,
+			body:       `{"title":`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   codeInvalidRequest,
+		},
+		{
+			name:       "#2 create invalid date format",
+			method:     http.MethodPost,
+			path:       tasksPath,
+			body:       `{"title":"title","activeAt":"01.04.2024"}`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   codeInvalidDate,
+			wantFields: []fieldError{{Field: "activeAt", Message: dateMessage}},
+		},
+		{
+			name:       "#3 create missing date",
+			method:     http.MethodPost,
+			path:       tasksPath,
+			body:       `{"title":"title"}`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   codeInvalidRequest,
+			wantFields: []fieldError{{Field: "activeAt", Message: "is required"}},
+		},
+		{
+			name:   "#4 create empty title",
+			method: http.MethodPost,
+			path:   tasksPath,
+			body:   `{"title":" ","activeAt":"2024-04-01"}`,
+			setup: func(m *MocktaskUsecase) {
+				m.EXPECT().Create(gomock.Any(), gomock.Any()).
+					Return("", entity.NewValidationError("title", "must not be empty", entity.ErrInvalidTitle))
+			},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantCode:   codeInvalidTitle,
+			wantFields: []fieldError{{Field: "title", Message: "must not be empty"}},
+		},
+		{
+			name:   "#5 create title too long",
+			method: http.MethodPost,
+			path:   tasksPath,
+			body:   `{"title":"title","activeAt":"2024-04-01"}`,
+			setup: func(m *MocktaskUsecase) {
+				m.EXPECT().Create(gomock.Any(), gomock.Any()).Return("", errValidation)
+			},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantCode:   codeInvalidTitle,
+			wantFields: []fieldError{{Field: "title", Message: "must not exceed 200 characters"}},
+		},
+		{
+			name:   "#6 create already exists",
+			method: http.MethodPost,
+			path:   tasksPath,
+			body:   `{"title":"title","activeAt":"2024-04-01"}`,
+			setup: func(m *MocktaskUsecase) {
+				m.EXPECT().Create(gomock.Any(), gomock.Any()).Return("", entity.ErrAlreadyExists)
+			},
+			wantStatus: http.StatusConflict,
+			wantCode:   codeAlreadyExists,
+		},
+		{
+			name:   "#7 update not found",
+			method: http.MethodPut,
+			path:   tasksPath + "/" + taskID,
+			body:   `{"title":"title","activeAt":"2024-04-01"}`,
+			setup: func(m *MocktaskUsecase) {
+				m.EXPECT().UpdateTask(gomock.Any(), gomock.Any()).Return(entity.ErrTaskNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+			wantCode:   codeTaskNotFound,
+		},
+		{
+			name:       "#8 update malformed body",
+			method:     http.MethodPut,
+			path:       tasksPath + "/" + taskID,
+			body:       `{"title":"title","activeAt":20240401}`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   codeInvalidRequest,
+			wantFields: []fieldError{{Field: "activeAt", Message: "must be a string"}},
+		},
+		{
+			name:   "#9 delete invalid id",
+			method: http.MethodDelete,
+			path:   tasksPath + "/1",
+			setup: func(m *MocktaskUsecase) {
+				m.EXPECT().Delete(gomock.Any(), "1").Return(entity.ErrInvalidID)
+			},
+			wantStatus: http.StatusBadRequest,
+			wantCode:   codeInvalidID,
+		},
+		{
+			name:   "#10 mark done not found",
+			method: http.MethodPut,
+			path:   tasksPath + "/" + taskID + "/done",
+			setup: func(m *MocktaskUsecase) {
+				m.EXPECT().ChangeStatus(gomock.Any(), taskID, entity.Done).Return(entity.ErrTaskNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+			wantCode:   codeTaskNotFound,
+		},
+		{
+			name:   "#11 get not found",
+			method: http.MethodGet,
+			path:   tasksPath + "/" + taskID,
+			setup: func(m *MocktaskUsecase) {
+				m.EXPECT().Get(gomock.Any(), taskID).Return(entity.Task{}, entity.ErrTaskNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+			wantCode:   codeTaskNotFound,
+		},
+		{
+			name:   "#12 list invalid status",
+			method: http.MethodGet,
+			path:   tasksPath + "?status=invalid",
+			setup: func(m *MocktaskUsecase) {
+				m.EXPECT().List(gomock.Any(), entity.TaskFilter{Status: "invalid"}, gomock.Any()).Return(entity.TaskPage{}, entity.ErrInvalidStatus)
+			},
+			wantStatus: http.StatusBadRequest,
+			wantCode:   codeInvalidStatus,
+		},
+		{
+			name:   "#13 internal error",
+			method: http.MethodGet,
+			path:   tasksPath,
+			setup: func(m *MocktaskUsecase) {
+				m.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(entity.TaskPage{}, errors.New("connection refused"))
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   codeInternal,
+		},
+		{
+			name:       "#14 list invalid limit",
+			method:     http.MethodGet,
+			path:       tasksPath + "?limit=ten",
+			wantStatus: http.StatusBadRequest,
+			wantCode:   codeInvalidLimit,
+			wantFields: []fieldError{{Field: "limit", Message: "must be a positive integer"}},
+		},
+		{
+			name:       "#15 list invalid from date",
+			method:     http.MethodGet,
+			path:       tasksPath + "?from=yesterday",
+			wantStatus: http.StatusBadRequest,
+			wantCode:   codeInvalidDate,
+			wantFields: []fieldError{{Field: "from", Message: dateMessage}},
+		},
+		{
+			name:       "#16 list invalid includeFuture",
+			method:     http.MethodGet,
+			path:       tasksPath + "?includeFuture=maybe",
+			wantStatus: http.StatusBadRequest,
+			wantCode:   codeInvalidFilter,
+			wantFields: []fieldError{{Field: "includeFuture", Message: "must be true or false"}},
+		},
+		{
+			name:   "#17 search empty query",
+			method: http.MethodGet,
+			path:   "/api/v1/todo-list/search?q=",
+			setup: func(m *MocktaskUsecase) {
+				m.EXPECT().Search(gomock.Any(), "", 0).
+					Return(nil, entity.NewValidationError("q", "must contain at least one word", entity.ErrInvalidQuery))
+			},
+			wantStatus: http.StatusBadRequest,
+			wantCode:   codeInvalidQuery,
+			wantFields: []fieldError{{Field: "q", Message: "must contain at least one word"}},
+		},
+		{
+			name:       "#18 list malformed cursor",
+			method:     http.MethodGet,
+			path:       tasksPath + "?cursor=not-a-cursor",
+			wantStatus: http.StatusBadRequest,
+			wantCode:   codeInvalidCursor,
+			wantFields: []fieldError{{Field: "cursor", Message: "is malformed"}},
+		},
+		{
+			name:   "#19 create invalid tags",
+			method: http.MethodPost,
+			path:   tasksPath,
+			body:   `{"title":"title","activeAt":"2024-04-01","tags":["new year"]}`,
+			setup: func(m *MocktaskUsecase) {
+				m.EXPECT().Create(gomock.Any(), gomock.Any()).
+					Return("", entity.NewValidationError("tags", `"new year" must be up to 32 letters, digits, '-' or '_'`, entity.ErrInvalidTags))
+			},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantCode:   codeInvalidTags,
+			wantFields: []fieldError{{Field: "tags", Message: `"new year" must be up to 32 letters, digits, '-' or '_'`}},
+		},
+		{
+			name:   "#20 list invalid priority",
+			method: http.MethodGet,
+			path:   tasksPath + "?priority=asap",
+			setup: func(m *MocktaskUsecase) {
+				m.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(entity.TaskPage{}, entity.NewValidationError("priority", "must be one of low, normal, high, urgent", entity.ErrInvalidFilter))
+			},
+			wantStatus: http.StatusBadRequest,
+			wantCode:   codeInvalidFilter,
+			wantFields: []fieldError{{Field: "priority", Message: "must be one of low, normal, high, urgent"}},
+		},
+		{
+			name:       "#21 unknown time zone",
+			method:     http.MethodGet,
+			path:       tasksPath + "?tz=Mars/Olympus",
+			wantStatus: http.StatusBadRequest,
+			wantCode:   codeInvalidTimeZone,
+			wantFields: []fieldError{{Field: timeZoneHeader, Message: "must be an IANA time zone name, e.g. Asia/Almaty"}},
+		},
+		{
+			name:       "#22 create invalid due date",
+			method:     http.MethodPost,
+			path:       tasksPath,
+			body:       `{"title":"title","activeAt":"2024-04-01","dueAt":"tomorrow"}`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   codeInvalidDate,
+			wantFields: []fieldError{{Field: "dueAt", Message: dateMessage}},
+		},
+		{
+			name:   "#23 create due before active",
+			method: http.MethodPost,
+			path:   tasksPath,
+			body:   `{"title":"title","activeAt":"2024-04-02","dueAt":"2024-04-01"}`,
+			setup: func(m *MocktaskUsecase) {
+				m.EXPECT().Create(gomock.Any(), gomock.Any()).
+					Return("", entity.NewValidationError("dueAt", "must not be before activeAt", entity.ErrInvalidDueDate))
+			},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantCode:   codeInvalidDueDate,
+			wantFields: []fieldError{{Field: "dueAt", Message: "must not be before activeAt"}},
+		},
+		{
+			name:   "#24 update invalid recurrence",
+			method: http.MethodPut,
+			path:   tasksPath + "/" + taskID,
+			body:   `{"title":"title","activeAt":"2024-04-01","recurrence":"FREQ=HOURLY"}`,
+			setup: func(m *MocktaskUsecase) {
+				m.EXPECT().UpdateTask(gomock.Any(), gomock.Any()).
+					Return(entity.NewValidationError("recurrence", "FREQ must be DAILY, WEEKLY or MONTHLY", entity.ErrInvalidRecurrence))
+			},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantCode:   codeInvalidRecurrence,
+			wantFields: []fieldError{{Field: "recurrence", Message: "FREQ must be DAILY, WEEKLY or MONTHLY"}},
+		},
+		{
+			name:   "#25 start done task",
+			method: http.MethodPut,
+			path:   tasksPath + "/" + taskID + "/start",
+			setup: func(m *MocktaskUsecase) {
+				m.EXPECT().ChangeStatus(gomock.Any(), taskID, entity.InProgress).
+					Return(entity.NewValidationError("status", "cannot change from done to in_progress", entity.ErrInvalidTransition))
+			},
+			wantStatus: http.StatusConflict,
+			wantCode:   codeInvalidTransition,
+			wantFields: []fieldError{{Field: "status", Message: "cannot change from done to in_progress"}},
+		},
+		{
+			name:   "#26 done with open subtasks",
+			method: http.MethodPut,
+			path:   tasksPath + "/" + taskID + "/done",
+			setup: func(m *MocktaskUsecase) {
+				m.EXPECT().ChangeStatus(gomock.Any(), taskID, entity.Done).
+					Return(entity.NewValidationError("status", "has 1 open checklist items and 0 open subtasks", entity.ErrOpenSubtasks))
+			},
+			wantStatus: http.StatusConflict,
+			wantCode:   codeOpenSubtasks,
+			wantFields: []fieldError{{Field: "status", Message: "has 1 open checklist items and 0 open subtasks"}},
+		},
+		{
+			name:   "#27 create with missing parent",
+			method: http.MethodPost,
+			path:   tasksPath,
+			body:   `{"title":"title","activeAt":"2024-04-01","parentId":"661fbb485131cd932a981b27"}`,
+			setup: func(m *MocktaskUsecase) {
+				m.EXPECT().Create(gomock.Any(), gomock.Any()).
+					Return("", entity.NewValidationError("parentId", "must be an existing task", entity.ErrInvalidParent))
+			},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantCode:   codeInvalidParent,
+			wantFields: []fieldError{{Field: "parentId", Message: "must be an existing task"}},
+		},
+		{
+			name:   "#28 toggle missing checklist item",
+			method: http.MethodPut,
+			path:   tasksPath + "/" + taskID + "/checklist/a/toggle",
+			setup: func(m *MocktaskUsecase) {
+				m.EXPECT().ToggleChecklistItem(gomock.Any(), taskID, "a").Return(entity.ChecklistItem{}, entity.ErrChecklistItemNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+			wantCode:   codeChecklistNotFound,
+		},
+		{
+			name:       "#29 add checklist item without title",
+			method:     http.MethodPost,
+			path:       tasksPath + "/" + taskID + "/checklist",
+			body:       `{}`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   codeInvalidRequest,
+			wantFields: []fieldError{{Field: "title", Message: "is required"}},
+		},
+		{
+			name:   "#30 reorder with missing item",
+			method: http.MethodPut,
+			path:   tasksPath + "/" + taskID + "/checklist",
+			body:   `{"items":["a"]}`,
+			setup: func(m *MocktaskUsecase) {
+				m.EXPECT().ReorderChecklist(gomock.Any(), taskID, []string{"a"}).
+					Return(nil, entity.NewValidationError("items", "must list every checklist item exactly once", entity.ErrInvalidChecklist))
+			},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantCode:   codeInvalidChecklist,
+			wantFields: []fieldError{{Field: "items", Message: "must list every checklist item exactly once"}},
+		},
+		{
+			name:   "#31 done while blocked",
+			method: http.MethodPut,
+			path:   tasksPath + "/" + taskID + "/done",
+			setup: func(m *MocktaskUsecase) {
+				m.EXPECT().ChangeStatus(gomock.Any(), taskID, entity.Done).
+					Return(entity.NewValidationError("status", "blocked by 1 open tasks", entity.ErrBlocked))
+			},
+			wantStatus: http.StatusConflict,
+			wantCode:   codeTaskBlocked,
+			wantFields: []fieldError{{Field: "status", Message: "blocked by 1 open tasks"}},
+		},
+		{
+			name:   "#32 blocker creates cycle",
+			method: http.MethodPut,
+			path:   tasksPath + "/" + taskID + "/blockers/661fbb485131cd932a981b27",
+			setup: func(m *MocktaskUsecase) {
+				m.EXPECT().AddBlocker(gomock.Any(), taskID, "661fbb485131cd932a981b27").
+					Return(entity.NewValidationError("blockerId", "would create a dependency cycle", entity.ErrDependencyCycle))
+			},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantCode:   codeDependencyCycle,
+			wantFields: []fieldError{{Field: "blockerId", Message: "would create a dependency cycle"}},
+		},
+		{
+			name:   "#33 missing blocker",
+			method: http.MethodPut,
+			path:   tasksPath + "/" + taskID + "/blockers/661fbb485131cd932a981b27",
+			setup: func(m *MocktaskUsecase) {
+				m.EXPECT().AddBlocker(gomock.Any(), taskID, "661fbb485131cd932a981b27").
+					Return(entity.NewValidationError("blockerId", "must be an existing task", entity.ErrInvalidBlocker))
+			},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantCode:   codeInvalidBlocker,
+			wantFields: []fieldError{{Field: "blockerId", Message: "must be an existing task"}},
+		},
+		{
+			name:       "#34 next invalid limit",
+			method:     http.MethodGet,
+			path:       tasksPath + "/next?limit=x",
+			wantStatus: http.StatusBadRequest,
+			wantCode:   codeInvalidLimit,
+			wantFields: []fieldError{{Field: "limit", Message: "must be a positive integer"}},
+		},
+		{
+			name:   "#35 move to archived project",
+			method: http.MethodPut,
+			path:   tasksPath + "/" + taskID + "/project",
+			body:   `{"projectId":"661fbb485131cd932a981b27"}`,
+			setup: func(m *MocktaskUsecase) {
+				m.EXPECT().MoveTask(gomock.Any(), taskID, "661fbb485131cd932a981b27").
+					Return(entity.NewValidationError("projectId", "must not be an archived project", entity.ErrInvalidProject))
+			},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantCode:   codeInvalidProject,
+			wantFields: []fieldError{{Field: "projectId", Message: "must not be an archived project"}},
+		},
+		{
+			name:   "#36 tasks of missing project",
+			method: http.MethodGet,
+			path:   "/api/v1/todo-list/projects/661fbb485131cd932a981b27/tasks",
+			setup: func(m *MocktaskUsecase) {
+				m.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(entity.TaskPage{}, fmt.Errorf("failed: %w", entity.ErrProjectNotFound))
+			},
+			wantStatus: http.StatusNotFound,
+			wantCode:   codeProjectNotFound,
+		},
+		{
+			name:   "#37 cascade stopped on subtask",
+			method: http.MethodPut,
+			path:   tasksPath + "/" + taskID + "/done",
+			setup: func(m *MocktaskUsecase) {
+				m.EXPECT().ChangeStatus(gomock.Any(), taskID, entity.Done).Return(entity.CascadeError{
+					Completed: []string{"661fbb485131cd932a981b27"},
+					FailedID:  "661fbb485131cd932a981b28",
+					Err:       entity.NewValidationError("status", "blocked by 1 open tasks", entity.ErrBlocked),
+				})
+			},
+			wantStatus:    http.StatusConflict,
+			wantCode:      codeTaskBlocked,
+			wantFields:    []fieldError{{Field: "status", Message: "blocked by 1 open tasks"}},
+			wantCompleted: []string{"661fbb485131cd932a981b27"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, taskUsecase := newTestRouter(t)
+
+			if tt.setup != nil {
+				tt.setup(taskUsecase)
+			}
+
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set(requestIDHeader, "request-1")
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, problemContentType, rec.Header().Get("Content-Type"))
+
+			var p problem
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &p))
+
+			assert.Equal(t, tt.wantStatus, p.Status)
+			assert.Equal(t, tt.wantCode, p.Code)
+			assert.Equal(t, "request-1", p.RequestID)
+			assert.Equal(t, tt.wantFields, p.Errors)
+			assert.Equal(t, tt.wantCompleted, p.CompletedSubtasks)
+		})
+	}
+}
+
+func Test_Create(t *testing.T) {
+	router, taskUsecase := newTestRouter(t)
+
+	want := entity.Task{
+		Title:       "title",
+		Description: "**soon**",
+		ActiveAt:    entity.DateOf(time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)),
+		Priority:    entity.PriorityHigh,
+		Tags:        []string{"Work", "home"},
+		Status:      entity.Active,
+	}
+	taskUsecase.EXPECT().Create(gomock.Any(), want).Return(taskID, nil)
+
+	body := `{"title":"title","activeAt":"2024-04-01","description":"**soon**","priority":"high","tags":["Work","home"]}`
+	req := httptest.NewRequest(http.MethodPost, tasksPath, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.JSONEq(t, `{"id":"`+taskID+`"}`, rec.Body.String())
+	assert.NotEmpty(t, rec.Header().Get(requestIDHeader))
+}
+
+func Test_Get(t *testing.T) {
+	router, taskUsecase := newTestRouter(t)
+
+	task := entity.Task{
+		ID:       taskID,
+		Title:    "title",
+		ActiveAt: entity.DateOf(time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)),
+		Priority: entity.PriorityLow,
+		Tags:     []string{"home"},
+		Status:   entity.Done,
+		Version:  3,
+	}
+	taskUsecase.EXPECT().Get(gomock.Any(), taskID).Return(task, nil)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tasksPath+"/"+taskID, nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `"3"`, rec.Header().Get("ETag"))
+	assert.JSONEq(t, `{"id":"`+taskID+`","title":"title","activeAt":"2024-04-01","priority":"low","tags":["home"],"status":"done","overdue":false,"version":3}`, rec.Body.String())
+}
+
+func Test_Next(t *testing.T) {
+	router, taskUsecase := newTestRouter(t)
+
+	taskUsecase.EXPECT().Next(gomock.Any(), 10).Return([]entity.Task{{
+		ID:        taskID,
+		Title:     "build",
+		ActiveAt:  entity.DateOf(time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)),
+		Priority:  entity.PriorityNormal,
+		Status:    entity.Active,
+		BlockedBy: []string{"661fbb485131cd932a981b27"},
+	}}, nil)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tasksPath+"/next?limit=10", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `[{"id":"`+taskID+`","title":"build","activeAt":"2024-04-01","priority":"normal","status":"active",
+		"blockedBy":["661fbb485131cd932a981b27"],"overdue":false,"version":0}]`, rec.Body.String())
+}
+
+func Test_Checklist(t *testing.T) {
+	router, taskUsecase := newTestRouter(t)
+
+	task := entity.Task{
+		ID:        taskID,
+		Title:     "release",
+		ActiveAt:  entity.DateOf(time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)),
+		Priority:  entity.PriorityNormal,
+		Status:    entity.Active,
+		Checklist: []entity.ChecklistItem{{ID: "a", Title: "changelog", Done: true}, {ID: "b", Title: "tag"}},
+		Progress:  &entity.Progress{Done: 1, Total: 2},
+	}
+	taskUsecase.EXPECT().Get(gomock.Any(), taskID).Return(task, nil)
+	taskUsecase.EXPECT().AddChecklistItem(gomock.Any(), taskID, "announce").
+		Return(entity.ChecklistItem{ID: "c", Title: "announce"}, nil)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tasksPath+"/"+taskID, nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"id":"`+taskID+`","title":"release","activeAt":"2024-04-01","priority":"normal","status":"active",
+		"checklist":[{"id":"a","title":"changelog","done":true},{"id":"b","title":"tag","done":false}],"progress":"1/2","overdue":false,"version":0}`, rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, tasksPath+"/"+taskID+"/checklist", strings.NewReader(`{"title":"announce"}`)))
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.JSONEq(t, `{"id":"c","title":"announce","done":false}`, rec.Body.String())
+}
+
+func Test_Assign(t *testing.T) {
+	router, taskUsecase := newTestRouter(t)
+
+	assignedAt := time.Date(2024, 4, 1, 9, 0, 0, 0, time.UTC)
+	taskUsecase.EXPECT().Assign(gomock.Any(), taskID, []string{"me", "661fbb485131cd932a981b27"}).Return(nil)
+	taskUsecase.EXPECT().Assign(gomock.Any(), taskID, []string{"661fbb485131cd932a981b28"}).
+		Return(entity.NewValidationError("assignees", "661fbb485131cd932a981b28 must be the owner or a user the task is shared with", entity.ErrInvalidAssignee))
+	taskUsecase.EXPECT().Assignments(gomock.Any(), taskID).
+		Return([]entity.Assignment{{Assignees: []string{"661fbb485131cd932a981b27"}, AssignedBy: "661fbb485131cd932a981b27", AssignedAt: assignedAt}}, nil)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, tasksPath+"/"+taskID+"/assignees",
+		strings.NewReader(`{"assignees":["me","661fbb485131cd932a981b27"]}`)))
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, tasksPath+"/"+taskID+"/assignees",
+		strings.NewReader(`{"assignees":["661fbb485131cd932a981b28"]}`)))
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+
+	var p problem
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &p))
+	assert.Equal(t, codeInvalidAssignee, p.Code)
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tasksPath+"/"+taskID+"/assignments", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `[{"assignees":["661fbb485131cd932a981b27"],"assignedBy":"661fbb485131cd932a981b27","assignedAt":"2024-04-01T09:00:00Z"}]`, rec.Body.String())
+}
+
+func Test_IfMatch(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		ifMatch    string
+		setup      func(m *MocktaskUsecase)
+		wantStatus int
+		wantCode   string
+	}{
+		{
+			name:    "#1 update current version",
+			method:  http.MethodPut,
+			path:    tasksPath + "/" + taskID,
+			body:    `{"title":"title","activeAt":"2024-04-01"}`,
+			ifMatch: `"3"`,
+			setup: func(m *MocktaskUsecase) {
+				m.EXPECT().UpdateTask(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, _ entity.Task) error {
+					assert.Equal(t, int64(3), entity.IfMatchFromContext(ctx))
+
+					return nil
+				})
+			},
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:    "#2 update stale version",
+			method:  http.MethodPut,
+			path:    tasksPath + "/" + taskID,
+			body:    `{"title":"title","activeAt":"2024-04-01"}`,
+			ifMatch: `"2"`,
+			setup: func(m *MocktaskUsecase) {
+				m.EXPECT().UpdateTask(gomock.Any(), gomock.Any()).Return(fmt.Errorf("failed to update task: %w", entity.ErrVersionMismatch))
+			},
+			wantStatus: http.StatusPreconditionFailed,
+			wantCode:   codeVersionMismatch,
+		},
+		{
+			name:    "#3 done weak etag",
+			method:  http.MethodPut,
+			path:    tasksPath + "/" + taskID + "/done",
+			ifMatch: `W/"5"`,
+			setup: func(m *MocktaskUsecase) {
+				m.EXPECT().ChangeStatus(gomock.Any(), taskID, entity.Done).DoAndReturn(func(ctx context.Context, _, _ string) error {
+					assert.Equal(t, int64(5), entity.IfMatchFromContext(ctx))
+
+					return nil
+				})
+			},
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:    "#4 delete any version",
+			method:  http.MethodDelete,
+			path:    tasksPath + "/" + taskID,
+			ifMatch: "*",
+			setup: func(m *MocktaskUsecase) {
+				m.EXPECT().Delete(gomock.Any(), taskID).DoAndReturn(func(ctx context.Context, _ string) error {
+					assert.Equal(t, entity.AnyVersion, entity.IfMatchFromContext(ctx))
+
+					return nil
+				})
+			},
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:       "#5 malformed etag",
+			method:     http.MethodDelete,
+			path:       tasksPath + "/" + taskID,
+			ifMatch:    "3",
+			wantStatus: http.StatusBadRequest,
+			wantCode:   codeInvalidVersion,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, taskUsecase := newTestRouter(t)
+			if tt.setup != nil {
+				tt.setup(taskUsecase)
+			}
+
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set(ifMatchHeader, tt.ifMatch)
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+
+			if tt.wantCode != "" {
+				var p problem
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &p))
+				assert.Equal(t, tt.wantCode, p.Code)
+			}
+		})
+	}
+}
+
+func Test_ListFilter(t *testing.T) {
+	router, taskUsecase := newTestRouter(t)
+
+	overdue := true
+
+	want := entity.TaskFilter{
+		Status:        entity.StatusAll,
+		IncludeFuture: true,
+		From:          entity.DateOf(time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)),
+		To:            entity.DateOf(time.Date(2024, 4, 30, 0, 0, 0, 0, time.UTC)),
+		Title:         "milk",
+		TitleMatch:    entity.TitlePrefix,
+		Priorities:    []string{entity.PriorityHigh, entity.PriorityUrgent},
+		Tags:          []string{"work"},
+		Assignee:      entity.AssigneeMe,
+		Overdue:       &overdue,
+	}
+	taskUsecase.EXPECT().List(gomock.Any(), want, gomock.Any()).Return(entity.TaskPage{}, nil)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet,
+		tasksPath+"?status=all&includeFuture=true&from=2024-04-01&to=2024-04-30&title=milk&titleMatch=prefix&priority=high&priority=urgent&tag=work&assignee=me&overdue=true", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `[]`, rec.Body.String())
+}
+
+func Test_TimeZone(t *testing.T) {
+	router, taskUsecase := newTestRouter(t)
+
+	taskUsecase.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, filter entity.TaskFilter, _ entity.PageRequest) (entity.TaskPage, error) {
+			assert.Equal(t, "Asia/Almaty", entity.LocationFromContext(ctx).String())
+			assert.Equal(t, time.Date(2024, 4, 30, 13, 0, 0, 0, time.UTC), filter.To.Time())
+
+			return entity.TaskPage{}, nil
+		})
+
+	req := httptest.NewRequest(http.MethodGet, tasksPath+"?to=2024-04-30T18:00:00%2B05:00", nil)
+	req.Header.Set(timeZoneHeader, "Asia/Almaty")
+	rec := httptest.NewRecorder()
+
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func Test_List(t *testing.T) {
+	router, taskUsecase := newTestRouter(t)
+
+	next := &entity.Cursor{SortBy: entity.SortByTitle, Order: entity.OrderAsc, Value: "b", ID: taskID}
+	prev := &entity.Cursor{SortBy: entity.SortByTitle, Order: entity.OrderAsc, Value: "a", ID: taskID, Backward: true}
+
+	taskUsecase.EXPECT().
+		List(gomock.Any(), entity.TaskFilter{Status: entity.Done}, entity.PageRequest{Limit: 2, SortBy: entity.SortByTitle}).
+		Return(entity.TaskPage{
+			Tasks: []entity.Task{{ID: taskID, Title: "a"}, {ID: taskID, Title: "b"}},
+			Total: 5,
+			Next:  next,
+			Prev:  prev,
+		}, nil)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tasksPath+"?status=done&limit=2&sort=title", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "5", rec.Header().Get(totalCountHeader))
+	assert.Equal(t,
+		`<`+tasksPath+`?cursor=`+next.Encode()+`&limit=2&sort=title&status=done>; rel="next", `+
+			`<`+tasksPath+`?cursor=`+prev.Encode()+`&limit=2&sort=title&status=done>; rel="prev"`,
+		rec.Header().Get("Link"),
+	)
+}
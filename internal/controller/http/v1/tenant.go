@@ -0,0 +1,56 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/skantay/todo-list/internal/entity"
+
+	"github.com/gin-gonic/gin"
+)
+
+// tenantUsecase определяет методы бизнес-логики для рабочих пространств.
+type tenantUsecase interface {
+	Tenant(ctx context.Context, id string) (entity.Tenant, error)
+}
+
+// tenant сохраняет в контексте запроса рабочее пространство из заголовка X-Tenant-ID.
+// Без заголовка пространство определяет токен или API-ключ в authenticate.
+func tenant(tenantUsecase tenantUsecase, log *slog.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(tenantHeader)
+		if id == "" {
+			c.Next()
+
+			return
+		}
+
+		tenant, err := tenantUsecase.Tenant(c.Request.Context(), id)
+		if err != nil {
+			abortWithProblem(c, log, err)
+
+			return
+		}
+
+		c.Request = c.Request.WithContext(entity.WithTenant(c.Request.Context(), tenant))
+
+		c.Next()
+	}
+}
+
+// resolveTenant возвращает рабочее пространство аутентифицированного запроса.
+// Пространство из токена или ключа claimed важнее заголовка, а другой заголовок с ним запрещён.
+func resolveTenant(ctx context.Context, tenantUsecase tenantUsecase, claimed string) (entity.Tenant, error) {
+	id := entity.TenantIDFromContext(ctx)
+
+	if claimed != "" {
+		if id != "" && id != claimed {
+			return entity.Tenant{}, fmt.Errorf("%w: credentials belong to another workspace", entity.ErrForbidden)
+		}
+
+		id = claimed
+	}
+
+	return tenantUsecase.Tenant(ctx, id)
+}
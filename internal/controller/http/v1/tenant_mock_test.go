@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/controller/http/v1/tenant.go
+
+// Package v1 is a generated GoMock package.
+package v1
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entity "github.com/skantay/todo-list/internal/entity"
+)
+
+// MocktenantUsecase is a mock of tenantUsecase interface.
+type MocktenantUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MocktenantUsecaseMockRecorder
+}
+
+// MocktenantUsecaseMockRecorder is the mock recorder for MocktenantUsecase.
+type MocktenantUsecaseMockRecorder struct {
+	mock *MocktenantUsecase
+}
+
+// NewMocktenantUsecase creates a new mock instance.
+func NewMocktenantUsecase(ctrl *gomock.Controller) *MocktenantUsecase {
+	mock := &MocktenantUsecase{ctrl: ctrl}
+	mock.recorder = &MocktenantUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocktenantUsecase) EXPECT() *MocktenantUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Tenant mocks base method.
+func (m *MocktenantUsecase) Tenant(ctx context.Context, id string) (entity.Tenant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Tenant", ctx, id)
+	ret0, _ := ret[0].(entity.Tenant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Tenant indicates an expected call of Tenant.
+func (mr *MocktenantUsecaseMockRecorder) Tenant(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Tenant", reflect.TypeOf((*MocktenantUsecase)(nil).Tenant), ctx, id)
+}
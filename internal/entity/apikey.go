@@ -0,0 +1,144 @@
+package entity
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Ошибки API-ключей
+var (
+	ErrAPIKeyNotFound = errors.New("api key does not exist")
+	ErrInvalidAPIKey  = errors.New("invalid api key")
+	ErrForbidden      = errors.New("forbidden")
+)
+
+// Области действия API-ключа
+const (
+	// ScopeReadWrite разрешает любые запросы
+	ScopeReadWrite = "read_write"
+	// ScopeRead разрешает только чтение
+	ScopeRead = "read"
+)
+
+// Scopes - все области действия API-ключа
+var Scopes = []string{ScopeReadWrite, ScopeRead}
+
+// APIKey - ключ доступа к API для скриптов и сервисов.
+// Сам ключ не хранится, только его SHA-256 хеш.
+type APIKey struct {
+	ID string `json:"id"`
+	// OwnerID - id пользователя, от имени которого действует ключ
+	OwnerID string `json:"-"`
+	// TenantID - рабочее пространство, к которому привязан ключ, пусто - пространство по умолчанию
+	TenantID string `json:"tenantId,omitempty"`
+	Name     string `json:"name"`
+	// Prefix - начало ключа, чтобы отличать ключи друг от друга
+	Prefix    string     `json:"prefix" example:"tdl_Xq3v9Kd2"`
+	Hash      string     `json:"-"`
+	Scope     string     `json:"scope" enums:"read_write,read"`
+	CreatedAt time.Time  `json:"createdAt"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+	RevokedAt *time.Time `json:"revokedAt,omitempty"`
+}
+
+// IsActive сообщает, что ключ не отозван и не истёк к моменту now.
+func (k APIKey) IsActive(now time.Time) bool {
+	return k.RevokedAt == nil && (k.ExpiresAt == nil || now.Before(*k.ExpiresAt))
+}
+
+// CreatedAPIKey - только что созданный ключ. Key показывается один раз и больше недоступен.
+type CreatedAPIKey struct {
+	APIKey
+	Key string `json:"key" example:"tdl_Xq3v9Kd2..."`
+}
+
+// HashAPIKey возвращает SHA-256 хеш ключа, под которым он хранится.
+// Ключ случайный и длинный, поэтому медленный хеш для паролей ему не нужен.
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// apiKeyDocument описывает представление API-ключа в BSON
+type apiKeyDocument struct {
+	ID        primitive.ObjectID `bson:"_id"`
+	OwnerID   string             `bson:"ownerId"`
+	TenantID  string             `bson:"tenantId,omitempty"`
+	Name      string             `bson:"name"`
+	Prefix    string             `bson:"prefix"`
+	Hash      string             `bson:"hash"`
+	Scope     string             `bson:"scope"`
+	CreatedAt time.Time          `bson:"createdAt"`
+	ExpiresAt *time.Time         `bson:"expiresAt,omitempty"`
+	RevokedAt *time.Time         `bson:"revokedAt,omitempty"`
+}
+
+// UnmarshalBSON разбирает BSON APIKey
+func (k *APIKey) UnmarshalBSON(data []byte) error {
+	var rawKey apiKeyDocument
+
+	if err := bson.Unmarshal(data, &rawKey); err != nil {
+		return fmt.Errorf("failed to unmarshal APIKey: %w", err)
+	}
+
+	k.ID = rawKey.ID.Hex()
+
+	k.OwnerID = rawKey.OwnerID
+
+	k.TenantID = rawKey.TenantID
+
+	k.Name = rawKey.Name
+
+	k.Prefix = rawKey.Prefix
+
+	k.Hash = rawKey.Hash
+
+	k.Scope = rawKey.Scope
+
+	k.CreatedAt = rawKey.CreatedAt.UTC()
+
+	k.ExpiresAt = utcTime(rawKey.ExpiresAt)
+
+	k.RevokedAt = utcTime(rawKey.RevokedAt)
+
+	return nil
+}
+
+// MarshalBSON преобразует APIKey в BSON
+func (k APIKey) MarshalBSON() ([]byte, error) {
+	id, err := primitive.ObjectIDFromHex(k.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert ObjectId: %w", err)
+	}
+
+	return bson.Marshal(apiKeyDocument{
+		ID:        id,
+		OwnerID:   k.OwnerID,
+		TenantID:  k.TenantID,
+		Name:      k.Name,
+		Prefix:    k.Prefix,
+		Hash:      k.Hash,
+		Scope:     k.Scope,
+		CreatedAt: k.CreatedAt,
+		ExpiresAt: k.ExpiresAt,
+		RevokedAt: k.RevokedAt,
+	})
+}
+
+// utcTime переводит необязательный момент времени в UTC
+func utcTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+
+	utc := t.UTC()
+
+	return &utc
+}
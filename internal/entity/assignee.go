@@ -0,0 +1,27 @@
+package entity
+
+import (
+	"errors"
+	"slices"
+	"time"
+)
+
+// ErrInvalidAssignee - исполнитель не может быть назначен на задачу
+var ErrInvalidAssignee = errors.New("invalid assignee")
+
+// AssigneeMe - значение фильтра и списка исполнителей, означающее текущего пользователя
+const AssigneeMe = "me"
+
+// Assignment - запись истории назначений задачи
+type Assignment struct {
+	// Assignees - исполнители после назначения, пустой список - исполнители сняты
+	Assignees []string `json:"assignees" bson:"assignees"`
+	// AssignedBy - id пользователя, который назначил исполнителей
+	AssignedBy string    `json:"assignedBy" bson:"assignedBy"`
+	AssignedAt time.Time `json:"assignedAt" bson:"assignedAt"`
+}
+
+// IsAssigned сообщает, что пользователь userID - исполнитель задачи.
+func (t Task) IsAssigned(userID string) bool {
+	return userID != "" && slices.Contains(t.Assignees, userID)
+}
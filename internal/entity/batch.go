@@ -0,0 +1,48 @@
+package entity
+
+import "errors"
+
+// Операции пакетного изменения задач
+const (
+	BatchCreate = "create"
+	BatchUpdate = "update"
+	BatchDone   = "done"
+	BatchDelete = "delete"
+)
+
+var (
+	// ErrInvalidBatch - операция пакета не применима
+	ErrInvalidBatch = errors.New("invalid batch operation")
+	// ErrBatchTooLarge - в пакете больше операций, чем разрешено
+	ErrBatchTooLarge = errors.New("batch too large")
+	// ErrBatchAborted - операция не выполнена, потому что в атомарном пакете не выполнилась другая
+	ErrBatchAborted = errors.New("batch aborted")
+)
+
+// BatchOperation - одна операция пакетного запроса.
+// Task нужна для create и update, ID - для остальных операций.
+// Version - ожидаемая версия задачи, AnyVersion - любая.
+type BatchOperation struct {
+	Op      string
+	ID      string
+	Version int64
+	Task    Task
+}
+
+// BatchResult - результат операции пакета: id задачи или ошибка, из-за которой операция не выполнена.
+type BatchResult struct {
+	ID  string
+	Err error
+}
+
+// TaskWrite - одна запись пакетного изменения задач в хранилище.
+// Для BatchCreate записывается Task, для BatchUpdate - её поля как в полном обновлении,
+// для BatchDone - Task.Status и Task.CompletedAt, для BatchDelete задача удаляется.
+// OwnerID - владелец изменяемой задачи, пустой - пользователь из контекста.
+type TaskWrite struct {
+	Op      string
+	ID      string
+	Version int64
+	OwnerID string
+	Task    Task
+}
@@ -0,0 +1,108 @@
+package entity
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Ошибки чек-листа и подзадач
+var (
+	ErrInvalidChecklist      = errors.New("invalid checklist")
+	ErrChecklistItemNotFound = errors.New("checklist item does not exist")
+	ErrInvalidParent         = errors.New("invalid parent task")
+	ErrOpenSubtasks          = errors.New("task has open subtasks")
+)
+
+// ChecklistItem - пункт чек-листа задачи
+type ChecklistItem struct {
+	ID    string `json:"id" bson:"id"`
+	Title string `json:"title" bson:"title"`
+	Done  bool   `json:"done" bson:"done"`
+}
+
+// NewChecklistItem создаёт невыполненный пункт чек-листа с новым id
+func NewChecklistItem(title string) ChecklistItem {
+	return ChecklistItem{
+		ID:    primitive.NewObjectID().Hex(),
+		Title: title,
+	}
+}
+
+// ChecklistIndex возвращает индекс пункта чек-листа с id или -1, если такого пункта нет.
+func (t Task) ChecklistIndex(id string) int {
+	return slices.IndexFunc(t.Checklist, func(item ChecklistItem) bool {
+		return item.ID == id
+	})
+}
+
+// OpenChecklistItems возвращает количество невыполненных пунктов чек-листа.
+func (t Task) OpenChecklistItems() int {
+	open := 0
+	for _, item := range t.Checklist {
+		if !item.Done {
+			open++
+		}
+	}
+
+	return open
+}
+
+// Progress - сколько пунктов чек-листа и подзадач выполнено из общего числа.
+// В JSON записывается строкой вида "3/5".
+type Progress struct {
+	Done  int
+	Total int
+}
+
+// ProgressOf считает прогресс задачи по её чек-листу и подзадачам children.
+// Отменённые подзадачи не учитываются. nil означает, что считать нечего.
+func ProgressOf(task Task, children []Task) *Progress {
+	p := Progress{
+		Done:  len(task.Checklist) - task.OpenChecklistItems(),
+		Total: len(task.Checklist),
+	}
+
+	for _, child := range children {
+		switch child.Status {
+		case Cancelled:
+		case Done:
+			p.Done++
+			p.Total++
+		default:
+			p.Total++
+		}
+	}
+
+	if p.Total == 0 {
+		return nil
+	}
+
+	return &p
+}
+
+func (p Progress) String() string {
+	return fmt.Sprintf("%d/%d", p.Done, p.Total)
+}
+
+// MarshalJSON преобразует Progress в строку вида "3/5".
+func (p Progress) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.String())
+}
+
+// UnmarshalJSON разбирает строку вида "3/5".
+func (p *Progress) UnmarshalJSON(data []byte) error {
+	var raw string
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("progress must be a string: %w", err)
+	}
+
+	if _, err := fmt.Sscanf(raw, "%d/%d", &p.Done, &p.Total); err != nil {
+		return fmt.Errorf("progress %q must be DONE/TOTAL: %w", raw, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,24 @@
+package entity
+
+import "errors"
+
+// Ошибки зависимостей между задачами
+var (
+	ErrInvalidBlocker  = errors.New("invalid blocker")
+	ErrDependencyCycle = errors.New("dependency cycle")
+	ErrBlocked         = errors.New("task is blocked")
+)
+
+// OpenBlockers возвращает id незавершённых задач, которые блокируют задачу.
+// blockers - известные задачи по id, удалённые блокирующие задачи не учитываются.
+func (t Task) OpenBlockers(blockers map[string]Task) []string {
+	var open []string
+
+	for _, id := range t.BlockedBy {
+		if blocker, ok := blockers[id]; ok && IsOpen(blocker.Status) {
+			open = append(open, id)
+		}
+	}
+
+	return open
+}
@@ -0,0 +1,214 @@
+package entity
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Ошибки параметров списка задач
+var (
+	ErrInvalidCursor = errors.New("invalid cursor")
+	ErrInvalidLimit  = errors.New("invalid limit")
+	ErrInvalidSort   = errors.New("invalid sort")
+	ErrInvalidFilter = errors.New("invalid filter")
+)
+
+// StatusAll - значение фильтра статуса, при котором отбираются задачи в любом статусе
+const StatusAll = "all"
+
+// Способы сравнения заголовка в фильтре
+const (
+	TitleContains = "contains"
+	TitlePrefix   = "prefix"
+)
+
+// Ключи и направления сортировки списка задач
+const (
+	SortByActiveAt = "activeAt"
+	SortByTitle    = "title"
+	SortByID       = "id" // Порядок создания, так как ObjectID начинается с времени
+	OrderAsc       = "asc"
+	OrderDesc      = "desc"
+)
+
+// TaskFilter определяет условия отбора задач.
+type TaskFilter struct {
+	// Status - статус задачи или StatusAll
+	Status string
+	// Now - момент, относительно которого активные задачи считаются наступившими
+	Now time.Time
+	// Location - часовой пояс пользователя для дат без времени, nil - UTC
+	Location *time.Location
+	// IncludeFuture отключает отсечение активных задач с activeAt позже Now
+	IncludeFuture bool
+	// From и To ограничивают activeAt включительно, нулевое значение - без ограничения
+	From TaskDate
+	To   TaskDate
+	// Title - подстрока заголовка без учёта регистра, TitleMatch - TitleContains или TitlePrefix
+	Title      string
+	TitleMatch string
+	// Priorities - допустимые приоритеты, пустой список - любой
+	Priorities []string
+	// Tags - теги, которые должны быть у задачи все одновременно
+	Tags []string
+	// Overdue отбирает только просроченные (true) или только непросроченные (false) задачи, nil - любые
+	Overdue *bool
+	// ParentID отбирает только подзадачи этой задачи, пусто - любые задачи
+	ParentID string
+	// ProjectID отбирает только задачи этого проекта, пусто - любые задачи
+	ProjectID string
+	// Assignee отбирает только задачи этого исполнителя, пусто - любые задачи.
+	// Задачи, назначенные текущему пользователю, видны ему независимо от владельца
+	Assignee string
+}
+
+// Bound - граница диапазона activeAt.
+// Задачи со временем сравниваются с моментом Instant, задачи на весь день - с датой Date.
+type Bound struct {
+	Instant time.Time
+	Date    TaskDate
+}
+
+// For возвращает значение границы, с которым сравнивается activeAt.
+func (b Bound) For(activeAt TaskDate) time.Time {
+	if activeAt.IsDateOnly() {
+		return b.Date.Time()
+	}
+
+	return b.Instant
+}
+
+// StartedBy - граница наступивших к моменту now задач: сам момент и дата now в часовом поясе loc.
+func StartedBy(now time.Time, loc *time.Location) Bound {
+	return Bound{
+		Instant: now,
+		Date:    DateOf(now.In(loc)),
+	}
+}
+
+// Started - верхняя граница activeAt наступивших задач: текущий момент и сегодняшняя дата пользователя.
+func (f TaskFilter) Started() Bound {
+	return StartedBy(f.Now, f.location())
+}
+
+// FromBound - нижняя граница activeAt, включительно.
+// Дата без времени означает начало этого дня в часовом поясе пользователя.
+func (f TaskFilter) FromBound() Bound {
+	return Bound{
+		Instant: f.From.Instant(f.location()),
+		Date:    f.From.Date(f.location()),
+	}
+}
+
+// ToBound - верхняя граница activeAt, включительно.
+// Дата без времени означает конец этого дня в часовом поясе пользователя.
+func (f TaskFilter) ToBound() Bound {
+	return Bound{
+		Instant: f.To.End(f.location()),
+		Date:    f.To.Date(f.location()),
+	}
+}
+
+func (f TaskFilter) location() *time.Location {
+	if f.Location == nil {
+		return time.UTC
+	}
+
+	return f.Location
+}
+
+// PageRequest определяет запрашиваемую страницу списка задач.
+type PageRequest struct {
+	Limit  int
+	SortBy string
+	Order  string
+	// Cursor - граница страницы, nil означает первую страницу
+	Cursor *Cursor
+}
+
+// TaskPage - страница списка задач.
+type TaskPage struct {
+	Tasks []Task
+	// Total - количество задач, подходящих под фильтр, без учёта пагинации
+	Total int64
+	// Next и Prev - курсоры соседних страниц, nil если страницы нет
+	Next *Cursor
+	Prev *Cursor
+}
+
+// Cursor - позиция в отсортированном списке задач для keyset-пагинации.
+// Хранит значение ключа сортировки и id граничной задачи.
+type Cursor struct {
+	SortBy string `json:"s"`
+	Order  string `json:"o"`
+	Value  string `json:"v"`
+	ID     string `json:"id"`
+	// Backward означает страницу перед граничной задачей, а не после неё
+	Backward bool `json:"b,omitempty"`
+}
+
+// NewCursor создаёт курсор на задаче task для указанной сортировки.
+func NewCursor(task Task, sortBy, order string, backward bool) *Cursor {
+	c := &Cursor{
+		SortBy:   sortBy,
+		Order:    order,
+		ID:       task.ID,
+		Backward: backward,
+	}
+
+	switch sortBy {
+	case SortByActiveAt:
+		c.Value = task.ActiveAt.Time().UTC().Format(time.RFC3339Nano)
+	case SortByTitle:
+		c.Value = task.Title
+	}
+
+	return c
+}
+
+// Boundary возвращает граничную задачу курсора с заполненными id и полем сортировки.
+func (c Cursor) Boundary() (Task, error) {
+	task := Task{ID: c.ID}
+
+	switch c.SortBy {
+	case SortByActiveAt:
+		activeAt, err := time.Parse(time.RFC3339Nano, c.Value)
+		if err != nil {
+			return Task{}, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+		}
+		task.ActiveAt = TimeOf(activeAt)
+	case SortByTitle:
+		task.Title = c.Value
+	}
+
+	return task, nil
+}
+
+// Encode кодирует курсор в непрозрачную строку для клиента.
+func (c Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor разбирает строку, полученную из Cursor.Encode.
+func DecodeCursor(s string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+
+	var c Cursor
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+
+	if c.ID == "" {
+		return nil, ErrInvalidCursor
+	}
+
+	return &c, nil
+}
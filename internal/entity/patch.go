@@ -0,0 +1,85 @@
+package entity
+
+import "errors"
+
+// ErrInvalidPatch - документ частичного изменения задачи не применим
+var ErrInvalidPatch = errors.New("invalid patch")
+
+// PatchField - изменение одного поля задачи. Set - поле меняется на Value,
+// нулевое Value удаляет необязательное поле.
+type PatchField[T any] struct {
+	Set   bool
+	Value T
+}
+
+// Change возвращает изменение поля на value.
+func Change[T any](value T) PatchField[T] {
+	return PatchField[T]{Set: true, Value: value}
+}
+
+// apply заменяет target на Value, если поле меняется.
+func (f PatchField[T]) apply(target *T) {
+	if f.Set {
+		*target = f.Value
+	}
+}
+
+// from возвращает такое же изменение поля со значением value.
+func (f PatchField[T]) from(value T) PatchField[T] {
+	if !f.Set {
+		return f
+	}
+
+	return Change(value)
+}
+
+// TaskPatch - частичное изменение задачи: меняются только поля с Set.
+// Статус, чек-лист, блокировки и исполнители меняются своими запросами.
+type TaskPatch struct {
+	Title       PatchField[string]
+	Description PatchField[string]
+	ActiveAt    PatchField[TaskDate]
+	DueAt       PatchField[*TaskDate]
+	Priority    PatchField[string]
+	Tags        PatchField[[]string]
+	Recurrence  PatchField[string]
+	ParentID    PatchField[string]
+	ProjectID   PatchField[string]
+}
+
+// IsEmpty сообщает, что изменение не затрагивает ни одного поля.
+func (p TaskPatch) IsEmpty() bool {
+	return !p.Title.Set && !p.Description.Set && !p.ActiveAt.Set && !p.DueAt.Set && !p.Priority.Set &&
+		!p.Tags.Set && !p.Recurrence.Set && !p.ParentID.Set && !p.ProjectID.Set
+}
+
+// Apply возвращает задачу task с изменёнными полями.
+func (p TaskPatch) Apply(task Task) Task {
+	p.Title.apply(&task.Title)
+	p.Description.apply(&task.Description)
+	p.ActiveAt.apply(&task.ActiveAt)
+	p.DueAt.apply(&task.DueAt)
+	p.Priority.apply(&task.Priority)
+	p.Tags.apply(&task.Tags)
+	p.Recurrence.apply(&task.Recurrence)
+	p.ParentID.apply(&task.ParentID)
+	p.ProjectID.apply(&task.ProjectID)
+
+	return task
+}
+
+// From возвращает изменение тех же полей со значениями из task,
+// например после нормализации задачи с применённым изменением.
+func (p TaskPatch) From(task Task) TaskPatch {
+	return TaskPatch{
+		Title:       p.Title.from(task.Title),
+		Description: p.Description.from(task.Description),
+		ActiveAt:    p.ActiveAt.from(task.ActiveAt),
+		DueAt:       p.DueAt.from(task.DueAt),
+		Priority:    p.Priority.from(task.Priority),
+		Tags:        p.Tags.from(task.Tags),
+		Recurrence:  p.Recurrence.from(task.Recurrence),
+		ParentID:    p.ParentID.from(task.ParentID),
+		ProjectID:   p.ProjectID.from(task.ProjectID),
+	}
+}
@@ -0,0 +1,100 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Ошибки сущности "Проект"
+var (
+	ErrProjectExists     = errors.New("project already exists")
+	ErrProjectNotFound   = errors.New("project does not exist")
+	ErrInvalidProject    = errors.New("invalid project")
+	ErrInvalidDeleteMode = errors.New("invalid delete mode")
+)
+
+// Способы удаления проекта
+const (
+	// DeleteArchive переводит проект в архив, задачи остаются в нём
+	DeleteArchive = "archive"
+	// DeleteCascade удаляет проект вместе с его задачами
+	DeleteCascade = "cascade"
+)
+
+// Project - список, в котором группируются задачи.
+type Project struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// OwnerID - id пользователя, создавшего проект
+	OwnerID string `json:"ownerId,omitempty"`
+	// TenantID - id рабочего пространства проекта, пусто - пространство по умолчанию
+	TenantID string `json:"-"`
+	// Description - описание проекта в формате markdown
+	Description string `json:"description,omitempty"`
+	// ArchivedAt - момент переноса в архив, nil у действующего проекта.
+	// В архивный проект нельзя добавлять задачи
+	ArchivedAt *time.Time `json:"archivedAt,omitempty"`
+}
+
+// IsArchived сообщает, что проект в архиве.
+func (p Project) IsArchived() bool {
+	return p.ArchivedAt != nil
+}
+
+// projectDocument описывает представление проекта в BSON
+type projectDocument struct {
+	ID          primitive.ObjectID `bson:"_id"`
+	Name        string             `bson:"name"`
+	OwnerID     string             `bson:"ownerId,omitempty"`
+	TenantID    string             `bson:"tenantId,omitempty"`
+	Description string             `bson:"description,omitempty"`
+	ArchivedAt  *time.Time         `bson:"archivedAt,omitempty"`
+}
+
+// UnmarshalBSON разбирает BSON Project
+func (p *Project) UnmarshalBSON(data []byte) error {
+	var rawProject projectDocument
+
+	if err := bson.Unmarshal(data, &rawProject); err != nil {
+		return fmt.Errorf("failed to unmarshal Project: %w", err)
+	}
+
+	p.ID = rawProject.ID.Hex()
+
+	p.Name = rawProject.Name
+
+	p.OwnerID = rawProject.OwnerID
+
+	p.TenantID = rawProject.TenantID
+
+	p.Description = rawProject.Description
+
+	p.ArchivedAt = nil
+	if rawProject.ArchivedAt != nil {
+		archivedAt := rawProject.ArchivedAt.UTC()
+		p.ArchivedAt = &archivedAt
+	}
+
+	return nil
+}
+
+// MarshalBSON преобразует Project в BSON
+func (p Project) MarshalBSON() ([]byte, error) {
+	id, err := primitive.ObjectIDFromHex(p.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert ObjectId: %w", err)
+	}
+
+	return bson.Marshal(projectDocument{
+		ID:          id,
+		Name:        p.Name,
+		OwnerID:     p.OwnerID,
+		TenantID:    p.TenantID,
+		Description: p.Description,
+		ArchivedAt:  p.ArchivedAt,
+	})
+}
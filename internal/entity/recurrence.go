@@ -0,0 +1,295 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidRecurrence - правило повторения не разобрано или не поддерживается
+var ErrInvalidRecurrence = errors.New("invalid recurrence rule")
+
+// Частоты повторения из iCalendar RRULE (RFC 5545)
+const (
+	FreqDaily   = "DAILY"
+	FreqWeekly  = "WEEKLY"
+	FreqMonthly = "MONTHLY"
+)
+
+// Форматы UNTIL: дата или момент времени в UTC
+const (
+	untilDateFormat = "20060102"
+	untilTimeFormat = "20060102T150405Z"
+)
+
+// maxPeriodSearch - сколько периодов правила просматривается в поисках следующего повторения.
+// Больше нужно только правилу, которое почти никогда не срабатывает, например BYMONTHDAY=31 раз в 2 месяца.
+const maxPeriodSearch = 1000
+
+// maxInterval - наибольший INTERVAL правила, для WEEKLY это почти 20 лет между повторениями
+const maxInterval = 1000
+
+// weekdayCode - код дня недели в BYDAY
+type weekdayCode struct {
+	code string
+	day  time.Weekday
+}
+
+// weekdayCodes - коды дней недели в порядке с понедельника
+var weekdayCodes = []weekdayCode{
+	{"MO", time.Monday},
+	{"TU", time.Tuesday},
+	{"WE", time.Wednesday},
+	{"TH", time.Thursday},
+	{"FR", time.Friday},
+	{"SA", time.Saturday},
+	{"SU", time.Sunday},
+}
+
+// Recurrence - правило повторения задачи, подмножество iCalendar RRULE:
+// FREQ=DAILY|WEEKLY|MONTHLY, INTERVAL, BYDAY (для WEEKLY), BYMONTHDAY (для MONTHLY), UNTIL или COUNT.
+type Recurrence struct {
+	Freq     string
+	Interval int
+	// ByDay - дни недели повторения, пусто - день недели текущего повторения
+	ByDay []time.Weekday
+	// ByMonthDay - день месяца повторения, 0 - день месяца текущего повторения
+	ByMonthDay int
+	// Until - последний допустимый момент повторения, nil - без ограничения
+	Until *TaskDate
+	// Count - общее количество повторений, 0 - без ограничения
+	Count int
+}
+
+// ParseRecurrence разбирает правило вида FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE.
+// Префикс RRULE: допускается.
+func ParseRecurrence(rule string) (Recurrence, error) {
+	r := Recurrence{Interval: 1}
+
+	rule = strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(rule)), "RRULE:")
+	seen := make(map[string]bool)
+
+	for _, part := range strings.Split(rule, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" {
+			return r, recurrenceError("%q must be KEY=VALUE", part)
+		}
+
+		if seen[key] {
+			return r, recurrenceError("%s is repeated", key)
+		}
+		seen[key] = true
+
+		var err error
+
+		switch key {
+		case "FREQ":
+			r.Freq = value
+		case "INTERVAL":
+			r.Interval, err = parsePositive(value)
+			if err == nil && r.Interval > maxInterval {
+				err = fmt.Errorf("must be from 1 to %d", maxInterval)
+			}
+		case "BYDAY":
+			r.ByDay, err = parseByDay(value)
+		case "BYMONTHDAY":
+			r.ByMonthDay, err = parsePositive(value)
+			if err == nil && r.ByMonthDay > 31 {
+				err = errors.New("must be from 1 to 31")
+			}
+		case "UNTIL":
+			r.Until, err = parseUntil(value)
+		case "COUNT":
+			r.Count, err = parsePositive(value)
+		default:
+			return r, recurrenceError("%s is not supported", key)
+		}
+
+		if err != nil {
+			return r, recurrenceError("%s %s", key, err)
+		}
+	}
+
+	switch {
+	case r.Freq != FreqDaily && r.Freq != FreqWeekly && r.Freq != FreqMonthly:
+		return r, recurrenceError("FREQ must be DAILY, WEEKLY or MONTHLY")
+	case len(r.ByDay) > 0 && r.Freq != FreqWeekly:
+		return r, recurrenceError("BYDAY is supported only with FREQ=WEEKLY")
+	case r.ByMonthDay != 0 && r.Freq != FreqMonthly:
+		return r, recurrenceError("BYMONTHDAY is supported only with FREQ=MONTHLY")
+	case r.Until != nil && r.Count != 0:
+		return r, recurrenceError("UNTIL and COUNT must not be used together")
+	}
+
+	return r, nil
+}
+
+// String возвращает правило в каноническом виде.
+func (r Recurrence) String() string {
+	parts := []string{"FREQ=" + r.Freq}
+
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+
+	if len(r.ByDay) > 0 {
+		var days []string
+		for _, wc := range weekdayCodes {
+			if slices.Contains(r.ByDay, wc.day) {
+				days = append(days, wc.code)
+			}
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+
+	if r.ByMonthDay != 0 {
+		parts = append(parts, "BYMONTHDAY="+strconv.Itoa(r.ByMonthDay))
+	}
+
+	if r.Until != nil {
+		if r.Until.IsDateOnly() {
+			parts = append(parts, "UNTIL="+r.Until.Time().Format(untilDateFormat))
+		} else {
+			parts = append(parts, "UNTIL="+r.Until.Time().UTC().Format(untilTimeFormat))
+		}
+	}
+
+	if r.Count != 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+
+	return strings.Join(parts, ";")
+}
+
+// Next возвращает повторение после current, если правило его допускает.
+// occurrence - номер current в серии начиная с 1, нужен для COUNT.
+// Время суток задач со временем сохраняется в часовом поясе пользователя loc.
+func (r Recurrence) Next(current TaskDate, occurrence int, loc *time.Location) (TaskDate, bool) {
+	if r.Count != 0 && occurrence >= r.Count {
+		return TaskDate{}, false
+	}
+
+	t := current.Time()
+	if !current.IsDateOnly() {
+		t = t.In(loc)
+	}
+
+	next, ok := r.after(t)
+	if !ok {
+		return TaskDate{}, false
+	}
+
+	result := TimeOf(next)
+	if current.IsDateOnly() {
+		result = DateOf(next)
+	}
+
+	if r.Until != nil && result.Instant(loc).After(r.Until.End(loc)) {
+		return TaskDate{}, false
+	}
+
+	return result, true
+}
+
+// after находит первое подходящее под правило время после t в том же часовом поясе.
+func (r Recurrence) after(t time.Time) (time.Time, bool) {
+	switch r.Freq {
+	case FreqDaily:
+		return t.AddDate(0, 0, r.Interval), true
+	case FreqWeekly:
+		days := r.ByDay
+		if len(days) == 0 {
+			days = []time.Weekday{t.Weekday()}
+		}
+
+		// Недели начинаются с понедельника (WKST=MO), повторения идут в каждую Interval-ю неделю от текущей:
+		// сначала оставшиеся дни текущей недели, затем неделя через Interval недель
+		weekday := (int(t.Weekday()) + 6) % 7
+		for offset := 1; weekday+offset < 7; offset++ {
+			if candidate := t.AddDate(0, 0, offset); slices.Contains(days, candidate.Weekday()) {
+				return candidate, true
+			}
+		}
+
+		weekStart := t.AddDate(0, 0, 7*r.Interval-weekday)
+		for offset := 0; offset < 7; offset++ {
+			if candidate := weekStart.AddDate(0, 0, offset); slices.Contains(days, candidate.Weekday()) {
+				return candidate, true
+			}
+		}
+	case FreqMonthly:
+		day := r.ByMonthDay
+		if day == 0 {
+			day = t.Day()
+		}
+
+		// Месяцы без нужного дня пропускаются, как в RFC 5545
+		for period := 1; period <= maxPeriodSearch; period++ {
+			first := time.Date(t.Year(), t.Month()+time.Month(r.Interval*period), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+			candidate := first.AddDate(0, 0, day-1)
+			if candidate.Month() == first.Month() {
+				return candidate, true
+			}
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// daysBetween считает календарные дни от from до to без учёта перехода на летнее время.
+func daysBetween(from, to time.Time) int {
+	return int(DateOf(to).Time().Sub(DateOf(from).Time()).Hours() / 24)
+}
+
+// recurrenceError описывает ошибку в поле recurrence.
+func recurrenceError(format string, args ...any) error {
+	return NewValidationError("recurrence", fmt.Sprintf(format, args...), ErrInvalidRecurrence)
+}
+
+func parsePositive(value string) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 1 {
+		return 0, errors.New("must be a positive integer")
+	}
+
+	return n, nil
+}
+
+func parseByDay(value string) ([]time.Weekday, error) {
+	var days []time.Weekday
+
+	for _, code := range strings.Split(value, ",") {
+		i := slices.IndexFunc(weekdayCodes, func(wc weekdayCode) bool {
+			return wc.code == code
+		})
+		if i < 0 {
+			return nil, fmt.Errorf("%q must be one of MO, TU, WE, TH, FR, SA, SU", code)
+		}
+
+		if !slices.Contains(days, weekdayCodes[i].day) {
+			days = append(days, weekdayCodes[i].day)
+		}
+	}
+
+	return days, nil
+}
+
+func parseUntil(value string) (*TaskDate, error) {
+	if t, err := time.Parse(untilDateFormat, value); err == nil {
+		until := DateOf(t)
+
+		return &until, nil
+	}
+
+	t, err := time.Parse(untilTimeFormat, value)
+	if err != nil {
+		return nil, errors.New("must be YYYYMMDD or YYYYMMDDTHHMMSSZ")
+	}
+
+	until := TimeOf(t)
+
+	return &until, nil
+}
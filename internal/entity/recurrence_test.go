@@ -0,0 +1,137 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseRecurrence(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    string
+		want    string
+		wantErr bool
+	}{
+		{name: "#1 daily", rule: "FREQ=DAILY", want: "FREQ=DAILY"},
+		{name: "#2 canonical form", rule: "rrule:byday=th,mo,mo;freq=weekly;interval=1", want: "FREQ=WEEKLY;BYDAY=MO,TH"},
+		{name: "#3 monthly until", rule: "FREQ=MONTHLY;BYMONTHDAY=31;UNTIL=20241231", want: "FREQ=MONTHLY;BYMONTHDAY=31;UNTIL=20241231"},
+		{name: "#4 every 3 days", rule: "FREQ=DAILY;INTERVAL=3;COUNT=5", want: "FREQ=DAILY;INTERVAL=3;COUNT=5"},
+		{name: "#5 unsupported freq", rule: "FREQ=YEARLY", wantErr: true},
+		{name: "#6 byday with daily", rule: "FREQ=DAILY;BYDAY=MO", wantErr: true},
+		{name: "#7 until with count", rule: "FREQ=DAILY;UNTIL=20241231;COUNT=2", wantErr: true},
+		{name: "#8 unknown part", rule: "FREQ=DAILY;BYHOUR=9", wantErr: true},
+		{name: "#9 bad interval", rule: "FREQ=DAILY;INTERVAL=0", wantErr: true},
+		{name: "#10 ordinal weekday", rule: "FREQ=WEEKLY;BYDAY=1MO", wantErr: true},
+		{name: "#11 interval too large", rule: "FREQ=WEEKLY;INTERVAL=20000000;BYDAY=MO", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := ParseRecurrence(tt.rule)
+			if tt.wantErr {
+				assert.True(t, errors.Is(err, ErrInvalidRecurrence), "unexpected error: %v", err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, rule.String())
+		})
+	}
+}
+
+func Test_RecurrenceNext(t *testing.T) {
+	date := func(year int, month time.Month, day int) TaskDate {
+		return DateOf(time.Date(year, month, day, 0, 0, 0, 0, time.UTC))
+	}
+	almaty := time.FixedZone("UTC+5", 5*60*60)
+
+	tests := []struct {
+		name       string
+		rule       string
+		current    TaskDate
+		occurrence int
+		loc        *time.Location
+		want       TaskDate
+		wantOK     bool
+	}{
+		{
+			name:    "#1 every 3 days",
+			rule:    "FREQ=DAILY;INTERVAL=3",
+			current: date(2024, 2, 27),
+			want:    date(2024, 3, 1),
+			wantOK:  true,
+		},
+		{
+			name:    "#2 weekly same week",
+			rule:    "FREQ=WEEKLY;BYDAY=MO,TH",
+			current: date(2024, 4, 1), // понедельник
+			want:    date(2024, 4, 4),
+			wantOK:  true,
+		},
+		{
+			name:    "#3 every other week",
+			rule:    "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH",
+			current: date(2024, 4, 4), // четверг
+			want:    date(2024, 4, 15),
+			wantOK:  true,
+		},
+		{
+			name:    "#4 largest weekly interval",
+			rule:    "FREQ=WEEKLY;INTERVAL=1000;BYDAY=MO",
+			current: date(2024, 4, 2), // вторник
+			want:    date(2043, 6, 1),
+			wantOK:  true,
+		},
+		{
+			name:    "#5 monthly skips short months",
+			rule:    "FREQ=MONTHLY;BYMONTHDAY=31",
+			current: date(2024, 1, 31),
+			want:    date(2024, 3, 31),
+			wantOK:  true,
+		},
+		{
+			name:    "#6 until reached",
+			rule:    "FREQ=DAILY;UNTIL=20240402",
+			current: date(2024, 4, 2),
+			wantOK:  false,
+		},
+		{
+			name:       "#7 count reached",
+			rule:       "FREQ=DAILY;COUNT=2",
+			current:    date(2024, 4, 2),
+			occurrence: 2,
+			wantOK:     false,
+		},
+		{
+			name:    "#8 wall clock kept in user time zone",
+			rule:    "FREQ=WEEKLY;BYDAY=MO",
+			current: TimeOf(time.Date(2024, 4, 7, 20, 0, 0, 0, time.UTC)), // понедельник 01:00 в UTC+5
+			loc:     almaty,
+			want:    TimeOf(time.Date(2024, 4, 14, 20, 0, 0, 0, time.UTC)),
+			wantOK:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := ParseRecurrence(tt.rule)
+			require.NoError(t, err)
+
+			loc := tt.loc
+			if loc == nil {
+				loc = time.UTC
+			}
+
+			got, ok := rule.Next(tt.current, max(tt.occurrence, 1), loc)
+			assert.Equal(t, tt.wantOK, ok)
+			if tt.wantOK {
+				assert.Equal(t, tt.want.Time(), got.Time())
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+)
+
+// ErrInvalidQuery - пустой или слишком длинный поисковый запрос
+var ErrInvalidQuery = errors.New("invalid search query")
+
+// SearchResult - задача, найденная полнотекстовым поиском.
+type SearchResult struct {
+	Task Task `json:"task"`
+	// Score - релевантность, чем больше, тем выше в выдаче
+	Score float64 `json:"score" example:"1.5"`
+	// Highlights - фрагменты полей с найденными словами, обёрнутыми в <mark>
+	Highlights map[string]string `json:"highlights"`
+}
+
+// Words разбивает текст на слова в нижнем регистре.
+func Words(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// SearchTerms возвращает уникальные слова поискового запроса.
+// Слова с минусом впереди (исключения в синтаксисе MongoDB $text) пропускаются.
+func SearchTerms(query string) []string {
+	var terms []string
+
+	seen := make(map[string]bool)
+
+	for _, field := range strings.Fields(query) {
+		if strings.HasPrefix(field, "-") {
+			continue
+		}
+
+		for _, word := range Words(field) {
+			if !seen[word] {
+				seen[word] = true
+				terms = append(terms, word)
+			}
+		}
+	}
+
+	return terms
+}
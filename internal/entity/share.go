@@ -0,0 +1,155 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Ошибки совместного доступа
+var (
+	ErrShareNotFound = errors.New("share does not exist")
+	ErrShareExists   = errors.New("already shared with this user")
+	ErrInvalidShare  = errors.New("invalid share")
+)
+
+// Роли пользователей, с которыми поделились задачей или проектом, по возрастанию прав
+const (
+	// RoleViewer может только просматривать
+	RoleViewer = "viewer"
+	// RoleEditor может также изменять и выполнять
+	RoleEditor = "editor"
+	// RoleOwner может также удалять и делиться дальше
+	RoleOwner = "owner"
+)
+
+// Roles - все роли по возрастанию прав
+var Roles = []string{RoleViewer, RoleEditor, RoleOwner}
+
+// HasRole сообщает, что роль role даёт не меньше прав, чем required.
+func HasRole(role, required string) bool {
+	return slices.Index(Roles, role) >= slices.Index(Roles, required) && slices.Contains(Roles, role)
+}
+
+// Виды ресурсов, которыми можно поделиться
+const (
+	ResourceTask    = "task"
+	ResourceProject = "project"
+)
+
+// Состояния приглашения
+const (
+	// SharePending - приглашение отправлено, но ещё не принято
+	SharePending = "pending"
+	// ShareAccepted - приглашение принято, доступ действует
+	ShareAccepted = "accepted"
+)
+
+// Share - приглашение пользователя к задаче или проекту с ролью.
+// Доступ к проекту распространяется на все его задачи.
+type Share struct {
+	ID           string `json:"id"`
+	ResourceType string `json:"resourceType" enums:"task,project"`
+	ResourceID   string `json:"resourceId"`
+	// OwnerID - id владельца ресурса
+	OwnerID string `json:"ownerId"`
+	// TenantID - id рабочего пространства ресурса, приглашение действует только в нём
+	TenantID string `json:"-"`
+	// UserID и Email - приглашённый пользователь
+	UserID     string     `json:"userId"`
+	Email      string     `json:"email"`
+	Role       string     `json:"role" enums:"viewer,editor,owner"`
+	Status     string     `json:"status" enums:"pending,accepted"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	AcceptedAt *time.Time `json:"acceptedAt,omitempty"`
+}
+
+// ShareFilter - условия выборки приглашений. Пустые поля не ограничивают выборку.
+type ShareFilter struct {
+	ResourceType string
+	ResourceID   string
+	UserID       string
+	Status       string
+}
+
+// Match сообщает, что приглашение подходит под фильтр.
+func (f ShareFilter) Match(share Share) bool {
+	return (f.ResourceType == "" || f.ResourceType == share.ResourceType) &&
+		(f.ResourceID == "" || f.ResourceID == share.ResourceID) &&
+		(f.UserID == "" || f.UserID == share.UserID) &&
+		(f.Status == "" || f.Status == share.Status)
+}
+
+// shareDocument описывает представление приглашения в BSON
+type shareDocument struct {
+	ID           primitive.ObjectID `bson:"_id"`
+	ResourceType string             `bson:"resourceType"`
+	ResourceID   string             `bson:"resourceId"`
+	OwnerID      string             `bson:"ownerId"`
+	TenantID     string             `bson:"tenantId,omitempty"`
+	UserID       string             `bson:"userId"`
+	Email        string             `bson:"email"`
+	Role         string             `bson:"role"`
+	Status       string             `bson:"status"`
+	CreatedAt    time.Time          `bson:"createdAt"`
+	AcceptedAt   *time.Time         `bson:"acceptedAt,omitempty"`
+}
+
+// UnmarshalBSON разбирает BSON Share
+func (s *Share) UnmarshalBSON(data []byte) error {
+	var rawShare shareDocument
+
+	if err := bson.Unmarshal(data, &rawShare); err != nil {
+		return fmt.Errorf("failed to unmarshal Share: %w", err)
+	}
+
+	s.ID = rawShare.ID.Hex()
+
+	s.ResourceType = rawShare.ResourceType
+
+	s.ResourceID = rawShare.ResourceID
+
+	s.OwnerID = rawShare.OwnerID
+
+	s.TenantID = rawShare.TenantID
+
+	s.UserID = rawShare.UserID
+
+	s.Email = rawShare.Email
+
+	s.Role = rawShare.Role
+
+	s.Status = rawShare.Status
+
+	s.CreatedAt = rawShare.CreatedAt.UTC()
+
+	s.AcceptedAt = utcTime(rawShare.AcceptedAt)
+
+	return nil
+}
+
+// MarshalBSON преобразует Share в BSON
+func (s Share) MarshalBSON() ([]byte, error) {
+	id, err := primitive.ObjectIDFromHex(s.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert ObjectId: %w", err)
+	}
+
+	return bson.Marshal(shareDocument{
+		ID:           id,
+		ResourceType: s.ResourceType,
+		ResourceID:   s.ResourceID,
+		OwnerID:      s.OwnerID,
+		TenantID:     s.TenantID,
+		UserID:       s.UserID,
+		Email:        s.Email,
+		Role:         s.Role,
+		Status:       s.Status,
+		CreatedAt:    s.CreatedAt,
+		AcceptedAt:   s.AcceptedAt,
+	})
+}
@@ -0,0 +1,57 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ErrInvalidTransition - задачу нельзя перевести из текущего статуса в запрошенный
+var ErrInvalidTransition = errors.New("invalid status transition")
+
+// CascadeError - каскадное выполнение остановилось на задаче FailedID с ошибкой Err,
+// когда подзадачи Completed уже были выполнены. Unwrap возвращает Err.
+type CascadeError struct {
+	Completed []string
+	FailedID  string
+	Err       error
+}
+
+func (c CascadeError) Error() string {
+	return fmt.Sprintf("cascade stopped at task %s after completing subtasks %s: %v", c.FailedID, strings.Join(c.Completed, ", "), c.Err)
+}
+
+func (c CascadeError) Unwrap() error {
+	return c.Err
+}
+
+// Statuses - все статусы задачи
+var Statuses = []string{Active, InProgress, Done, Cancelled}
+
+// OpenStatuses - статусы незавершённой задачи, по ним считается просрочка
+var OpenStatuses = []string{Active, InProgress}
+
+// transitions - разрешённые переходы между статусами.
+// Переход в тот же статус разрешён всегда и ничего не меняет.
+var transitions = map[string][]string{
+	Active:     {InProgress, Done, Cancelled},
+	InProgress: {Active, Done, Cancelled},
+	Done:       {Active},
+	Cancelled:  {Active},
+}
+
+// IsStatus сообщает, что status - один из статусов задачи.
+func IsStatus(status string) bool {
+	return slices.Contains(Statuses, status)
+}
+
+// IsOpen сообщает, что задача в статусе status ещё не завершена.
+func IsOpen(status string) bool {
+	return slices.Contains(OpenStatuses, status)
+}
+
+// CanTransition сообщает, можно ли перевести задачу из статуса from в статус to.
+func CanTransition(from, to string) bool {
+	return from == to || slices.Contains(transitions[from], to)
+}
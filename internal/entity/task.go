@@ -17,31 +17,115 @@ var (
 	ErrInvalidTitle  = errors.New("invalid title")
 	ErrInvalidStatus = errors.New("invalid status")
 	ErrInvalidID     = errors.New("invalid id")
+	ErrInvalidDate   = errors.New("invalid date")
+
+	ErrInvalidDueDate     = errors.New("invalid due date")
+	ErrInvalidDescription = errors.New("invalid description")
+	ErrInvalidPriority    = errors.New("invalid priority")
+	ErrInvalidTags        = errors.New("invalid tags")
 )
 
+// ValidationError описывает, почему конкретное поле задачи не прошло валидацию.
+// Unwrap возвращает одну из общих ошибок выше, поэтому errors.Is продолжает работать.
+type ValidationError struct {
+	Field  string
+	Reason string
+	Err    error
+}
+
+func NewValidationError(field, reason string, err error) ValidationError {
+	return ValidationError{
+		Field:  field,
+		Reason: reason,
+		Err:    err,
+	}
+}
+
+func (v ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s %s", v.Err, v.Field, v.Reason)
+}
+
+func (v ValidationError) Unwrap() error {
+	return v.Err
+}
+
 // Константы для статусов задачи и формата даты
 const (
 	Active     = "active"
+	InProgress = "in_progress"
 	Done       = "done"
+	Cancelled  = "cancelled"
 	dateFormat = "2006-01-02"
 )
 
-// TaskDate определяет пользовательский тип для даты задачи
-type TaskDate time.Time
+// Приоритеты задачи
+const (
+	PriorityLow    = "low"
+	PriorityNormal = "normal"
+	PriorityHigh   = "high"
+	PriorityUrgent = "urgent"
+)
+
+// Priorities - допустимые приоритеты в порядке возрастания
+var Priorities = []string{PriorityLow, PriorityNormal, PriorityHigh, PriorityUrgent}
+
+// TaskDate определяет пользовательский тип для даты задачи.
+// Дата без времени хранится как полночь UTC и означает весь день в часовом поясе пользователя,
+// момент времени отмечен флагом timed: он тоже может прийтись на полночь UTC.
+type TaskDate struct {
+	t     time.Time
+	timed bool
+}
 
 type Task struct {
-	ID       string   `json:"id"`
-	Title    string   `json:"title"`
-	ActiveAt TaskDate `json:"activeAt"`
-	Status   string   `json:"-"`
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	// Description - описание задачи в формате markdown
+	Description string   `json:"description,omitempty"`
+	ActiveAt    TaskDate `json:"activeAt" swaggertype:"string" example:"2024-04-01"`
+	// DueAt - срок выполнения, nil если срока нет
+	DueAt    *TaskDate `json:"dueAt,omitempty" swaggertype:"string" example:"2024-04-05"`
+	Priority string    `json:"priority" enums:"low,normal,high,urgent"`
+	// Tags - множество тегов, хранится без повторов в порядке сортировки
+	Tags   []string `json:"tags,omitempty"`
+	Status string   `json:"status" enums:"active,in_progress,done,cancelled"`
+	// CompletedAt - момент выполнения, есть только у задач в статусе done
+	CompletedAt *time.Time `json:"completedAt,omitempty"`
+	// Recurrence - правило повторения iCalendar RRULE, пусто - задача не повторяется
+	Recurrence string `json:"recurrence,omitempty" example:"FREQ=WEEKLY;BYDAY=MO"`
+	// Occurrence - номер повторения в серии начиная с 1
+	Occurrence int `json:"occurrence,omitempty" example:"1"`
+	// OwnerID - id пользователя, создавшего задачу. Другие пользователи задачу не видят
+	OwnerID string `json:"ownerId,omitempty"`
+	// TenantID - id рабочего пространства задачи, пусто - пространство по умолчанию
+	TenantID string `json:"-"`
+	// ProjectID - id проекта, в котором находится задача, пусто - задача вне проектов
+	ProjectID string `json:"projectId,omitempty"`
+	// ParentID - id родительской задачи, пусто у задачи верхнего уровня
+	ParentID string `json:"parentId,omitempty"`
+	// BlockedBy - id задач, которые должны быть завершены раньше этой
+	BlockedBy []string `json:"blockedBy,omitempty"`
+	// Assignees - id исполнителей задачи
+	Assignees []string `json:"assignees,omitempty"`
+	// AssignmentHistory - история назначений, от старых к новым. Отдаётся отдельным запросом
+	AssignmentHistory []Assignment `json:"-"`
+	// Checklist - упорядоченный чек-лист задачи
+	Checklist []ChecklistItem `json:"checklist,omitempty"`
+	// Progress - выполненные пункты чек-листа и подзадачи. Вычисляется при чтении и не хранится
+	Progress *Progress `json:"progress,omitempty" swaggertype:"string" example:"3/5"`
+	// Overdue - срок прошёл, а задача не выполнена. Вычисляется при чтении и не хранится
+	Overdue bool `json:"overdue"`
+	// Version - номер версии, растёт при каждом изменении задачи. Отдаётся в ETag
+	Version int64 `json:"version" example:"1"`
 }
 
 // NewTask создает новую задачу
-// Со значением status="active"
+// Со значением status="active" и priority="normal"
 func NewTask(title string, activeAt TaskDate) Task {
 	t := Task{
 		Title:    title,
 		ActiveAt: activeAt,
+		Priority: PriorityNormal,
 	}
 
 	t.SetStatusActive()
@@ -53,57 +137,274 @@ func (t *Task) SetStatusDone() {
 	t.Status = Done
 }
 
+// SetStatus переводит задачу в статус status в момент at.
+// completedAt заполняется только для выполненной задачи.
+func (t *Task) SetStatus(status string, at time.Time) {
+	t.Status = status
+
+	t.CompletedAt = nil
+	if status == Done {
+		completedAt := at.UTC()
+		t.CompletedAt = &completedAt
+	}
+}
+
 func (t *Task) SetStatusActive() {
 	t.Status = Active
 }
 
 func (td TaskDate) Time() time.Time {
-	return time.Time(td)
+	return td.t
+}
+
+// IsOverdue сообщает, что срок задачи прошёл к моменту started, а задача ещё открыта.
+// Задача со сроком на весь день просрочена со следующего дня.
+func (t Task) IsOverdue(started Bound) bool {
+	if !IsOpen(t.Status) || t.DueAt == nil {
+		return false
+	}
+
+	return t.DueAt.Time().Before(started.For(*t.DueAt))
+}
+
+// DateOf возвращает календарную дату момента t в его часовом поясе.
+func DateOf(t time.Time) TaskDate {
+	year, month, day := t.Date()
+
+	return TaskDate{t: time.Date(year, month, day, 0, 0, 0, 0, time.UTC)}
+}
+
+// TimeOf возвращает момент времени t.
+func TimeOf(t time.Time) TaskDate {
+	return TaskDate{t: t.UTC(), timed: true}
+}
+
+// IsDateOnly сообщает, что значение - дата без времени.
+func (td TaskDate) IsDateOnly() bool {
+	return !td.timed
+}
+
+// Date возвращает календарную дату в часовом поясе loc.
+func (td TaskDate) Date(loc *time.Location) TaskDate {
+	if td.IsDateOnly() {
+		return td
+	}
+
+	return DateOf(td.Time().In(loc))
+}
+
+// Instant возвращает момент времени, для даты без времени - начало дня в часовом поясе loc.
+func (td TaskDate) Instant(loc *time.Location) time.Time {
+	if !td.IsDateOnly() {
+		return td.Time()
+	}
+
+	year, month, day := td.Time().Date()
+
+	return time.Date(year, month, day, 0, 0, 0, 0, loc).UTC()
+}
+
+// NextOccurrence возвращает следующее повторение задачи: новую активную задачу без id
+// с activeAt по правилу Recurrence и dueAt, сдвинутым на столько же.
+// false означает, что серия закончилась или задача не повторяется.
+func (t Task) NextOccurrence(loc *time.Location) (Task, bool, error) {
+	if t.Recurrence == "" {
+		return Task{}, false, nil
+	}
+
+	rule, err := ParseRecurrence(t.Recurrence)
+	if err != nil {
+		return Task{}, false, err
+	}
+
+	occurrence := max(t.Occurrence, 1)
+
+	activeAt, ok := rule.Next(t.ActiveAt, occurrence, loc)
+	if !ok {
+		return Task{}, false, nil
+	}
+
+	next := NewTask(t.Title, activeAt)
+	next.Description = t.Description
+	next.Priority = t.Priority
+	next.Tags = t.Tags
+	next.Recurrence = t.Recurrence
+	next.OwnerID = t.OwnerID
+	next.TenantID = t.TenantID
+	next.ProjectID = t.ProjectID
+	next.ParentID = t.ParentID
+	next.Assignees = t.Assignees
+	next.Occurrence = occurrence + 1
+
+	if t.DueAt != nil {
+		var dueAt TaskDate
+		if t.DueAt.IsDateOnly() {
+			// Срок на весь день остаётся датой через столько же дней после начала
+			days := daysBetween(t.ActiveAt.Date(loc).Time(), t.DueAt.Time())
+			dueAt = DateOf(activeAt.Date(loc).Time().AddDate(0, 0, days))
+		} else {
+			dueAt = TimeOf(activeAt.Instant(loc).Add(t.DueAt.Time().Sub(t.ActiveAt.Instant(loc))))
+		}
+		next.DueAt = &dueAt
+	}
+
+	return next, true, nil
+}
+
+// End возвращает последний момент значения, для даты без времени - конец дня в часовом поясе loc.
+func (td TaskDate) End(loc *time.Location) time.Time {
+	if !td.IsDateOnly() {
+		return td.Time()
+	}
+
+	return DateOf(td.Time().AddDate(0, 0, 1)).Instant(loc).Add(-time.Nanosecond)
+}
+
+// ParseTaskDate разбирает дату в формате YYYY-MM-DD или момент времени в формате RFC 3339
+func ParseTaskDate(raw string) (TaskDate, error) {
+	if parsedDate, err := time.Parse(dateFormat, raw); err == nil {
+		return DateOf(parsedDate), nil
+	}
+
+	parsedTime, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return TaskDate{}, fmt.Errorf("%w: %q must be a YYYY-MM-DD date or an RFC 3339 timestamp", ErrInvalidDate, raw)
+	}
+
+	// Момент остаётся моментом, даже если приходится на полночь UTC
+	return TimeOf(parsedTime), nil
 }
 
 // UnmarshalJSON разбирает JSON TaskDate
 func (td *TaskDate) UnmarshalJSON(data []byte) error {
 	var rawDate string
 	if err := json.Unmarshal(data, &rawDate); err != nil {
-		return fmt.Errorf("failed to unmarshal: %w", err)
+		return fmt.Errorf("%w: must be a string: %w", ErrInvalidDate, err)
 	}
 
-	parsedDate, err := time.Parse(dateFormat, rawDate)
+	parsedDate, err := ParseTaskDate(rawDate)
 	if err != nil {
-		return fmt.Errorf("failed to parse: %w", err)
+		return err
 	}
 
-	*td = TaskDate(parsedDate)
-	
+	*td = parsedDate
+
 	return nil
 }
 
-// MarshalJSON преобразует TaskDate в JSON
+// MarshalJSON преобразует TaskDate в JSON.
+// Дата без времени остаётся в формате YYYY-MM-DD для совместимости со старыми клиентами.
 func (td TaskDate) MarshalJSON() ([]byte, error) {
-	return []byte(fmt.Sprintf(`"%s"`, time.Time(td).Format(dateFormat))), nil
+	if td.IsDateOnly() {
+		return []byte(fmt.Sprintf(`"%s"`, td.t.Format(dateFormat))), nil
+	}
+
+	return []byte(fmt.Sprintf(`"%s"`, td.t.UTC().Format(time.RFC3339))), nil
+}
+
+// storedDate восстанавливает дату из документа по флагу timed.
+// У документов, записанных до появления моментов времени, флага нет, и в них только даты,
+// поэтому значение не в полночь UTC без флага тоже читается как момент.
+func storedDate(t time.Time, timed bool) TaskDate {
+	t = t.UTC()
+	if timed || !t.Equal(t.Truncate(24*time.Hour)) {
+		return TimeOf(t)
+	}
+
+	return TaskDate{t: t}
+}
+
+// taskDocument описывает представление задачи в BSON
+type taskDocument struct {
+	ID          primitive.ObjectID `bson:"_id"`
+	Title       string             `bson:"title"`
+	Description string             `bson:"description,omitempty"`
+	ActiveAt    time.Time          `bson:"activeAt"`
+	// Timed отличает момент времени от даты без времени, у старых документов его нет
+	Timed       bool            `bson:"timed,omitempty"`
+	DueAt       *time.Time      `bson:"dueAt,omitempty"`
+	DueTimed    bool            `bson:"dueTimed,omitempty"`
+	Recurrence  string          `bson:"recurrence,omitempty"`
+	Occurrence  int             `bson:"occurrence,omitempty"`
+	Priority    string          `bson:"priority"`
+	Tags        []string        `bson:"tags,omitempty"`
+	Status      string          `bson:"status"`
+	CompletedAt *time.Time      `bson:"completedAt,omitempty"`
+	OwnerID     string          `bson:"ownerId,omitempty"`
+	TenantID    string          `bson:"tenantId,omitempty"`
+	ProjectID   string          `bson:"projectId,omitempty"`
+	ParentID    string          `bson:"parentId,omitempty"`
+	BlockedBy   []string        `bson:"blockedBy,omitempty"`
+	Checklist   []ChecklistItem `bson:"checklist,omitempty"`
+	Assignees   []string        `bson:"assignees,omitempty"`
+	History     []Assignment    `bson:"assignmentHistory,omitempty"`
+	// Version у старых документов нет, они читаются как версия 0
+	Version int64 `bson:"version"`
 }
 
 // UnmarshalBSON разбирает BSON Task
 func (t *Task) UnmarshalBSON(data []byte) error {
-	var rawTask struct {
-		ID       primitive.ObjectID `bson:"_id"`
-		Title    string             `bson:"title"`
-		ActiveAt time.Time          `bson:"activeAt"`
-		Status   string             `bson:"status"`
-	}
+	var rawTask taskDocument
 
 	if err := bson.Unmarshal(data, &rawTask); err != nil {
 		return fmt.Errorf("failed to unmarshal Task: %w", err)
 	}
 
 	t.ID = rawTask.ID.Hex()
-	
+
 	t.Title = rawTask.Title
-	
-	t.ActiveAt = TaskDate(rawTask.ActiveAt)
-	
+
+	t.Description = rawTask.Description
+
+	t.ActiveAt = storedDate(rawTask.ActiveAt, rawTask.Timed)
+
+	t.DueAt = nil
+	if rawTask.DueAt != nil {
+		dueAt := storedDate(*rawTask.DueAt, rawTask.DueTimed)
+		t.DueAt = &dueAt
+	}
+
+	t.CompletedAt = nil
+	if rawTask.CompletedAt != nil {
+		completedAt := rawTask.CompletedAt.UTC()
+		t.CompletedAt = &completedAt
+	}
+
+	// Документы, созданные до появления приоритетов, считаются обычными
+	t.Priority = rawTask.Priority
+	if t.Priority == "" {
+		t.Priority = PriorityNormal
+	}
+
+	t.Tags = rawTask.Tags
+
 	t.Status = rawTask.Status
-	
+
+	t.Recurrence = rawTask.Recurrence
+
+	t.Occurrence = rawTask.Occurrence
+
+	t.OwnerID = rawTask.OwnerID
+
+	t.TenantID = rawTask.TenantID
+
+	t.ProjectID = rawTask.ProjectID
+
+	t.ParentID = rawTask.ParentID
+
+	t.BlockedBy = rawTask.BlockedBy
+
+	t.Checklist = rawTask.Checklist
+
+	t.Assignees = rawTask.Assignees
+
+	t.AssignmentHistory = rawTask.History
+	for i := range t.AssignmentHistory {
+		t.AssignmentHistory[i].AssignedAt = t.AssignmentHistory[i].AssignedAt.UTC()
+	}
+
+	t.Version = rawTask.Version
+
 	return nil
 }
 
@@ -114,15 +415,34 @@ func (t Task) MarshalBSON() ([]byte, error) {
 		return nil, fmt.Errorf("failed to convert ObjectId: %w", err)
 	}
 
-	return bson.Marshal(struct {
-		ID       primitive.ObjectID `bson:"_id"`
-		Title    string             `bson:"title"`
-		ActiveAt time.Time          `bson:"activeAt"`
-		Status   string             `bson:"status"`
-	}{
-		ID:       id,
-		Title:    t.Title,
-		ActiveAt: time.Time(t.ActiveAt),
-		Status:   t.Status,
+	var dueAt *time.Time
+	if t.DueAt != nil {
+		due := t.DueAt.Time()
+		dueAt = &due
+	}
+
+	return bson.Marshal(taskDocument{
+		ID:          id,
+		Title:       t.Title,
+		Description: t.Description,
+		ActiveAt:    t.ActiveAt.Time(),
+		Timed:       !t.ActiveAt.IsDateOnly(),
+		DueAt:       dueAt,
+		DueTimed:    t.DueAt != nil && !t.DueAt.IsDateOnly(),
+		Recurrence:  t.Recurrence,
+		Occurrence:  t.Occurrence,
+		CompletedAt: t.CompletedAt,
+		Priority:    t.Priority,
+		Tags:        t.Tags,
+		Status:      t.Status,
+		OwnerID:     t.OwnerID,
+		TenantID:    t.TenantID,
+		ProjectID:   t.ProjectID,
+		ParentID:    t.ParentID,
+		BlockedBy:   t.BlockedBy,
+		Checklist:   t.Checklist,
+		Assignees:   t.Assignees,
+		History:     t.AssignmentHistory,
+		Version:     t.Version,
 	})
 }
@@ -0,0 +1,56 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func Test_TaskDate(t *testing.T) {
+	almaty := time.FixedZone("UTC+5", 5*60*60)
+
+	tests := []struct {
+		name         string
+		raw          string
+		wantDateOnly bool
+		wantJSON     string
+		wantDate     string
+	}{
+		{name: "#1 date", raw: "2024-04-01", wantDateOnly: true, wantJSON: `"2024-04-01"`, wantDate: "2024-04-01"},
+		{name: "#2 moment", raw: "2024-04-01T09:30:00Z", wantJSON: `"2024-04-01T09:30:00Z"`, wantDate: "2024-04-01"},
+		{name: "#3 moment at midnight UTC", raw: "2024-04-01T05:00:00+05:00", wantJSON: `"2024-04-01T00:00:00Z"`, wantDate: "2024-04-01"},
+		{name: "#4 midnight UTC in other zone", raw: "2024-04-01T00:00:00Z", wantJSON: `"2024-04-01T00:00:00Z"`, wantDate: "2024-04-01"},
+		{name: "#5 moment on previous day in UTC", raw: "2024-04-01T02:00:00+05:00", wantJSON: `"2024-03-31T21:00:00Z"`, wantDate: "2024-04-01"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			date, err := ParseTaskDate(tt.raw)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantDateOnly, date.IsDateOnly())
+			assert.Equal(t, tt.wantDate, date.Date(almaty).Time().Format(dateFormat))
+
+			data, err := date.MarshalJSON()
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantJSON, string(data))
+
+			// Флаг момента переживает запись в хранилище и чтение обратно
+			task := NewTask("title", date)
+			task.ID = "661fbb485131cd932a981b26"
+			task.DueAt = &date
+
+			document, err := bson.Marshal(task)
+			require.NoError(t, err)
+
+			var stored Task
+			require.NoError(t, bson.Unmarshal(document, &stored))
+			assert.Equal(t, date, stored.ActiveAt)
+			require.NotNil(t, stored.DueAt)
+			assert.Equal(t, date, *stored.DueAt)
+		})
+	}
+}
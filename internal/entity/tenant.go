@@ -0,0 +1,51 @@
+package entity
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrInvalidTenant - рабочее пространство не указано или неизвестно
+var ErrInvalidTenant = errors.New("invalid tenant")
+
+// DefaultWeekendPrefix - префикс заголовка задач на выходные, если пространство не задало свой
+const DefaultWeekendPrefix = "ВЫХОДНОЙ - "
+
+// Tenant - рабочее пространство команды. Задачи разных пространств не видны друг другу.
+type Tenant struct {
+	ID string `json:"id"`
+	// Location - часовой пояс запросов без X-Time-Zone
+	Location *time.Location `json:"-"`
+	// WeekendPrefix - префикс заголовка задач, выпадающих на выходные, пусто - задачи не помечаются
+	WeekendPrefix string `json:"weekendPrefix"`
+}
+
+// DefaultTenant возвращает пространство по умолчанию: без id, в UTC и с обычным префиксом выходных.
+func DefaultTenant() Tenant {
+	return Tenant{
+		Location:      time.UTC,
+		WeekendPrefix: DefaultWeekendPrefix,
+	}
+}
+
+type tenantKey struct{}
+
+// WithTenant сохраняет рабочее пространство запроса в контексте.
+func WithTenant(ctx context.Context, tenant Tenant) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenant)
+}
+
+// TenantFromContext возвращает рабочее пространство запроса, по умолчанию DefaultTenant.
+func TenantFromContext(ctx context.Context) Tenant {
+	if tenant, ok := ctx.Value(tenantKey{}).(Tenant); ok {
+		return tenant
+	}
+
+	return DefaultTenant()
+}
+
+// TenantIDFromContext возвращает id рабочего пространства запроса, пусто - пространство по умолчанию.
+func TenantIDFromContext(ctx context.Context) string {
+	return TenantFromContext(ctx).ID
+}
@@ -0,0 +1,109 @@
+package entity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Ошибки пользователей и аутентификации
+var (
+	ErrUserExists         = errors.New("user already exists")
+	ErrUserNotFound       = errors.New("user does not exist")
+	ErrInvalidEmail       = errors.New("invalid email")
+	ErrInvalidPassword    = errors.New("invalid password")
+	ErrInvalidCredentials = errors.New("invalid email or password")
+	ErrUnauthorized       = errors.New("unauthorized")
+)
+
+// User - пользователь API, владелец задач и проектов.
+type User struct {
+	ID    string `json:"id"`
+	Email string `json:"email"`
+	// PasswordHash - bcrypt-хеш пароля, наружу не отдаётся
+	PasswordHash string `json:"-"`
+	// Tenants - рабочие пространства, в которые пользователь зарегистрировался или был приглашён
+	Tenants []string `json:"-"`
+}
+
+// IsMember сообщает, что пользователь может работать в пространстве tenantID.
+// В пространстве по умолчанию работают все пользователи.
+func (u User) IsMember(tenantID string) bool {
+	return tenantID == "" || slices.Contains(u.Tenants, tenantID)
+}
+
+// userDocument описывает представление пользователя в BSON
+type userDocument struct {
+	ID           primitive.ObjectID `bson:"_id"`
+	Email        string             `bson:"email"`
+	PasswordHash string             `bson:"passwordHash"`
+	Tenants      []string           `bson:"tenants,omitempty"`
+}
+
+// UnmarshalBSON разбирает BSON User
+func (u *User) UnmarshalBSON(data []byte) error {
+	var rawUser userDocument
+
+	if err := bson.Unmarshal(data, &rawUser); err != nil {
+		return fmt.Errorf("failed to unmarshal User: %w", err)
+	}
+
+	u.ID = rawUser.ID.Hex()
+
+	u.Email = rawUser.Email
+
+	u.PasswordHash = rawUser.PasswordHash
+
+	u.Tenants = rawUser.Tenants
+
+	return nil
+}
+
+// MarshalBSON преобразует User в BSON
+func (u User) MarshalBSON() ([]byte, error) {
+	id, err := primitive.ObjectIDFromHex(u.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert ObjectId: %w", err)
+	}
+
+	return bson.Marshal(userDocument{
+		ID:           id,
+		Email:        u.Email,
+		PasswordHash: u.PasswordHash,
+		Tenants:      u.Tenants,
+	})
+}
+
+type userIDKey struct{}
+
+// WithUserID сохраняет id аутентифицированного пользователя в контексте запроса.
+func WithUserID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, userIDKey{}, id)
+}
+
+// UserIDFromContext возвращает id аутентифицированного пользователя, пусто - запрос без пользователя.
+func UserIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(userIDKey{}).(string)
+
+	return id
+}
+
+// Token - выданный пользователю токен доступа.
+type Token struct {
+	AccessToken string `json:"accessToken"`
+	// TokenType - схема заголовка Authorization, всегда Bearer
+	TokenType string    `json:"tokenType" example:"Bearer"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// Identity - пользователь, которому выдан токен, и рабочее пространство, для которого он выдан.
+type Identity struct {
+	UserID string
+	// TenantID - пространство из токена, пусто - токен не привязан к пространству
+	TenantID string
+}
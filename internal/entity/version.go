@@ -0,0 +1,60 @@
+package entity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrVersionMismatch - задача изменилась после того, как клиент получил её версию
+var ErrVersionMismatch = errors.New("task version mismatch")
+
+// ErrInvalidVersion - версия в If-Match не является ETag задачи
+var ErrInvalidVersion = errors.New("invalid version")
+
+// AnyVersion - изменение без проверки версии
+const AnyVersion int64 = 0
+
+// ETag возвращает сильный ETag версии задачи, например "3"
+func ETag(version int64) string {
+	return strconv.Quote(strconv.FormatInt(version, 10))
+}
+
+// ParseIfMatch разбирает заголовок If-Match. Пустой заголовок и * дают AnyVersion.
+// Принимается один ETag, слабый (W/"3") сравнивается как сильный.
+func ParseIfMatch(header string) (int64, error) {
+	header = strings.TrimSpace(header)
+	if header == "" || header == "*" {
+		return AnyVersion, nil
+	}
+
+	raw, err := strconv.Unquote(strings.TrimPrefix(header, "W/"))
+	if err != nil {
+		return 0, fmt.Errorf("%w: If-Match must be a single quoted ETag", ErrInvalidVersion)
+	}
+
+	version, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil || version < 1 {
+		return 0, fmt.Errorf("%w: unknown ETag %s", ErrInvalidVersion, header)
+	}
+
+	return version, nil
+}
+
+type ifMatchKey struct{}
+
+// WithIfMatch сохраняет в контексте версию задачи из If-Match запроса.
+func WithIfMatch(ctx context.Context, version int64) context.Context {
+	return context.WithValue(ctx, ifMatchKey{}, version)
+}
+
+// IfMatchFromContext возвращает ожидаемую версию задачи запроса, по умолчанию AnyVersion.
+func IfMatchFromContext(ctx context.Context) int64 {
+	if version, ok := ctx.Value(ifMatchKey{}).(int64); ok {
+		return version
+	}
+
+	return AnyVersion
+}
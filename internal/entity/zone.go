@@ -0,0 +1,52 @@
+package entity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrInvalidTimeZone - неизвестный часовой пояс
+var ErrInvalidTimeZone = errors.New("invalid time zone")
+
+// LoadLocation находит часовой пояс по имени из базы IANA, например Asia/Almaty.
+// Пустое имя означает UTC.
+func LoadLocation(name string) (*time.Location, error) {
+	if name == "" {
+		return time.UTC, nil
+	}
+
+	// Local зависит от настроек сервера, поэтому клиентам он недоступен
+	if name == "Local" {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidTimeZone, name)
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidTimeZone, name)
+	}
+
+	return loc, nil
+}
+
+type locationKey struct{}
+
+// WithLocation сохраняет часовой пояс пользователя в контексте запроса.
+func WithLocation(ctx context.Context, loc *time.Location) context.Context {
+	return context.WithValue(ctx, locationKey{}, loc)
+}
+
+// LocationFromContext возвращает часовой пояс пользователя.
+// По умолчанию - часовой пояс рабочего пространства, а без него UTC.
+func LocationFromContext(ctx context.Context) *time.Location {
+	if loc, ok := ctx.Value(locationKey{}).(*time.Location); ok && loc != nil {
+		return loc
+	}
+
+	if loc := TenantFromContext(ctx).Location; loc != nil {
+		return loc
+	}
+
+	return time.UTC
+}
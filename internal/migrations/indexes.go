@@ -0,0 +1,181 @@
+package migrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/skantay/todo-list/internal/entity"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Индексы закреплены за версиями миграций: применённая версия всегда означает одни и те же индексы.
+// Чтобы изменить индексы, добавляется новая миграция со своим набором, существующие наборы не меняются.
+
+// Веса полей в текстовом индексе: совпадение в заголовке важнее, чем в описании
+const (
+	titleTextWeight       = 10
+	descriptionTextWeight = 1
+)
+
+// legacyTextIndex - текстовый индекс только по title, созданный до миграций.
+// MongoDB допускает один текстовый индекс на коллекцию, поэтому его нужно удалить.
+const legacyTextIndex = "task_text"
+
+// v1TaskIndexes - индексы коллекции задач версии 1.
+var v1TaskIndexes = []mongo.IndexModel{
+	{
+		// Текстовый индекс для полнотекстового поиска
+		Keys: bson.D{{Key: "title", Value: "text"}, {Key: "description", Value: "text"}},
+		Options: options.Index().SetName("task_text_v2").SetWeights(bson.D{
+			{Key: "title", Value: titleTextWeight},
+			{Key: "description", Value: descriptionTextWeight},
+		}),
+	},
+	{
+		Keys:    bson.D{{Key: "tags", Value: 1}},
+		Options: options.Index().SetName("task_tags"),
+	},
+	{
+		// Для фильтра просроченных задач: активные со сроком раньше текущего момента
+		Keys:    bson.D{{Key: "status", Value: 1}, {Key: "dueAt", Value: 1}},
+		Options: options.Index().SetName("task_status_due"),
+	},
+	{
+		// Для подсчёта прогресса и выборки подзадач
+		Keys:    bson.D{{Key: "parentId", Value: 1}},
+		Options: options.Index().SetName("task_parent"),
+	},
+	{
+		// Для выборки задач проекта
+		Keys:    bson.D{{Key: "projectId", Value: 1}},
+		Options: options.Index().SetName("task_project"),
+	},
+	{
+		// Для списка задач исполнителя
+		Keys:    bson.D{{Key: "assignees", Value: 1}},
+		Options: options.Index().SetName("task_assignees"),
+	},
+	{
+		// Все запросы ограничены задачами владельца
+		Keys:    bson.D{{Key: "ownerId", Value: 1}, {Key: "activeAt", Value: 1}},
+		Options: options.Index().SetName("task_owner"),
+	},
+	{
+		// И задачами рабочего пространства
+		Keys:    bson.D{{Key: "tenantId", Value: 1}, {Key: "ownerId", Value: 1}, {Key: "activeAt", Value: 1}},
+		Options: options.Index().SetName("task_tenant_owner"),
+	},
+}
+
+// taskUniqueActive - уникальность активных задач версии 6.
+// Активная задача уникальна по title и activeAt в пределах владельца и рабочего пространства, выполненные
+// и отменённые задачи индекс не ограничивает. Индекс, а не проверка перед записью, исключает гонку параллельных запросов
+var taskUniqueActive = mongo.IndexModel{
+	Keys: bson.D{
+		{Key: "tenantId", Value: 1},
+		{Key: "ownerId", Value: 1},
+		{Key: "title", Value: 1},
+		{Key: "activeAt", Value: 1},
+	},
+	Options: options.Index().
+		SetName("task_unique_active").
+		SetUnique(true).
+		SetPartialFilterExpression(bson.M{"status": entity.Active}),
+}
+
+// legacyUniqueIndex - уникальный индекс с полем status из ранних сборок версии 1,
+// он запрещал две одинаковые выполненные задачи
+const legacyUniqueIndex = "task_unique"
+
+// v6TaskIndexes - индексы коллекции задач версии 6.
+var v6TaskIndexes = append(slices.Clone(v1TaskIndexes), taskUniqueActive)
+
+// v1ProjectIndexes - индексы коллекции проектов версии 1.
+var v1ProjectIndexes = []mongo.IndexModel{
+	{
+		// Для проверки уникальности и сортировки по имени в проектах владельца
+		Keys:    bson.D{{Key: "ownerId", Value: 1}, {Key: "name", Value: 1}},
+		Options: options.Index().SetName("project_owner_name"),
+	},
+}
+
+// v1UserIndexes - индексы коллекции пользователей версии 1.
+var v1UserIndexes = []mongo.IndexModel{
+	{
+		// Email уникален, по нему пользователь входит в систему
+		Keys:    bson.D{{Key: "email", Value: 1}},
+		Options: options.Index().SetName("user_email").SetUnique(true),
+	},
+}
+
+// v1APIKeyIndexes - индексы коллекции API-ключей версии 1.
+var v1APIKeyIndexes = []mongo.IndexModel{
+	{
+		// Ключ ищется по хешу при каждом запросе
+		Keys:    bson.D{{Key: "hash", Value: 1}},
+		Options: options.Index().SetName("api_key_hash").SetUnique(true),
+	},
+	{
+		Keys:    bson.D{{Key: "ownerId", Value: 1}, {Key: "createdAt", Value: 1}},
+		Options: options.Index().SetName("api_key_owner"),
+	},
+}
+
+// v1ShareIndexes - индексы коллекции приглашений версии 1.
+var v1ShareIndexes = []mongo.IndexModel{
+	{
+		// Пользователя приглашают к ресурсу не больше одного раза
+		Keys:    bson.D{{Key: "resourceType", Value: 1}, {Key: "resourceId", Value: 1}, {Key: "userId", Value: 1}},
+		Options: options.Index().SetName("share_resource_user").SetUnique(true),
+	},
+	{
+		// Для проверки доступа и списка приглашений пользователя
+		Keys:    bson.D{{Key: "userId", Value: 1}, {Key: "status", Value: 1}},
+		Options: options.Index().SetName("share_user_status"),
+	},
+}
+
+// createIndexes создаёт индексы models в коллекции collection.
+// Уже существующие индексы с тем же описанием MongoDB пропускает.
+func createIndexes(ctx context.Context, collection *mongo.Collection, models []mongo.IndexModel) error {
+	if _, err := collection.Indexes().CreateMany(ctx, models); err != nil {
+		return fmt.Errorf("failed to create indexes in %s: %w", collection.Name(), err)
+	}
+
+	return nil
+}
+
+// dropIndexes удаляет индексы models из коллекции collection. Отсутствующие индексы пропускаются.
+func dropIndexes(ctx context.Context, collection *mongo.Collection, models []mongo.IndexModel) error {
+	for _, model := range models {
+		if err := dropIndex(ctx, collection, *model.Options.Name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// dropIndex удаляет индекс name из коллекции collection, если он есть.
+func dropIndex(ctx context.Context, collection *mongo.Collection, name string) error {
+	if _, err := collection.Indexes().DropOne(ctx, name); err != nil && !isIndexNotFound(err) {
+		return fmt.Errorf("failed to drop index %s in %s: %w", name, collection.Name(), err)
+	}
+
+	return nil
+}
+
+// isIndexNotFound сообщает, что удаляемого индекса (или самой коллекции) нет.
+func isIndexNotFound(err error) bool {
+	var commandErr mongo.CommandError
+	if errors.As(err, &commandErr) {
+		return commandErr.Name == "IndexNotFound" || commandErr.Name == "NamespaceNotFound"
+	}
+
+	return false
+}
@@ -0,0 +1,198 @@
+// Package migrations применяет версионированные изменения схемы MongoDB:
+// создание индексов, заполнение новых полей, переименования.
+// Применённые версии хранятся в коллекции schema_migrations.
+package migrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// ErrNothingToRollback - ни одна миграция не применена
+var ErrNothingToRollback = errors.New("no applied migrations to roll back")
+
+// Migration - один шаг изменения схемы.
+// Up и Down должны быть идемпотентны: несколько экземпляров приложения могут запустить шаг одновременно.
+// TaskIndexes - все индексы коллекции задач после шага, если шаг их меняет: по ним коллекции задач,
+// появившиеся после применения шага, получают индексы (см. EnsureTaskIndexes).
+type Migration struct {
+	Version     int
+	Name        string
+	Up          func(ctx context.Context, db *mongo.Database) error
+	Down        func(ctx context.Context, db *mongo.Database) error
+	TaskIndexes []mongo.IndexModel
+}
+
+// Status - состояние миграции, AppliedAt пуст у ещё не применённой
+type Status struct {
+	Version   int        `json:"version"`
+	Name      string     `json:"name"`
+	AppliedAt *time.Time `json:"appliedAt,omitempty"`
+}
+
+// versionStore хранит применённые версии миграций
+type versionStore interface {
+	Applied(ctx context.Context) (map[int]time.Time, error)
+	Record(ctx context.Context, migration Migration, appliedAt time.Time) error
+	Remove(ctx context.Context, version int) error
+}
+
+// Migrator применяет и откатывает миграции по порядку версий
+type Migrator struct {
+	db         *mongo.Database
+	store      versionStore
+	migrations []Migration
+	log        *slog.Logger
+}
+
+// New создаёт Migrator для базы db с миграциями из List.
+func New(db *mongo.Database, migrations []Migration, log *slog.Logger) *Migrator {
+	return newMigrator(db, newMongoStore(db.Collection(collectionName)), migrations, log)
+}
+
+func newMigrator(db *mongo.Database, store versionStore, migrations []Migration, log *slog.Logger) *Migrator {
+	sorted := append([]Migration(nil), migrations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Version < sorted[j].Version })
+
+	return &Migrator{
+		db:         db,
+		store:      store,
+		migrations: sorted,
+		log:        log,
+	}
+}
+
+// Up применяет все неприменённые миграции по возрастанию версии и возвращает применённые.
+// При ошибке следующие миграции не запускаются, а уже применённые остаются записанными.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	applied, err := m.store.Applied(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load applied migrations: %w", err)
+	}
+
+	var done []Migration
+
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; ok {
+			continue
+		}
+
+		if err := migration.Up(ctx, m.db); err != nil {
+			return done, fmt.Errorf("migration %d %s: %w", migration.Version, migration.Name, err)
+		}
+
+		if err := m.store.Record(ctx, migration, time.Now().UTC()); err != nil {
+			return done, fmt.Errorf("failed to record migration %d: %w", migration.Version, err)
+		}
+
+		m.log.Info("migration applied", "version", migration.Version, "name", migration.Name)
+
+		done = append(done, migration)
+	}
+
+	return done, nil
+}
+
+// Down откатывает последнюю применённую миграцию и возвращает её.
+func (m *Migrator) Down(ctx context.Context) (Migration, error) {
+	applied, err := m.store.Applied(ctx)
+	if err != nil {
+		return Migration{}, fmt.Errorf("failed to load applied migrations: %w", err)
+	}
+
+	for i := len(m.migrations) - 1; i >= 0; i-- {
+		migration := m.migrations[i]
+		if _, ok := applied[migration.Version]; !ok {
+			continue
+		}
+
+		if err := migration.Down(ctx, m.db); err != nil {
+			return Migration{}, fmt.Errorf("rollback of migration %d %s: %w", migration.Version, migration.Name, err)
+		}
+
+		if err := m.store.Remove(ctx, migration.Version); err != nil {
+			return Migration{}, fmt.Errorf("failed to remove migration %d: %w", migration.Version, err)
+		}
+
+		m.log.Info("migration rolled back", "version", migration.Version, "name", migration.Name)
+
+		return migration, nil
+	}
+
+	return Migration{}, ErrNothingToRollback
+}
+
+// Status возвращает состояние всех известных миграций по возрастанию версии.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	applied, err := m.store.Applied(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load applied migrations: %w", err)
+	}
+
+	statuses := make([]Status, 0, len(m.migrations))
+
+	for _, migration := range m.migrations {
+		status := Status{Version: migration.Version, Name: migration.Name}
+		if appliedAt, ok := applied[migration.Version]; ok {
+			status.AppliedAt = &appliedAt
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+// Pending возвращает число неприменённых миграций.
+func (m *Migrator) Pending(ctx context.Context) (int, error) {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	pending := 0
+
+	for _, status := range statuses {
+		if status.AppliedAt == nil {
+			pending++
+		}
+	}
+
+	return pending, nil
+}
+
+// EnsureTaskIndexes создаёт в коллекциях задач collections индексы последней применённой миграции,
+// которая их задаёт. Так коллекции рабочих пространств, добавленных после миграций, получают
+// те же индексы, что и остальные. Для коллекций, где индексы уже есть, вызов ничего не меняет.
+func (m *Migrator) EnsureTaskIndexes(ctx context.Context, collections []string) error {
+	applied, err := m.store.Applied(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load applied migrations: %w", err)
+	}
+
+	var indexes []mongo.IndexModel
+
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; ok && migration.TaskIndexes != nil {
+			indexes = migration.TaskIndexes
+		}
+	}
+
+	if indexes == nil {
+		return nil
+	}
+
+	for _, name := range collections {
+		if err := createIndexes(ctx, m.db.Collection(name), indexes); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
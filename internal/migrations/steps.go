@@ -0,0 +1,381 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/skantay/todo-list/internal/entity"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Collections - коллекции, схему которых меняют миграции.
+// Tasks - все коллекции задач, включая коллекции рабочих пространств.
+type Collections struct {
+	Tasks   []string
+	Project string
+	User    string
+	APIKey  string
+	Share   string
+}
+
+// List возвращает миграции схемы для коллекций collections.
+// legacyOwner - email пользователя, которому передаются задачи и проекты без владельца.
+// Новые шаги добавляются в конец со следующей версией, применённые версии не меняются.
+func List(collections Collections, legacyOwner string) []Migration {
+	return []Migration{
+		{
+			Version:     1,
+			Name:        "create_indexes",
+			TaskIndexes: v1TaskIndexes,
+			Up: func(ctx context.Context, db *mongo.Database) error {
+				err := forEachTaskCollection(db, collections, func(collection *mongo.Collection) error {
+					if err := dropIndex(ctx, collection, legacyTextIndex); err != nil {
+						return err
+					}
+
+					return createIndexes(ctx, collection, v1TaskIndexes)
+				})
+				if err != nil {
+					return err
+				}
+
+				return forEachIndexed(db, collections, func(collection *mongo.Collection, indexes []mongo.IndexModel) error {
+					return createIndexes(ctx, collection, indexes)
+				})
+			},
+			Down: func(ctx context.Context, db *mongo.Database) error {
+				err := forEachTaskCollection(db, collections, func(collection *mongo.Collection) error {
+					return dropIndexes(ctx, collection, v1TaskIndexes)
+				})
+				if err != nil {
+					return err
+				}
+
+				return forEachIndexed(db, collections, func(collection *mongo.Collection, indexes []mongo.IndexModel) error {
+					return dropIndexes(ctx, collection, indexes)
+				})
+			},
+		},
+		{
+			Version: 2,
+			Name:    "backfill_task_priority",
+			// У старых задач нет поля priority, они считаются обычными
+			Up: func(ctx context.Context, db *mongo.Database) error {
+				return forEachTaskCollection(db, collections, func(collection *mongo.Collection) error {
+					_, err := collection.UpdateMany(ctx,
+						bson.M{"priority": bson.M{"$in": bson.A{nil, ""}}},
+						bson.M{"$set": bson.M{"priority": entity.PriorityNormal}},
+					)
+
+					return err
+				})
+			},
+			// Задача без priority читается как обычная, поэтому заполненное поле можно не удалять
+			Down: func(context.Context, *mongo.Database) error {
+				return nil
+			},
+		},
+		{
+			Version: 3,
+			Name:    "backfill_task_version",
+			// Без поля version у задачи нет ETag, который можно передать в If-Match
+			Up: func(ctx context.Context, db *mongo.Database) error {
+				return forEachTaskCollection(db, collections, func(collection *mongo.Collection) error {
+					_, err := collection.UpdateMany(ctx,
+						bson.M{"version": bson.M{"$exists": false}},
+						bson.M{"$set": bson.M{"version": int64(1)}},
+					)
+
+					return err
+				})
+			},
+			// Версии задач уже могли вырасти после миграции, поэтому откат их не трогает
+			Down: func(context.Context, *mongo.Database) error {
+				return nil
+			},
+		},
+		{
+			Version: 4,
+			Name:    "backfill_owner",
+			// Задачи и проекты, созданные до появления пользователей, без владельца недоступны через API
+			Up: func(ctx context.Context, db *mongo.Database) error {
+				return backfillOwner(ctx, db, collections, legacyOwner)
+			},
+			// После миграции не отличить старые задачи от созданных владельцем, поэтому откат их не трогает
+			Down: func(context.Context, *mongo.Database) error {
+				return nil
+			},
+		},
+		{
+			Version: 5,
+			Name:    "dedupe_active_tasks",
+			// Без этого шага индекс task_unique_active не создастся на базе с одинаковыми активными задачами
+			Up: func(ctx context.Context, db *mongo.Database) error {
+				return forEachTaskCollection(db, collections, func(collection *mongo.Collection) error {
+					return dedupeActiveTasks(ctx, collection)
+				})
+			},
+			// Переименованные задачи уже могли измениться, поэтому откат их не трогает
+			Down: func(context.Context, *mongo.Database) error {
+				return nil
+			},
+		},
+		{
+			Version:     6,
+			Name:        "unique_active_tasks",
+			TaskIndexes: v6TaskIndexes,
+			Up: func(ctx context.Context, db *mongo.Database) error {
+				return forEachTaskCollection(db, collections, func(collection *mongo.Collection) error {
+					if err := dropIndex(ctx, collection, legacyUniqueIndex); err != nil {
+						return err
+					}
+
+					return createIndexes(ctx, collection, []mongo.IndexModel{taskUniqueActive})
+				})
+			},
+			Down: func(ctx context.Context, db *mongo.Database) error {
+				return forEachTaskCollection(db, collections, func(collection *mongo.Collection) error {
+					return dropIndexes(ctx, collection, []mongo.IndexModel{taskUniqueActive})
+				})
+			},
+		},
+		{
+			Version: 7,
+			Name:    "backfill_user_tenants",
+			// Вход в пространство проверяет участие, без этого шага пользователи потеряли бы доступ к своим пространствам
+			Up: func(ctx context.Context, db *mongo.Database) error {
+				return backfillUserTenants(ctx, db, collections)
+			},
+			// Участие, добавленное после миграции, не отличить от заполненного ею, поэтому откат его не трогает
+			Down: func(context.Context, *mongo.Database) error {
+				return nil
+			},
+		},
+	}
+}
+
+// backfillUserTenants делает пользователей участниками пространств, в которых у них есть задачи,
+// проекты и API-ключи или куда их пригласили.
+func backfillUserTenants(ctx context.Context, db *mongo.Database, collections Collections) error {
+	// source - коллекция и поле с id пользователя в её документах
+	type source struct {
+		collection string
+		userField  string
+	}
+
+	sources := []source{
+		{collections.Project, "ownerId"},
+		{collections.APIKey, "ownerId"},
+		{collections.Share, "ownerId"},
+		{collections.Share, "userId"},
+	}
+	for _, name := range collections.Tasks {
+		sources = append(sources, source{name, "ownerId"})
+	}
+
+	users := db.Collection(collections.User)
+
+	for _, source := range sources {
+		cursor, err := db.Collection(source.collection).Aggregate(ctx, mongo.Pipeline{
+			{{Key: "$match", Value: bson.M{"tenantId": bson.M{"$nin": bson.A{nil, ""}}}}},
+			{{Key: "$group", Value: bson.M{"_id": bson.M{"userId": "$" + source.userField, "tenantId": "$tenantId"}}}},
+		})
+		if err != nil {
+			return fmt.Errorf("collection %s: failed to find user tenants: %w", source.collection, err)
+		}
+
+		var memberships []struct {
+			Key struct {
+				UserID   string `bson:"userId"`
+				TenantID string `bson:"tenantId"`
+			} `bson:"_id"`
+		}
+
+		if err := cursor.All(ctx, &memberships); err != nil {
+			return fmt.Errorf("collection %s: failed to decode user tenants: %w", source.collection, err)
+		}
+
+		for _, membership := range memberships {
+			userID, err := primitive.ObjectIDFromHex(membership.Key.UserID)
+			if err != nil {
+				continue
+			}
+
+			_, err = users.UpdateOne(ctx, bson.M{"_id": userID}, bson.M{"$addToSet": bson.M{"tenants": membership.Key.TenantID}})
+			if err != nil {
+				return fmt.Errorf("failed to add tenant %s to user %s: %w", membership.Key.TenantID, membership.Key.UserID, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// dedupeActiveTasks оставляет в каждой группе одинаковых активных задач (владелец, пространство, title, activeAt)
+// самую раннюю задачу, а к заголовкам остальных добавляет номер: "title (2)", "title (3)".
+// Задачи не удаляются, чтобы не потерять их описание, подзадачи и историю.
+func dedupeActiveTasks(ctx context.Context, collection *mongo.Collection) error {
+	cursor, err := collection.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"status": entity.Active}}},
+		{{Key: "$sort", Value: bson.M{"_id": 1}}},
+		{{Key: "$group", Value: bson.M{
+			"_id": bson.M{"tenantId": "$tenantId", "ownerId": "$ownerId", "title": "$title", "activeAt": "$activeAt"},
+			"ids": bson.M{"$push": "$_id"},
+		}}},
+		{{Key: "$match", Value: bson.M{"ids.1": bson.M{"$exists": true}}}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to find duplicate tasks: %w", err)
+	}
+
+	var groups []struct {
+		Key struct {
+			TenantID any    `bson:"tenantId"`
+			OwnerID  any    `bson:"ownerId"`
+			Title    string `bson:"title"`
+			ActiveAt any    `bson:"activeAt"`
+		} `bson:"_id"`
+		IDs []primitive.ObjectID `bson:"ids"`
+	}
+
+	if err := cursor.All(ctx, &groups); err != nil {
+		return fmt.Errorf("failed to decode duplicate tasks: %w", err)
+	}
+
+	for _, group := range groups {
+		n := 1
+
+		for _, id := range group.IDs[1:] {
+			// Номер пропускается, если активная задача с таким заголовком уже есть
+			for {
+				n++
+
+				taken, err := collection.CountDocuments(ctx, bson.M{
+					"tenantId": group.Key.TenantID,
+					"ownerId":  group.Key.OwnerID,
+					"title":    fmt.Sprintf("%s (%d)", group.Key.Title, n),
+					"activeAt": group.Key.ActiveAt,
+					"status":   entity.Active,
+				})
+				if err != nil {
+					return fmt.Errorf("failed to check task title: %w", err)
+				}
+
+				if taken == 0 {
+					break
+				}
+			}
+
+			_, err := collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{
+				"$set": bson.M{"title": fmt.Sprintf("%s (%d)", group.Key.Title, n)},
+				"$inc": bson.M{"version": 1},
+			})
+			if err != nil {
+				return fmt.Errorf("failed to rename task %s: %w", id.Hex(), err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// orphaned - задачи и проекты без владельца
+var orphaned = bson.M{"ownerId": bson.M{"$in": bson.A{nil, ""}}}
+
+// backfillOwner передаёт задачи и проекты без владельца пользователю с email legacyOwner.
+// Если такого пользователя нет, создаётся учётная запись без пароля: пароль задаётся при регистрации
+// с этим email. Без задач и проектов без владельца шаг ничего не делает.
+func backfillOwner(ctx context.Context, db *mongo.Database, collections Collections, legacyOwner string) error {
+	names := append(slices.Clone(collections.Tasks), collections.Project)
+
+	var count int64
+
+	for _, name := range names {
+		n, err := db.Collection(name).CountDocuments(ctx, orphaned)
+		if err != nil {
+			return fmt.Errorf("collection %s: failed to count documents without owner: %w", name, err)
+		}
+
+		count += n
+	}
+
+	if count == 0 {
+		return nil
+	}
+
+	legacyOwner = strings.ToLower(strings.TrimSpace(legacyOwner))
+	if legacyOwner == "" {
+		return fmt.Errorf("%d tasks and projects have no owner, set auth.legacyOwner to the email of the user who will own them", count)
+	}
+
+	ownerID, err := legacyOwnerID(ctx, db.Collection(collections.User), legacyOwner)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		if _, err := db.Collection(name).UpdateMany(ctx, orphaned, bson.M{"$set": bson.M{"ownerId": ownerID}}); err != nil {
+			return fmt.Errorf("collection %s: failed to set owner: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// legacyOwnerID возвращает id пользователя с email email, при необходимости создавая его без пароля.
+// Upsert по уникальному email не создаст двух пользователей при одновременном запуске.
+func legacyOwnerID(ctx context.Context, users *mongo.Collection, email string) (string, error) {
+	var user struct {
+		ID primitive.ObjectID `bson:"_id"`
+	}
+
+	err := users.FindOneAndUpdate(ctx,
+		bson.M{"email": email},
+		bson.M{"$setOnInsert": bson.M{"_id": primitive.NewObjectID(), "passwordHash": ""}},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(&user)
+	if err != nil {
+		return "", fmt.Errorf("failed to find legacy owner %s: %w", email, err)
+	}
+
+	return user.ID.Hex(), nil
+}
+
+// forEachTaskCollection вызывает fn для каждой коллекции задач, включая коллекции пространств.
+func forEachTaskCollection(db *mongo.Database, collections Collections, fn func(*mongo.Collection) error) error {
+	for _, name := range collections.Tasks {
+		if err := fn(db.Collection(name)); err != nil {
+			return fmt.Errorf("collection %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// forEachIndexed вызывает fn для коллекций проектов, пользователей, API-ключей и приглашений
+// с их индексами версии 1.
+func forEachIndexed(db *mongo.Database, collections Collections, fn func(*mongo.Collection, []mongo.IndexModel) error) error {
+	indexed := []struct {
+		name    string
+		indexes []mongo.IndexModel
+	}{
+		{collections.Project, v1ProjectIndexes},
+		{collections.User, v1UserIndexes},
+		{collections.APIKey, v1APIKeyIndexes},
+		{collections.Share, v1ShareIndexes},
+	}
+
+	for _, collection := range indexed {
+		if err := fn(db.Collection(collection.name), collection.indexes); err != nil {
+			return fmt.Errorf("collection %s: %w", collection.name, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// collectionName - коллекция с применёнными версиями
+const collectionName = "schema_migrations"
+
+// migrationDocument - запись о применённой миграции, _id - версия
+type migrationDocument struct {
+	Version   int       `bson:"_id"`
+	Name      string    `bson:"name"`
+	AppliedAt time.Time `bson:"appliedAt"`
+}
+
+// mongoStore хранит применённые версии в коллекции schema_migrations
+type mongoStore struct {
+	collection *mongo.Collection
+}
+
+func newMongoStore(collection *mongo.Collection) mongoStore {
+	return mongoStore{collection: collection}
+}
+
+// Applied возвращает время применения каждой записанной версии.
+func (s mongoStore) Applied(ctx context.Context) (map[int]time.Time, error) {
+	cursor, err := s.collection.Find(ctx, bson.M{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find migrations: %w", err)
+	}
+
+	var documents []migrationDocument
+	if err := cursor.All(ctx, &documents); err != nil {
+		return nil, fmt.Errorf("failed to decode migrations: %w", err)
+	}
+
+	applied := make(map[int]time.Time, len(documents))
+	for _, document := range documents {
+		applied[document.Version] = document.AppliedAt.UTC()
+	}
+
+	return applied, nil
+}
+
+// Record записывает применённую версию.
+// Если другой экземпляр уже записал её, это не ошибка: шаги идемпотентны.
+func (s mongoStore) Record(ctx context.Context, migration Migration, appliedAt time.Time) error {
+	_, err := s.collection.InsertOne(ctx, migrationDocument{
+		Version:   migration.Version,
+		Name:      migration.Name,
+		AppliedAt: appliedAt,
+	})
+	if err != nil && !mongo.IsDuplicateKeyError(err) {
+		return fmt.Errorf("failed to insert migration: %w", err)
+	}
+
+	return nil
+}
+
+// Remove удаляет запись о версии.
+func (s mongoStore) Remove(ctx context.Context, version int) error {
+	if _, err := s.collection.DeleteOne(ctx, bson.M{"_id": version}); err != nil {
+		return fmt.Errorf("failed to delete migration: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/skantay/todo-list/internal/entity"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type apiKeyRepository struct {
+	collection *mongo.Collection
+	log        *slog.Logger
+}
+
+func newAPIKeyRepository(collection *mongo.Collection, log *slog.Logger) apiKeyRepository {
+	return apiKeyRepository{
+		collection: collection,
+		log:        log,
+	}
+}
+
+// Create сохраняет API-ключ пользователя из контекста в коллекции.
+func (a apiKeyRepository) Create(ctx context.Context, key entity.APIKey) (string, error) {
+	key.ID = primitive.NewObjectID().Hex()
+	key.OwnerID = entity.UserIDFromContext(ctx)
+
+	if _, err := a.collection.InsertOne(ctx, key); err != nil {
+		return "", fmt.Errorf("failed to insert an api key into db: %w", err)
+	}
+
+	return key.ID, nil
+}
+
+// GetByHash возвращает API-ключ по хешу. Ключ ищется среди ключей всех пользователей.
+func (a apiKeyRepository) GetByHash(ctx context.Context, hash string) (entity.APIKey, error) {
+	var key entity.APIKey
+
+	if err := a.collection.FindOne(ctx, bson.M{"hash": hash}).Decode(&key); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return entity.APIKey{}, entity.ErrAPIKeyNotFound
+		}
+		return entity.APIKey{}, fmt.Errorf("failed to find api key: %w", err)
+	}
+
+	return key, nil
+}
+
+// List возвращает API-ключи пользователя из контекста, выпущенные в его рабочем пространстве, в порядке создания.
+func (a apiKeyRepository) List(ctx context.Context) ([]entity.APIKey, error) {
+	cursor, err := a.collection.Find(ctx, scope(ctx, bson.M{}), options.Find().SetSort(bson.D{{Key: "createdAt", Value: 1}, {Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to cursor a collection: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var keys []entity.APIKey
+
+	if err := cursor.All(ctx, &keys); err != nil {
+		return nil, fmt.Errorf("failed to decode api keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+// Revoke отзывает API-ключ в момент revokedAt. Уже отозванный ключ не меняется.
+func (a apiKeyRepository) Revoke(ctx context.Context, id string, revokedAt time.Time) error {
+	idObj, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return entity.ErrInvalidID
+	}
+
+	filter := scope(ctx, bson.M{"_id": idObj})
+
+	result, err := a.collection.UpdateOne(ctx, filter, bson.M{"$min": bson.M{"revokedAt": revokedAt}})
+	if err != nil {
+		return fmt.Errorf("update failed: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return entity.ErrAPIKeyNotFound
+	}
+
+	return nil
+}
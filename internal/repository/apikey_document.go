@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/skantay/todo-list/internal/entity"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// documentAPIKeyRepository реализует APIKeyRepository поверх documentStore.
+type documentAPIKeyRepository struct {
+	mu    sync.Mutex
+	store documentStore
+	log   *slog.Logger
+}
+
+func newDocumentAPIKeyRepository(store documentStore, log *slog.Logger) *documentAPIKeyRepository {
+	return &documentAPIKeyRepository{
+		store: store,
+		log:   log,
+	}
+}
+
+// Create сохраняет API-ключ пользователя из контекста.
+func (d *documentAPIKeyRepository) Create(ctx context.Context, key entity.APIKey) (string, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	key.ID = primitive.NewObjectID().Hex()
+	key.OwnerID = entity.UserIDFromContext(ctx)
+
+	if err := d.save(ctx, key); err != nil {
+		return "", fmt.Errorf("failed to insert an api key: %w", err)
+	}
+
+	return key.ID, nil
+}
+
+// GetByHash возвращает API-ключ по хешу. Ключ ищется среди ключей всех пользователей.
+func (d *documentAPIKeyRepository) GetByHash(ctx context.Context, hash string) (entity.APIKey, error) {
+	keys, err := d.all(ctx)
+	if err != nil {
+		return entity.APIKey{}, err
+	}
+
+	for _, key := range keys {
+		if key.Hash == hash {
+			return key, nil
+		}
+	}
+
+	return entity.APIKey{}, entity.ErrAPIKeyNotFound
+}
+
+// List возвращает API-ключи пользователя из контекста, выпущенные в его рабочем пространстве, в порядке создания.
+func (d *documentAPIKeyRepository) List(ctx context.Context) ([]entity.APIKey, error) {
+	all, err := d.all(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []entity.APIKey
+
+	for _, key := range all {
+		if isOwner(ctx, key.OwnerID) && isTenant(ctx, key.TenantID) {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.SliceStable(keys, func(i, j int) bool {
+		return keys[i].CreatedAt.Before(keys[j].CreatedAt)
+	})
+
+	return keys, nil
+}
+
+// Revoke отзывает API-ключ в момент revokedAt. Уже отозванный ключ не меняется.
+func (d *documentAPIKeyRepository) Revoke(ctx context.Context, id string, revokedAt time.Time) error {
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return entity.ErrInvalidID
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	document, ok, err := d.store.get(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get api key: %w", err)
+	}
+	if !ok {
+		return entity.ErrAPIKeyNotFound
+	}
+
+	var key entity.APIKey
+	if err := bson.Unmarshal(document, &key); err != nil {
+		return fmt.Errorf("failed to decode api key: %w", err)
+	}
+
+	if !isOwner(ctx, key.OwnerID) || !isTenant(ctx, key.TenantID) {
+		return entity.ErrAPIKeyNotFound
+	}
+
+	if key.RevokedAt != nil {
+		return nil
+	}
+
+	key.RevokedAt = &revokedAt
+
+	if err := d.save(ctx, key); err != nil {
+		return fmt.Errorf("update failed: %w", err)
+	}
+
+	return nil
+}
+
+// all возвращает API-ключи всех пользователей.
+func (d *documentAPIKeyRepository) all(ctx context.Context) ([]entity.APIKey, error) {
+	documents, err := d.store.list(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+
+	keys := make([]entity.APIKey, 0, len(documents))
+
+	for _, document := range documents {
+		var key entity.APIKey
+		if err := bson.Unmarshal(document, &key); err != nil {
+			return nil, fmt.Errorf("failed to decode api key: %w", err)
+		}
+
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+// save кодирует API-ключ в BSON и сохраняет его.
+func (d *documentAPIKeyRepository) save(ctx context.Context, key entity.APIKey) error {
+	document, err := bson.Marshal(key)
+	if err != nil {
+		return fmt.Errorf("failed to encode api key: %w", err)
+	}
+
+	return d.store.put(ctx, key.ID, document)
+}
@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/skantay/todo-list/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_DocumentAPIKeys(t *testing.T) {
+	for name, repo := range repositories(t) {
+		t.Run(name, func(t *testing.T) {
+			alice := entity.WithUserID(context.Background(), "alice")
+			bob := entity.WithUserID(context.Background(), "bob")
+			keys := repo.APIKeyRepository
+
+			createdAt := time.Date(2024, 4, 1, 9, 0, 0, 0, time.UTC)
+
+			id, err := keys.Create(alice, entity.APIKey{Name: "CI", Prefix: "tdl_abcdefgh", Hash: "hash", Scope: entity.ScopeRead, CreatedAt: createdAt})
+			require.NoError(t, err)
+
+			// Ключ ищется по хешу без пользователя в контексте
+			key, err := keys.GetByHash(context.Background(), "hash")
+			require.NoError(t, err)
+			assert.Equal(t, entity.APIKey{ID: id, OwnerID: "alice", Name: "CI", Prefix: "tdl_abcdefgh", Hash: "hash", Scope: entity.ScopeRead, CreatedAt: createdAt}, key)
+
+			_, err = keys.GetByHash(context.Background(), "other")
+			assert.ErrorIs(t, err, entity.ErrAPIKeyNotFound)
+
+			list, err := keys.List(bob)
+			require.NoError(t, err)
+			assert.Empty(t, list)
+
+			assert.ErrorIs(t, keys.Revoke(bob, id, createdAt), entity.ErrAPIKeyNotFound)
+			assert.ErrorIs(t, keys.Revoke(alice, "invalid", createdAt), entity.ErrInvalidID)
+
+			revokedAt := createdAt.Add(time.Hour)
+			require.NoError(t, keys.Revoke(alice, id, revokedAt))
+
+			// Повторный отзыв не меняет момент отзыва
+			require.NoError(t, keys.Revoke(alice, id, revokedAt.Add(time.Hour)))
+
+			list, err = keys.List(alice)
+			require.NoError(t, err)
+			require.Len(t, list, 1)
+			require.NotNil(t, list[0].RevokedAt)
+			assert.Equal(t, revokedAt, *list[0].RevokedAt)
+		})
+	}
+}
+
+func Test_DocumentAPIKeyTenants(t *testing.T) {
+	for name, repo := range repositories(t) {
+		t.Run(name, func(t *testing.T) {
+			assertAPIKeyTenants(t, repo)
+		})
+	}
+}
+
+// assertAPIKeyTenants проверяет, что ключ рабочего пространства виден и отзывается только в нём,
+// а по хешу находится без пространства в контексте.
+func assertAPIKeyTenants(t *testing.T, repo Repository) {
+	t.Helper()
+
+	alice := entity.WithUserID(context.Background(), "alice")
+	platform := entity.WithTenant(alice, entity.Tenant{ID: "platform"})
+	ops := entity.WithTenant(alice, entity.Tenant{ID: "ops"})
+	keys := repo.APIKeyRepository
+
+	createdAt := time.Date(2024, 4, 1, 9, 0, 0, 0, time.UTC)
+
+	id, err := keys.Create(platform, entity.APIKey{Name: "CI", Prefix: "tdl_tenant00", Hash: "tenant-hash", TenantID: "platform", CreatedAt: createdAt})
+	require.NoError(t, err)
+
+	key, err := keys.GetByHash(context.Background(), "tenant-hash")
+	require.NoError(t, err)
+	assert.Equal(t, "platform", key.TenantID)
+
+	for ctx, want := range map[context.Context]int{platform: 1, ops: 0, alice: 0} {
+		list, err := keys.List(ctx)
+		require.NoError(t, err)
+		assert.Len(t, list, want)
+	}
+
+	assert.ErrorIs(t, keys.Revoke(ops, id, createdAt), entity.ErrAPIKeyNotFound)
+	assert.ErrorIs(t, keys.Revoke(alice, id, createdAt), entity.ErrAPIKeyNotFound)
+	require.NoError(t, keys.Revoke(platform, id, createdAt))
+}
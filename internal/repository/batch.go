@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/skantay/todo-list/internal/entity"
+)
+
+// errBatchFailed откатывает атомарный пакет, если не выполнилась хотя бы одна запись.
+var errBatchFailed = errors.New("batch write failed")
+
+// writeOwner возвращает контекст владельца задачи записи write.
+func writeOwner(ctx context.Context, write entity.TaskWrite) context.Context {
+	if write.OwnerID == "" {
+		return ctx
+	}
+
+	return entity.WithUserID(ctx, write.OwnerID)
+}
+
+// batchFailed сообщает, что хотя бы одна запись пакета не выполнилась.
+func batchFailed(results []entity.BatchResult) bool {
+	for _, result := range results {
+		if result.Err != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// abortBatch помечает записи откатанного пакета: записи без своей ошибки получают entity.ErrBatchAborted,
+// а у созданных задач убирается id, потому что откат их удалил.
+func abortBatch(writes []entity.TaskWrite, results []entity.BatchResult) []entity.BatchResult {
+	for i := range results {
+		if results[i].Err == nil {
+			results[i].Err = entity.ErrBatchAborted
+		}
+
+		if writes[i].Op == entity.BatchCreate {
+			results[i].ID = ""
+		}
+	}
+
+	return results
+}
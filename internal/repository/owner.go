@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/skantay/todo-list/internal/entity"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// ownedBy ограничивает фильтр MongoDB документами пользователя из контекста.
+// Без пользователя в контексте видны только документы без владельца.
+func ownedBy(ctx context.Context, filter bson.M) bson.M {
+	if owner := entity.UserIDFromContext(ctx); owner != "" {
+		filter["ownerId"] = owner
+	} else {
+		filter["ownerId"] = nil
+	}
+
+	return filter
+}
+
+// isOwner сообщает, что документ с владельцем ownerID принадлежит пользователю из контекста.
+func isOwner(ctx context.Context, ownerID string) bool {
+	return ownerID == entity.UserIDFromContext(ctx)
+}
+
+// assignedToCaller сообщает, что фильтр отбирает задачи, назначенные пользователю из контекста.
+// Такие задачи видны исполнителю независимо от владельца.
+func assignedToCaller(ctx context.Context, filter entity.TaskFilter) bool {
+	return filter.Assignee != "" && filter.Assignee == entity.UserIDFromContext(ctx)
+}
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"slices"
+
+	"github.com/skantay/todo-list/internal/entity"
+)
+
+// ascending сообщает, в каком направлении нужно читать хранилище для страницы.
+// Для страницы "назад" порядок обратный запрошенному, а результат потом разворачивается.
+func ascending(page entity.PageRequest) bool {
+	backward := page.Cursor != nil && page.Cursor.Backward
+
+	return (page.Order != entity.OrderDesc) != backward
+}
+
+// buildPage собирает страницу из задач, прочитанных с лимитом page.Limit+1.
+// Лишняя задача означает, что дальше в направлении чтения есть ещё страница.
+func buildPage(tasks []entity.Task, total int64, page entity.PageRequest) entity.TaskPage {
+	hasMore := len(tasks) > page.Limit
+	if hasMore {
+		tasks = tasks[:page.Limit]
+	}
+
+	backward := page.Cursor != nil && page.Cursor.Backward
+	if backward {
+		slices.Reverse(tasks)
+	}
+
+	result := entity.TaskPage{
+		Tasks: tasks,
+		Total: total,
+	}
+
+	if len(tasks) == 0 {
+		return result
+	}
+
+	first, last := tasks[0], tasks[len(tasks)-1]
+
+	if backward {
+		if hasMore {
+			result.Prev = entity.NewCursor(first, page.SortBy, page.Order, true)
+		}
+		result.Next = entity.NewCursor(last, page.SortBy, page.Order, false)
+	} else {
+		if hasMore {
+			result.Next = entity.NewCursor(last, page.SortBy, page.Order, false)
+		}
+		if page.Cursor != nil {
+			result.Prev = entity.NewCursor(first, page.SortBy, page.Order, true)
+		}
+	}
+
+	return result
+}
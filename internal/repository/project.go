@@ -0,0 +1,184 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/skantay/todo-list/internal/entity"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type projectRepository struct {
+	collection *mongo.Collection
+	log        *slog.Logger
+}
+
+func newProjectRepository(collection *mongo.Collection, log *slog.Logger) projectRepository {
+	return projectRepository{
+		collection: collection,
+		log:        log,
+	}
+}
+
+// Create создаёт проект в коллекции.
+func (p projectRepository) Create(ctx context.Context, project entity.Project) (string, error) {
+	exists, err := p.findProject(ctx, project)
+	if err != nil {
+		return "", fmt.Errorf("failed to check project uniqueness: %w", err)
+	}
+	if exists {
+		return "", entity.ErrProjectExists
+	}
+
+	project.ID = primitive.NewObjectID().Hex()
+	// Владелец проекта - пользователь, от имени которого он создаётся
+	project.OwnerID = entity.UserIDFromContext(ctx)
+	project.TenantID = entity.TenantIDFromContext(ctx)
+
+	if _, err := p.collection.InsertOne(ctx, project); err != nil {
+		return "", fmt.Errorf("failed to insert a project into db: %w", err)
+	}
+
+	return project.ID, nil
+}
+
+// Get возвращает проект из коллекции по его id.
+func (p projectRepository) Get(ctx context.Context, id string) (entity.Project, error) {
+	idObj, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return entity.Project{}, entity.ErrInvalidID
+	}
+
+	var project entity.Project
+
+	if err := p.collection.FindOne(ctx, scope(ctx, bson.M{"_id": idObj})).Decode(&project); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return entity.Project{}, entity.ErrProjectNotFound
+		}
+		return entity.Project{}, fmt.Errorf("failed to find project: %w", err)
+	}
+
+	return project, nil
+}
+
+// List возвращает проекты из коллекции в порядке имени, архивные - только если includeArchived.
+func (p projectRepository) List(ctx context.Context, includeArchived bool) ([]entity.Project, error) {
+	filter := scope(ctx, bson.M{})
+	if !includeArchived {
+		filter["archivedAt"] = bson.M{"$exists": false}
+	}
+
+	cursor, err := p.collection.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "name", Value: 1}, {Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to cursor a collection: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var projects []entity.Project
+
+	if err := cursor.All(ctx, &projects); err != nil {
+		return nil, fmt.Errorf("failed to decode projects: %w", err)
+	}
+
+	return projects, nil
+}
+
+// Update обновляет name и description проекта в коллекции.
+func (p projectRepository) Update(ctx context.Context, project entity.Project) error {
+	idObj, err := primitive.ObjectIDFromHex(project.ID)
+	if err != nil {
+		return entity.ErrInvalidID
+	}
+
+	exists, err := p.findProject(ctx, project)
+	if err != nil {
+		return fmt.Errorf("failed to check project uniqueness: %w", err)
+	}
+	if exists {
+		return entity.ErrProjectExists
+	}
+
+	update := bson.M{"$set": bson.M{
+		"name":        project.Name,
+		"description": project.Description,
+	}}
+
+	result, err := p.collection.UpdateOne(ctx, scope(ctx, bson.M{"_id": idObj}), update)
+	if err != nil {
+		return fmt.Errorf("update failed: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return entity.ErrProjectNotFound
+	}
+
+	return nil
+}
+
+// SetArchived переносит проект в архив в момент archivedAt или, если archivedAt nil, возвращает из архива.
+func (p projectRepository) SetArchived(ctx context.Context, id string, archivedAt *time.Time) error {
+	idObj, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return entity.ErrInvalidID
+	}
+
+	update := bson.M{"$unset": bson.M{"archivedAt": ""}}
+	if archivedAt != nil {
+		update = bson.M{"$set": bson.M{"archivedAt": *archivedAt}}
+	}
+
+	result, err := p.collection.UpdateOne(ctx, scope(ctx, bson.M{"_id": idObj}), update)
+	if err != nil {
+		return fmt.Errorf("update failed: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return entity.ErrProjectNotFound
+	}
+
+	return nil
+}
+
+// Delete удаляет проект из коллекции.
+func (p projectRepository) Delete(ctx context.Context, id string) error {
+	idObj, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return entity.ErrInvalidID
+	}
+
+	result, err := p.collection.DeleteOne(ctx, scope(ctx, bson.M{"_id": idObj}))
+	if err != nil {
+		return fmt.Errorf("failed to delete: %w", err)
+	}
+
+	if result.DeletedCount == 0 {
+		return entity.ErrProjectNotFound
+	}
+
+	return nil
+}
+
+// findProject ищет другой проект владельца в рабочем пространстве с тем же именем.
+func (p projectRepository) findProject(ctx context.Context, project entity.Project) (bool, error) {
+	filter := scope(ctx, bson.M{"name": project.Name})
+
+	if idObj, err := primitive.ObjectIDFromHex(project.ID); err == nil {
+		filter["_id"] = bson.M{"$ne": idObj}
+	}
+
+	if err := p.collection.FindOne(ctx, filter).Err(); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to find project: %w", err)
+	}
+
+	return true, nil
+}
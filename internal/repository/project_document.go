@@ -0,0 +1,239 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/skantay/todo-list/internal/entity"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// documentProjectRepository реализует ProjectRepository поверх documentStore.
+type documentProjectRepository struct {
+	// mu сериализует операции записи, чтобы проверка уникальности и вставка были атомарны
+	mu    sync.Mutex
+	store documentStore
+	log   *slog.Logger
+}
+
+func newDocumentProjectRepository(store documentStore, log *slog.Logger) *documentProjectRepository {
+	return &documentProjectRepository{
+		store: store,
+		log:   log,
+	}
+}
+
+// Create создаёт проект в хранилище.
+func (d *documentProjectRepository) Create(ctx context.Context, project entity.Project) (string, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	exists, err := d.findProject(ctx, project)
+	if err != nil {
+		return "", fmt.Errorf("failed to check project uniqueness: %w", err)
+	}
+	if exists {
+		return "", entity.ErrProjectExists
+	}
+
+	project.ID = primitive.NewObjectID().Hex()
+	project.OwnerID = entity.UserIDFromContext(ctx)
+	project.TenantID = entity.TenantIDFromContext(ctx)
+
+	if err := d.save(ctx, project); err != nil {
+		return "", fmt.Errorf("failed to insert a project: %w", err)
+	}
+
+	return project.ID, nil
+}
+
+// Get возвращает проект по его id.
+func (d *documentProjectRepository) Get(ctx context.Context, id string) (entity.Project, error) {
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return entity.Project{}, entity.ErrInvalidID
+	}
+
+	return d.get(ctx, id)
+}
+
+// List возвращает проекты в порядке имени, архивные - только если includeArchived.
+func (d *documentProjectRepository) List(ctx context.Context, includeArchived bool) ([]entity.Project, error) {
+	all, err := d.all(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var projects []entity.Project
+
+	for _, project := range all {
+		if includeArchived || !project.IsArchived() {
+			projects = append(projects, project)
+		}
+	}
+
+	sort.SliceStable(projects, func(i, j int) bool {
+		return projects[i].Name < projects[j].Name
+	})
+
+	return projects, nil
+}
+
+// Update обновляет name и description проекта.
+func (d *documentProjectRepository) Update(ctx context.Context, project entity.Project) error {
+	if _, err := primitive.ObjectIDFromHex(project.ID); err != nil {
+		return entity.ErrInvalidID
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	exists, err := d.findProject(ctx, project)
+	if err != nil {
+		return fmt.Errorf("failed to check project uniqueness: %w", err)
+	}
+	if exists {
+		return entity.ErrProjectExists
+	}
+
+	stored, err := d.get(ctx, project.ID)
+	if err != nil {
+		return err
+	}
+
+	stored.Name = project.Name
+	stored.Description = project.Description
+
+	if err := d.save(ctx, stored); err != nil {
+		return fmt.Errorf("update failed: %w", err)
+	}
+
+	return nil
+}
+
+// SetArchived переносит проект в архив в момент archivedAt или, если archivedAt nil, возвращает из архива.
+func (d *documentProjectRepository) SetArchived(ctx context.Context, id string, archivedAt *time.Time) error {
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return entity.ErrInvalidID
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	stored, err := d.get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	stored.ArchivedAt = archivedAt
+
+	if err := d.save(ctx, stored); err != nil {
+		return fmt.Errorf("update failed: %w", err)
+	}
+
+	return nil
+}
+
+// Delete удаляет проект.
+func (d *documentProjectRepository) Delete(ctx context.Context, id string) error {
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return entity.ErrInvalidID
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if _, err := d.get(ctx, id); err != nil {
+		return err
+	}
+
+	deleted, err := d.store.delete(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete: %w", err)
+	}
+
+	if !deleted {
+		return entity.ErrProjectNotFound
+	}
+
+	return nil
+}
+
+// get достаёт проект по id, возвращает entity.ErrProjectNotFound если его нет или он чужой.
+func (d *documentProjectRepository) get(ctx context.Context, id string) (entity.Project, error) {
+	document, ok, err := d.store.get(ctx, id)
+	if err != nil {
+		return entity.Project{}, fmt.Errorf("failed to get project: %w", err)
+	}
+	if !ok {
+		return entity.Project{}, entity.ErrProjectNotFound
+	}
+
+	var project entity.Project
+	if err := bson.Unmarshal(document, &project); err != nil {
+		return entity.Project{}, fmt.Errorf("failed to decode project: %w", err)
+	}
+
+	// Чужие документы и документы других пространств неотличимы от отсутствующих
+	if !isOwner(ctx, project.OwnerID) || !isTenant(ctx, project.TenantID) {
+		return entity.Project{}, entity.ErrProjectNotFound
+	}
+
+	return project, nil
+}
+
+// all возвращает все проекты пользователя из контекста в его рабочем пространстве.
+func (d *documentProjectRepository) all(ctx context.Context) ([]entity.Project, error) {
+	documents, err := d.store.list(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list projects: %w", err)
+	}
+
+	projects := make([]entity.Project, 0, len(documents))
+
+	for _, document := range documents {
+		var project entity.Project
+		if err := bson.Unmarshal(document, &project); err != nil {
+			return nil, fmt.Errorf("failed to decode project: %w", err)
+		}
+
+		if !isOwner(ctx, project.OwnerID) || !isTenant(ctx, project.TenantID) {
+			continue
+		}
+
+		projects = append(projects, project)
+	}
+
+	return projects, nil
+}
+
+// save кодирует проект в BSON и сохраняет его.
+func (d *documentProjectRepository) save(ctx context.Context, project entity.Project) error {
+	document, err := bson.Marshal(project)
+	if err != nil {
+		return fmt.Errorf("failed to encode project: %w", err)
+	}
+
+	return d.store.put(ctx, project.ID, document)
+}
+
+// findProject ищет другой проект с тем же именем.
+func (d *documentProjectRepository) findProject(ctx context.Context, project entity.Project) (bool, error) {
+	all, err := d.all(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	for _, existing := range all {
+		if existing.Name == project.Name && existing.ID != project.ID {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/skantay/todo-list/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_DocumentProjects(t *testing.T) {
+	for name, repo := range repositories(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			projects := repo.ProjectRepository
+
+			id, err := projects.Create(ctx, entity.Project{Name: "work", Description: "office"})
+			require.NoError(t, err)
+			_, err = projects.Create(ctx, entity.Project{Name: "home"})
+			require.NoError(t, err)
+
+			_, err = projects.Create(ctx, entity.Project{Name: "work"})
+			assert.ErrorIs(t, err, entity.ErrProjectExists)
+
+			project, err := projects.Get(ctx, id)
+			require.NoError(t, err)
+			assert.Equal(t, entity.Project{ID: id, Name: "work", Description: "office"}, project)
+
+			// Обновление без смены имени не конфликтует с самим проектом
+			require.NoError(t, projects.Update(ctx, entity.Project{ID: id, Name: "work"}))
+			assert.ErrorIs(t, projects.Update(ctx, entity.Project{ID: id, Name: "home"}), entity.ErrProjectExists)
+
+			archivedAt := time.Date(2024, 4, 1, 12, 0, 0, 0, time.UTC)
+			require.NoError(t, projects.SetArchived(ctx, id, &archivedAt))
+
+			list, err := projects.List(ctx, false)
+			require.NoError(t, err)
+			assert.Equal(t, []string{"home"}, projectNames(list))
+
+			list, err = projects.List(ctx, true)
+			require.NoError(t, err)
+			assert.Equal(t, []string{"home", "work"}, projectNames(list))
+			assert.Equal(t, &archivedAt, list[1].ArchivedAt)
+
+			require.NoError(t, projects.SetArchived(ctx, id, nil))
+			project, err = projects.Get(ctx, id)
+			require.NoError(t, err)
+			assert.False(t, project.IsArchived())
+
+			require.NoError(t, projects.Delete(ctx, id))
+			_, err = projects.Get(ctx, id)
+			assert.ErrorIs(t, err, entity.ErrProjectNotFound)
+			assert.ErrorIs(t, projects.Delete(ctx, id), entity.ErrProjectNotFound)
+			assert.ErrorIs(t, projects.Delete(ctx, "invalid"), entity.ErrInvalidID)
+		})
+	}
+}
+
+func projectNames(projects []entity.Project) []string {
+	result := make([]string, 0, len(projects))
+	for _, project := range projects {
+		result = append(result, project.Name)
+	}
+
+	return result
+}
+
+func Test_DocumentProjectTenants(t *testing.T) {
+	for name, repo := range repositories(t) {
+		t.Run(name, func(t *testing.T) {
+			assertProjectTenants(t, repo)
+		})
+	}
+}
+
+// assertProjectTenants проверяет, что проект одного рабочего пространства не виден в другом
+// и не удаляется из него вместе с задачами.
+func assertProjectTenants(t *testing.T, repo Repository) {
+	t.Helper()
+
+	alice := entity.WithUserID(context.Background(), "alice")
+	platform := entity.WithTenant(alice, entity.Tenant{ID: "platform"})
+	ops := entity.WithTenant(alice, entity.Tenant{ID: "ops"})
+	projects := repo.ProjectRepository
+
+	id, err := projects.Create(platform, entity.Project{Name: "work"})
+	require.NoError(t, err)
+
+	// То же имя в другом пространстве не считается повтором
+	_, err = projects.Create(ops, entity.Project{Name: "work"})
+	require.NoError(t, err)
+
+	project, err := projects.Get(platform, id)
+	require.NoError(t, err)
+	assert.Equal(t, "platform", project.TenantID)
+
+	_, err = projects.Get(ops, id)
+	assert.ErrorIs(t, err, entity.ErrProjectNotFound)
+	_, err = projects.Get(alice, id)
+	assert.ErrorIs(t, err, entity.ErrProjectNotFound)
+	assert.ErrorIs(t, projects.Update(ops, entity.Project{ID: id, Name: "renamed"}), entity.ErrProjectNotFound)
+	assert.ErrorIs(t, projects.SetArchived(ops, id, nil), entity.ErrProjectNotFound)
+	assert.ErrorIs(t, projects.Delete(ops, id), entity.ErrProjectNotFound)
+
+	for ctx, want := range map[context.Context][]string{platform: {"work"}, ops: {"work"}, alice: {}} {
+		list, err := projects.List(ctx, true)
+		require.NoError(t, err)
+		assert.Equal(t, want, projectNames(list))
+
+		for _, project := range list {
+			assert.Equal(t, ctx == platform, project.ID == id)
+		}
+	}
+
+	require.NoError(t, projects.Delete(platform, id))
+	_, err = projects.Get(platform, id)
+	assert.ErrorIs(t, err, entity.ErrProjectNotFound)
+}
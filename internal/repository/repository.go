@@ -1,23 +1,191 @@
 package repository
 
 import (
+	"context"
+	"database/sql"
+	"fmt"
 	"log/slog"
+	"time"
+
+	"github.com/skantay/todo-list/internal/entity"
 
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
+// TaskRepository определяет контракт хранилища задач.
+// Его реализуют все бэкенды: MongoDB, память процесса и SQLite.
+type TaskRepository interface {
+	Create(ctx context.Context, task entity.Task) (string, error)
+	Get(ctx context.Context, id string) (entity.Task, error)
+	GetMany(ctx context.Context, ids []string) ([]entity.Task, error)
+	List(ctx context.Context, filter entity.TaskFilter, page entity.PageRequest) (entity.TaskPage, error)
+	Search(ctx context.Context, query string, limit int) ([]entity.SearchResult, error)
+	Update(ctx context.Context, task entity.Task) error
+	Patch(ctx context.Context, id string, version int64, patch entity.TaskPatch) error
+	SetStatus(ctx context.Context, id string, version int64, status string, completedAt *time.Time) error
+	SetChecklist(ctx context.Context, id string, checklist []entity.ChecklistItem) error
+	SetBlockers(ctx context.Context, id string, blockedBy []string) error
+	SetAssignees(ctx context.Context, id string, assignment entity.Assignment) error
+	Children(ctx context.Context, parentIDs []string) ([]entity.Task, error)
+	SetProject(ctx context.Context, id, projectID string) error
+	DeleteByProject(ctx context.Context, projectID string) (int64, error)
+	Delete(ctx context.Context, id string, version int64) error
+	BulkWrite(ctx context.Context, writes []entity.TaskWrite, atomic bool) ([]entity.BatchResult, error)
+}
+
+// ProjectRepository определяет контракт хранилища проектов.
+type ProjectRepository interface {
+	Create(ctx context.Context, project entity.Project) (string, error)
+	Get(ctx context.Context, id string) (entity.Project, error)
+	List(ctx context.Context, includeArchived bool) ([]entity.Project, error)
+	Update(ctx context.Context, project entity.Project) error
+	SetArchived(ctx context.Context, id string, archivedAt *time.Time) error
+	Delete(ctx context.Context, id string) error
+}
+
+// UserRepository определяет контракт хранилища пользователей.
+type UserRepository interface {
+	Create(ctx context.Context, user entity.User) (string, error)
+	Get(ctx context.Context, id string) (entity.User, error)
+	GetByEmail(ctx context.Context, email string) (entity.User, error)
+	AddTenant(ctx context.Context, id, tenantID string) error
+	Activate(ctx context.Context, id, passwordHash string) error
+}
+
+// APIKeyRepository определяет контракт хранилища API-ключей.
+type APIKeyRepository interface {
+	Create(ctx context.Context, key entity.APIKey) (string, error)
+	GetByHash(ctx context.Context, hash string) (entity.APIKey, error)
+	List(ctx context.Context) ([]entity.APIKey, error)
+	Revoke(ctx context.Context, id string, revokedAt time.Time) error
+}
+
+// ShareRepository определяет контракт хранилища приглашений к задачам и проектам.
+type ShareRepository interface {
+	Create(ctx context.Context, share entity.Share) (string, error)
+	Get(ctx context.Context, id string) (entity.Share, error)
+	List(ctx context.Context, filter entity.ShareFilter) ([]entity.Share, error)
+	Accept(ctx context.Context, id string, acceptedAt time.Time) error
+	Delete(ctx context.Context, id string) error
+	DeleteByResource(ctx context.Context, resourceType, resourceID string) error
+}
+
+// Проверка на этапе компиляции, что все бэкенды реализуют контракт
+var (
+	_ TaskRepository = taskRepository{}
+	_ TaskRepository = (*documentTaskRepository)(nil)
+
+	_ ProjectRepository = projectRepository{}
+	_ ProjectRepository = (*documentProjectRepository)(nil)
+
+	_ UserRepository = userRepository{}
+	_ UserRepository = (*documentUserRepository)(nil)
+
+	_ APIKeyRepository = apiKeyRepository{}
+	_ APIKeyRepository = (*documentAPIKeyRepository)(nil)
+
+	_ ShareRepository = shareRepository{}
+	_ ShareRepository = (*documentShareRepository)(nil)
+)
+
 type Repository struct {
-	TaskRepository taskRepository
+	TaskRepository    TaskRepository
+	ProjectRepository ProjectRepository
+	UserRepository    UserRepository
+	APIKeyRepository  APIKeyRepository
+	ShareRepository   ShareRepository
 }
 
 type Collections struct {
-	Task string
+	Task    string
+	Project string
+	User    string
+	APIKey  string
+	Share   string
+	// TaskPerTenant включает отдельную коллекцию задач <Task>_<tenant> для каждого рабочего пространства
+	TaskPerTenant bool
+	// Tenants - id рабочих пространств, для их коллекций задач создаются индексы
+	Tenants []string
 }
 
+// TaskCollections возвращает все коллекции задач: общую и, при TaskPerTenant, коллекции пространств.
+func (c Collections) TaskCollections() []string {
+	names := []string{c.Task}
+	if c.TaskPerTenant {
+		for _, tenant := range c.Tenants {
+			names = append(names, tenantCollectionName(c.Task, tenant))
+		}
+	}
+
+	return names
+}
+
+// New создаёт репозиторий поверх MongoDB, это хранилище по умолчанию.
 func New(client *mongo.Client, database string, collection Collections, log *slog.Logger) Repository {
-	taskCollection := client.Database(database).Collection(collection.Task)
+	taskCollection := tenantCollection{
+		database:  client.Database(database),
+		name:      collection.Task,
+		perTenant: collection.TaskPerTenant,
+	}
+	projectCollection := client.Database(database).Collection(collection.Project)
+	userCollection := client.Database(database).Collection(collection.User)
+	apiKeyCollection := client.Database(database).Collection(collection.APIKey)
+	shareCollection := client.Database(database).Collection(collection.Share)
+
+	return Repository{
+		TaskRepository:    newTaskRepository(taskCollection, log),
+		ProjectRepository: newProjectRepository(projectCollection, log),
+		UserRepository:    newUserRepository(userCollection, log),
+		APIKeyRepository:  newAPIKeyRepository(apiKeyCollection, log),
+		ShareRepository:   newShareRepository(shareCollection, log),
+	}
+}
 
+// NewMemory создаёт репозиторий, который хранит задачи в памяти процесса.
+// Данные теряются при перезапуске, подходит для локального запуска и тестов.
+func NewMemory(log *slog.Logger) Repository {
 	return Repository{
-		TaskRepository: newTaskRepository(taskCollection, log),
+		TaskRepository:    newDocumentTaskRepository(newMemoryStore(), log),
+		ProjectRepository: newDocumentProjectRepository(newMemoryStore(), log),
+		UserRepository:    newDocumentUserRepository(newMemoryStore(), log),
+		APIKeyRepository:  newDocumentAPIKeyRepository(newMemoryStore(), log),
+		ShareRepository:   newDocumentShareRepository(newMemoryStore(), log),
 	}
 }
+
+// NewSQLite создаёт репозиторий поверх встроенной базы SQLite.
+// Схема создаётся автоматически, если её ещё нет.
+func NewSQLite(ctx context.Context, db *sql.DB, log *slog.Logger) (Repository, error) {
+	taskStore, err := newSQLiteStore(ctx, db, sqliteTaskTable)
+	if err != nil {
+		return Repository{}, fmt.Errorf("failed to init sqlite task store: %w", err)
+	}
+
+	projectStore, err := newSQLiteStore(ctx, db, sqliteProjectTable)
+	if err != nil {
+		return Repository{}, fmt.Errorf("failed to init sqlite project store: %w", err)
+	}
+
+	userStore, err := newSQLiteStore(ctx, db, sqliteUserTable)
+	if err != nil {
+		return Repository{}, fmt.Errorf("failed to init sqlite user store: %w", err)
+	}
+
+	apiKeyStore, err := newSQLiteStore(ctx, db, sqliteAPIKeyTable)
+	if err != nil {
+		return Repository{}, fmt.Errorf("failed to init sqlite api key store: %w", err)
+	}
+
+	shareStore, err := newSQLiteStore(ctx, db, sqliteShareTable)
+	if err != nil {
+		return Repository{}, fmt.Errorf("failed to init sqlite share store: %w", err)
+	}
+
+	return Repository{
+		TaskRepository:    newDocumentTaskRepository(taskStore, log),
+		ProjectRepository: newDocumentProjectRepository(projectStore, log),
+		UserRepository:    newDocumentUserRepository(userStore, log),
+		APIKeyRepository:  newDocumentAPIKeyRepository(apiKeyStore, log),
+		ShareRepository:   newDocumentShareRepository(shareStore, log),
+	}, nil
+}
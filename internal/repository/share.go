@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/skantay/todo-list/internal/entity"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// shareRepository хранит приглашения. Приглашение видно двум пользователям,
+// поэтому выборки ограничиваются только рабочим пространством, доступ проверяет usecase.
+type shareRepository struct {
+	collection *mongo.Collection
+	log        *slog.Logger
+}
+
+func newShareRepository(collection *mongo.Collection, log *slog.Logger) shareRepository {
+	return shareRepository{
+		collection: collection,
+		log:        log,
+	}
+}
+
+// Create сохраняет приглашение от имени пользователя из контекста.
+func (s shareRepository) Create(ctx context.Context, share entity.Share) (string, error) {
+	share.ID = primitive.NewObjectID().Hex()
+	share.OwnerID = entity.UserIDFromContext(ctx)
+	share.TenantID = entity.TenantIDFromContext(ctx)
+
+	if _, err := s.collection.InsertOne(ctx, share); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return "", entity.ErrShareExists
+		}
+		return "", fmt.Errorf("failed to insert a share into db: %w", err)
+	}
+
+	return share.ID, nil
+}
+
+// Get возвращает приглашение по его id.
+func (s shareRepository) Get(ctx context.Context, id string) (entity.Share, error) {
+	idObj, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return entity.Share{}, entity.ErrInvalidID
+	}
+
+	var share entity.Share
+
+	if err := s.collection.FindOne(ctx, inTenant(ctx, bson.M{"_id": idObj})).Decode(&share); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return entity.Share{}, entity.ErrShareNotFound
+		}
+		return entity.Share{}, fmt.Errorf("failed to find share: %w", err)
+	}
+
+	return share, nil
+}
+
+// List возвращает приглашения под фильтром в порядке создания.
+func (s shareRepository) List(ctx context.Context, filter entity.ShareFilter) ([]entity.Share, error) {
+	query := inTenant(ctx, bson.M{})
+
+	if filter.ResourceType != "" {
+		query["resourceType"] = filter.ResourceType
+	}
+	if filter.ResourceID != "" {
+		query["resourceId"] = filter.ResourceID
+	}
+	if filter.UserID != "" {
+		query["userId"] = filter.UserID
+	}
+	if filter.Status != "" {
+		query["status"] = filter.Status
+	}
+
+	cursor, err := s.collection.Find(ctx, query, options.Find().SetSort(bson.D{{Key: "createdAt", Value: 1}, {Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to cursor a collection: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var shares []entity.Share
+
+	if err := cursor.All(ctx, &shares); err != nil {
+		return nil, fmt.Errorf("failed to decode shares: %w", err)
+	}
+
+	return shares, nil
+}
+
+// Accept принимает приглашение в момент acceptedAt.
+func (s shareRepository) Accept(ctx context.Context, id string, acceptedAt time.Time) error {
+	idObj, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return entity.ErrInvalidID
+	}
+
+	update := bson.M{"$set": bson.M{"status": entity.ShareAccepted, "acceptedAt": acceptedAt}}
+
+	result, err := s.collection.UpdateOne(ctx, inTenant(ctx, bson.M{"_id": idObj}), update)
+	if err != nil {
+		return fmt.Errorf("update failed: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return entity.ErrShareNotFound
+	}
+
+	return nil
+}
+
+// Delete удаляет приглашение.
+func (s shareRepository) Delete(ctx context.Context, id string) error {
+	idObj, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return entity.ErrInvalidID
+	}
+
+	result, err := s.collection.DeleteOne(ctx, inTenant(ctx, bson.M{"_id": idObj}))
+	if err != nil {
+		return fmt.Errorf("failed to delete share: %w", err)
+	}
+
+	if result.DeletedCount == 0 {
+		return entity.ErrShareNotFound
+	}
+
+	return nil
+}
+
+// DeleteByResource удаляет все приглашения к ресурсу.
+func (s shareRepository) DeleteByResource(ctx context.Context, resourceType, resourceID string) error {
+	if _, err := s.collection.DeleteMany(ctx, inTenant(ctx, bson.M{"resourceType": resourceType, "resourceId": resourceID})); err != nil {
+		return fmt.Errorf("failed to delete shares: %w", err)
+	}
+
+	return nil
+}
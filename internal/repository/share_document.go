@@ -0,0 +1,180 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/skantay/todo-list/internal/entity"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// documentShareRepository реализует ShareRepository поверх documentStore.
+type documentShareRepository struct {
+	mu    sync.Mutex
+	store documentStore
+	log   *slog.Logger
+}
+
+func newDocumentShareRepository(store documentStore, log *slog.Logger) *documentShareRepository {
+	return &documentShareRepository{
+		store: store,
+		log:   log,
+	}
+}
+
+// Create сохраняет приглашение от имени пользователя из контекста.
+func (d *documentShareRepository) Create(ctx context.Context, share entity.Share) (string, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	existing, err := d.List(ctx, entity.ShareFilter{
+		ResourceType: share.ResourceType,
+		ResourceID:   share.ResourceID,
+		UserID:       share.UserID,
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(existing) > 0 {
+		return "", entity.ErrShareExists
+	}
+
+	share.ID = primitive.NewObjectID().Hex()
+	share.OwnerID = entity.UserIDFromContext(ctx)
+	share.TenantID = entity.TenantIDFromContext(ctx)
+
+	if err := d.save(ctx, share); err != nil {
+		return "", fmt.Errorf("failed to insert a share: %w", err)
+	}
+
+	return share.ID, nil
+}
+
+// Get возвращает приглашение по его id.
+func (d *documentShareRepository) Get(ctx context.Context, id string) (entity.Share, error) {
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return entity.Share{}, entity.ErrInvalidID
+	}
+
+	document, ok, err := d.store.get(ctx, id)
+	if err != nil {
+		return entity.Share{}, fmt.Errorf("failed to get share: %w", err)
+	}
+	if !ok {
+		return entity.Share{}, entity.ErrShareNotFound
+	}
+
+	var share entity.Share
+	if err := bson.Unmarshal(document, &share); err != nil {
+		return entity.Share{}, fmt.Errorf("failed to decode share: %w", err)
+	}
+
+	// Приглашения других пространств неотличимы от отсутствующих
+	if !isTenant(ctx, share.TenantID) {
+		return entity.Share{}, entity.ErrShareNotFound
+	}
+
+	return share, nil
+}
+
+// List возвращает приглашения рабочего пространства из контекста под фильтром в порядке создания.
+func (d *documentShareRepository) List(ctx context.Context, filter entity.ShareFilter) ([]entity.Share, error) {
+	documents, err := d.store.list(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list shares: %w", err)
+	}
+
+	var shares []entity.Share
+
+	for _, document := range documents {
+		var share entity.Share
+		if err := bson.Unmarshal(document, &share); err != nil {
+			return nil, fmt.Errorf("failed to decode share: %w", err)
+		}
+
+		if isTenant(ctx, share.TenantID) && filter.Match(share) {
+			shares = append(shares, share)
+		}
+	}
+
+	sort.SliceStable(shares, func(i, j int) bool {
+		return shares[i].CreatedAt.Before(shares[j].CreatedAt)
+	})
+
+	return shares, nil
+}
+
+// Accept принимает приглашение в момент acceptedAt.
+func (d *documentShareRepository) Accept(ctx context.Context, id string, acceptedAt time.Time) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	share, err := d.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	share.Status = entity.ShareAccepted
+	share.AcceptedAt = &acceptedAt
+
+	if err := d.save(ctx, share); err != nil {
+		return fmt.Errorf("update failed: %w", err)
+	}
+
+	return nil
+}
+
+// Delete удаляет приглашение.
+func (d *documentShareRepository) Delete(ctx context.Context, id string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if _, err := d.Get(ctx, id); err != nil {
+		return err
+	}
+
+	ok, err := d.store.delete(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete share: %w", err)
+	}
+	if !ok {
+		return entity.ErrShareNotFound
+	}
+
+	return nil
+}
+
+// DeleteByResource удаляет все приглашения к ресурсу.
+func (d *documentShareRepository) DeleteByResource(ctx context.Context, resourceType, resourceID string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	shares, err := d.List(ctx, entity.ShareFilter{ResourceType: resourceType, ResourceID: resourceID})
+	if err != nil {
+		return err
+	}
+
+	for _, share := range shares {
+		if _, err := d.store.delete(ctx, share.ID); err != nil {
+			return fmt.Errorf("failed to delete share: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// save кодирует приглашение в BSON и сохраняет его.
+func (d *documentShareRepository) save(ctx context.Context, share entity.Share) error {
+	document, err := bson.Marshal(share)
+	if err != nil {
+		return fmt.Errorf("failed to encode share: %w", err)
+	}
+
+	return d.store.put(ctx, share.ID, document)
+}
@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/skantay/todo-list/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_DocumentShares(t *testing.T) {
+	for name, repo := range repositories(t) {
+		t.Run(name, func(t *testing.T) {
+			alice := entity.WithUserID(context.Background(), "alice")
+			bob := entity.WithUserID(context.Background(), "bob")
+			shares := repo.ShareRepository
+
+			createdAt := time.Date(2024, 4, 1, 9, 0, 0, 0, time.UTC)
+			share := entity.Share{
+				ResourceType: entity.ResourceTask,
+				ResourceID:   "661fbb485131cd932a981b26",
+				UserID:       "bob",
+				Email:        "bob@example.com",
+				Role:         entity.RoleEditor,
+				Status:       entity.SharePending,
+				CreatedAt:    createdAt,
+			}
+
+			id, err := shares.Create(alice, share)
+			require.NoError(t, err)
+
+			// Повторно пригласить того же пользователя к тому же ресурсу нельзя
+			_, err = shares.Create(alice, share)
+			assert.ErrorIs(t, err, entity.ErrShareExists)
+
+			projectShare := share
+			projectShare.ResourceType = entity.ResourceProject
+			projectShare.CreatedAt = createdAt.Add(time.Minute)
+			projectID, err := shares.Create(alice, projectShare)
+			require.NoError(t, err)
+
+			stored, err := shares.Get(bob, id)
+			require.NoError(t, err)
+			share.ID, share.OwnerID = id, "alice"
+			assert.Equal(t, share, stored)
+
+			pending, err := shares.List(bob, entity.ShareFilter{UserID: "bob", Status: entity.SharePending})
+			require.NoError(t, err)
+			require.Len(t, pending, 2)
+			assert.Equal(t, id, pending[0].ID)
+
+			acceptedAt := createdAt.Add(time.Hour)
+			require.NoError(t, shares.Accept(bob, id, acceptedAt))
+
+			accepted, err := shares.List(bob, entity.ShareFilter{UserID: "bob", Status: entity.ShareAccepted})
+			require.NoError(t, err)
+			require.Len(t, accepted, 1)
+			require.NotNil(t, accepted[0].AcceptedAt)
+			assert.Equal(t, acceptedAt, *accepted[0].AcceptedAt)
+
+			require.NoError(t, shares.Delete(alice, projectID))
+			assert.ErrorIs(t, shares.Delete(alice, projectID), entity.ErrShareNotFound)
+
+			require.NoError(t, shares.DeleteByResource(alice, entity.ResourceTask, share.ResourceID))
+			_, err = shares.Get(bob, id)
+			assert.ErrorIs(t, err, entity.ErrShareNotFound)
+		})
+	}
+}
+
+func Test_DocumentShareTenants(t *testing.T) {
+	for name, repo := range repositories(t) {
+		t.Run(name, func(t *testing.T) {
+			assertShareTenants(t, repo)
+		})
+	}
+}
+
+// assertShareTenants проверяет, что приглашение действует только в рабочем пространстве ресурса.
+func assertShareTenants(t *testing.T, repo Repository) {
+	t.Helper()
+
+	alice := entity.WithTenant(entity.WithUserID(context.Background(), "alice"), entity.Tenant{ID: "platform"})
+	bob := entity.WithUserID(context.Background(), "bob")
+	bobPlatform := entity.WithTenant(bob, entity.Tenant{ID: "platform"})
+	bobOps := entity.WithTenant(bob, entity.Tenant{ID: "ops"})
+	shares := repo.ShareRepository
+
+	id, err := shares.Create(alice, entity.Share{
+		ResourceType: entity.ResourceProject,
+		ResourceID:   "661fbb485131cd932a981b26",
+		UserID:       "bob",
+		Email:        "bob@example.com",
+		Role:         entity.RoleViewer,
+		Status:       entity.SharePending,
+		CreatedAt:    time.Date(2024, 4, 1, 9, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+
+	share, err := shares.Get(bobPlatform, id)
+	require.NoError(t, err)
+	assert.Equal(t, "platform", share.TenantID)
+
+	filter := entity.ShareFilter{UserID: "bob"}
+	for ctx, want := range map[context.Context]int{bobPlatform: 1, bobOps: 0, bob: 0} {
+		list, err := shares.List(ctx, filter)
+		require.NoError(t, err)
+		assert.Len(t, list, want)
+	}
+
+	_, err = shares.Get(bobOps, id)
+	assert.ErrorIs(t, err, entity.ErrShareNotFound)
+	assert.ErrorIs(t, shares.Accept(bobOps, id, time.Now()), entity.ErrShareNotFound)
+	assert.ErrorIs(t, shares.Delete(bobOps, id), entity.ErrShareNotFound)
+
+	// Удаление ресурса в другом пространстве не трогает приглашения к нему
+	require.NoError(t, shares.DeleteByResource(bobOps, entity.ResourceProject, "661fbb485131cd932a981b26"))
+	_, err = shares.Get(bobPlatform, id)
+	require.NoError(t, err)
+
+	require.NoError(t, shares.Delete(bobPlatform, id))
+}
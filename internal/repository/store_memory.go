@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+)
+
+// memoryStore хранит документы в map в памяти процесса.
+type memoryStore struct {
+	mu        sync.RWMutex
+	documents map[string][]byte
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{
+		documents: make(map[string][]byte),
+	}
+}
+
+func (m *memoryStore) get(_ context.Context, id string) ([]byte, bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	document, ok := m.documents[id]
+
+	return document, ok, nil
+}
+
+func (m *memoryStore) put(_ context.Context, id string, document []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.documents[id] = document
+
+	return nil
+}
+
+func (m *memoryStore) delete(_ context.Context, id string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.documents[id]; !ok {
+		return false, nil
+	}
+
+	delete(m.documents, id)
+
+	return true, nil
+}
+
+func (m *memoryStore) list(_ context.Context) ([][]byte, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	// Обходим ключи в отсортированном порядке, чтобы выдача была детерминированной
+	ids := make([]string, 0, len(m.documents))
+	for id := range m.documents {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	documents := make([][]byte, 0, len(ids))
+	for _, id := range ids {
+		documents = append(documents, m.documents[id])
+	}
+
+	return documents, nil
+}
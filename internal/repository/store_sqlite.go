@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// Таблицы SQLite для документов
+const (
+	sqliteTaskTable    = "tasks"
+	sqliteProjectTable = "projects"
+	sqliteUserTable    = "users"
+	sqliteAPIKeyTable  = "api_keys"
+	sqliteShareTable   = "shares"
+)
+
+const (
+	sqliteSchema = `CREATE TABLE IF NOT EXISTS %s (
+	id       TEXT PRIMARY KEY,
+	document BLOB NOT NULL
+)`
+)
+
+// sqliteStore хранит BSON-документы в таблице SQLite.
+type sqliteStore struct {
+	db *sql.DB
+	// table - имя таблицы, одна из констант выше, поэтому безопасно подставляется в запросы
+	table string
+}
+
+func newSQLiteStore(ctx context.Context, db *sql.DB, table string) (sqliteStore, error) {
+	if _, err := db.ExecContext(ctx, fmt.Sprintf(sqliteSchema, table)); err != nil {
+		return sqliteStore{}, fmt.Errorf("failed to create schema: %w", err)
+	}
+
+	return sqliteStore{db: db, table: table}, nil
+}
+
+func (s sqliteStore) get(ctx context.Context, id string) ([]byte, bool, error) {
+	var document []byte
+
+	err := s.db.QueryRowContext(ctx, `SELECT document FROM `+s.table+` WHERE id = ?`, id).Scan(&document)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("failed to select document: %w", err)
+	}
+
+	return document, true, nil
+}
+
+func (s sqliteStore) put(ctx context.Context, id string, document []byte) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO `+s.table+` (id, document) VALUES (?, ?)
+		ON CONFLICT (id) DO UPDATE SET document = excluded.document`,
+		id, document,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to upsert document: %w", err)
+	}
+
+	return nil
+}
+
+func (s sqliteStore) delete(ctx context.Context, id string) (bool, error) {
+	result, err := s.db.ExecContext(ctx, `DELETE FROM `+s.table+` WHERE id = ?`, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete document: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	return affected > 0, nil
+}
+
+func (s sqliteStore) list(ctx context.Context) ([][]byte, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT document FROM `+s.table+` ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select documents: %w", err)
+	}
+	defer rows.Close()
+
+	var documents [][]byte
+
+	for rows.Next() {
+		var document []byte
+		if err := rows.Scan(&document); err != nil {
+			return nil, fmt.Errorf("failed to scan document: %w", err)
+		}
+
+		documents = append(documents, document)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error occured: %w", err)
+	}
+
+	return documents, nil
+}
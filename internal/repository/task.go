@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"regexp"
 	"time"
 
 	"github.com/skantay/todo-list/internal/entity"
@@ -15,12 +16,18 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+// Веса полей при поиске, как в текстовом индексе задач: совпадение в заголовке важнее, чем в описании
+const (
+	titleTextWeight       = 10
+	descriptionTextWeight = 1
+)
+
 type taskRepository struct {
-	collection *mongo.Collection
+	collection tenantCollection
 	log        *slog.Logger
 }
 
-func newTaskRepository(collection *mongo.Collection, log *slog.Logger) taskRepository {
+func newTaskRepository(collection tenantCollection, log *slog.Logger) taskRepository {
 	return taskRepository{
 		collection: collection,
 		log:        log,
@@ -28,19 +35,19 @@ func newTaskRepository(collection *mongo.Collection, log *slog.Logger) taskRepos
 }
 
 // Create создаёт задачу в коллекции.
+// Активная задача с теми же title и activeAt у того же владельца отклоняется уникальным индексом, который создают миграции.
 func (t taskRepository) Create(ctx context.Context, task entity.Task) (string, error) {
-	existingTask, err := t.findTask(ctx, task)
-	if err != nil {
-		return "", fmt.Errorf("failed to check task uniqueness: %w", err)
-	}
-	if existingTask {
-		return "", entity.ErrAlreadyExists
-	}
-
 	task.ID = primitive.NewObjectID().Hex()
+	// Владелец задачи - пользователь, от имени которого она создаётся
+	task.OwnerID = entity.UserIDFromContext(ctx)
+	task.TenantID = entity.TenantIDFromContext(ctx)
+	task.Version = 1
 
-	result, err := t.collection.InsertOne(ctx, task)
+	result, err := t.collection.get(ctx).InsertOne(ctx, task)
 	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return "", entity.ErrAlreadyExists
+		}
 		return "", fmt.Errorf("failed to insert a task into db: %w", err)
 	}
 
@@ -52,26 +59,93 @@ func (t taskRepository) Create(ctx context.Context, task entity.Task) (string, e
 	return oidResult.Hex(), nil
 }
 
-// List возвращает список задач с колекции на основе указанных параметров(status, now time.Time).
-func (t taskRepository) List(ctx context.Context, status string, now time.Time) ([]entity.Task, error) {
-	var filter bson.M
+// Get возвращает задачу из коллекции по её id.
+func (t taskRepository) Get(ctx context.Context, id string) (entity.Task, error) {
+	// Конвертируем строку ID в тип ObjectID
+	idObj, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return entity.Task{}, entity.ErrInvalidID
+	}
+
+	var task entity.Task
 
-	if status == entity.Active {
-		filter = bson.M{
-			"status":   status,
-			"activeAt": bson.M{"$lte": now},
+	if err := t.collection.get(ctx).FindOne(ctx, scope(ctx, bson.M{"_id": idObj})).Decode(&task); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return entity.Task{}, entity.ErrTaskNotFound
 		}
-	} else {
-		filter = bson.M{
-			"status": status,
+		return entity.Task{}, fmt.Errorf("failed to find task: %w", err)
+	}
+
+	return task, nil
+}
+
+// GetMany возвращает из коллекции задачи с указанными id.
+// Задачи, которых нет, и некорректные id пропускаются.
+func (t taskRepository) GetMany(ctx context.Context, ids []string) ([]entity.Task, error) {
+	objectIDs := make(bson.A, 0, len(ids))
+	for _, id := range ids {
+		if idObj, err := primitive.ObjectIDFromHex(id); err == nil {
+			objectIDs = append(objectIDs, idObj)
 		}
 	}
 
-	sort := bson.D{{"activeAt", 1}}
+	if len(objectIDs) == 0 {
+		return nil, nil
+	}
 
-	cursor, err := t.collection.Find(ctx, filter, options.Find().SetSort(sort))
+	cursor, err := t.collection.get(ctx).Find(ctx, scope(ctx, bson.M{"_id": bson.M{"$in": objectIDs}}))
 	if err != nil {
-		return nil, fmt.Errorf("failed to cursor a collection: %w", err)
+		return nil, fmt.Errorf("failed to find tasks: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var tasks []entity.Task
+
+	if err := cursor.All(ctx, &tasks); err != nil {
+		return nil, fmt.Errorf("failed to decode tasks: %w", err)
+	}
+
+	return tasks, nil
+}
+
+// List возвращает страницу задач с колекции на основе указанных параметров(filter, page).
+// Пагинация keyset: вместо skip используется условие "после граничной задачи" по индексируемым полям.
+func (t taskRepository) List(ctx context.Context, filter entity.TaskFilter, page entity.PageRequest) (entity.TaskPage, error) {
+	// Назначенные пользователю задачи видны ему у любого владельца, но только в своём пространстве
+	query := inTenant(ctx, taskFilter(filter))
+	if !assignedToCaller(ctx, filter) {
+		query = ownedBy(ctx, query)
+	}
+
+	total, err := t.collection.get(ctx).CountDocuments(ctx, query)
+	if err != nil {
+		return entity.TaskPage{}, fmt.Errorf("failed to count tasks: %w", err)
+	}
+
+	if page.Cursor != nil {
+		keyset, err := keysetFilter(page)
+		if err != nil {
+			return entity.TaskPage{}, err
+		}
+
+		query = bson.M{"$and": bson.A{query, keyset}}
+	}
+
+	direction := 1
+	if !ascending(page) {
+		direction = -1
+	}
+
+	sort := bson.D{{Key: sortField(page.SortBy), Value: direction}}
+	if page.SortBy != entity.SortByID {
+		sort = append(sort, bson.E{Key: "_id", Value: direction})
+	}
+
+	opts := options.Find().SetSort(sort).SetLimit(int64(page.Limit) + 1)
+
+	cursor, err := t.collection.get(ctx).Find(ctx, query, opts)
+	if err != nil {
+		return entity.TaskPage{}, fmt.Errorf("failed to cursor a collection: %w", err)
 	}
 	defer cursor.Close(ctx)
 
@@ -80,20 +154,211 @@ func (t taskRepository) List(ctx context.Context, status string, now time.Time)
 	for cursor.Next(ctx) {
 		var task entity.Task
 		if err := cursor.Decode(&task); err != nil {
-			return nil, fmt.Errorf("failed to decode task: %w", err)
+			return entity.TaskPage{}, fmt.Errorf("failed to decode task: %w", err)
 		}
 
 		tasks = append(tasks, task)
 	}
 
+	if err := cursor.Err(); err != nil {
+		return entity.TaskPage{}, fmt.Errorf("cursor error occured: %w", err)
+	}
+
+	return buildPage(tasks, total, page), nil
+}
+
+// boundFilter сравнивает activeAt с границей: задачи со временем - с моментом, задачи на весь день - с датой.
+// У документов без поля timed activeAt всегда дата.
+func boundFilter(op string, bound entity.Bound) bson.M {
+	return dateBoundFilter("activeAt", "timed", op, bound)
+}
+
+// dateBoundFilter сравнивает поле даты field с границей, timedField отмечает значения со временем.
+func dateBoundFilter(field, timedField, op string, bound entity.Bound) bson.M {
+	return bson.M{"$or": bson.A{
+		bson.M{timedField: true, field: bson.M{op: bound.Instant}},
+		bson.M{timedField: bson.M{"$ne": true}, field: bson.M{op: bound.Date.Time()}},
+	}}
+}
+
+// overdueFilter отбирает активные задачи, срок которых прошёл, так же как entity.Task.IsOverdue.
+func overdueFilter(filter entity.TaskFilter) bson.M {
+	return bson.M{"$and": bson.A{
+		bson.M{"status": bson.M{"$in": entity.OpenStatuses}},
+		dateBoundFilter("dueAt", "dueTimed", "$lt", filter.Started()),
+	}}
+}
+
+// taskFilter переводит entity.TaskFilter в фильтр MongoDB.
+func taskFilter(filter entity.TaskFilter) bson.M {
+	var conditions bson.A
+
+	if filter.Status != entity.StatusAll {
+		conditions = append(conditions, bson.M{"status": filter.Status})
+	}
+
+	// Активные задачи видны только с наступлением activeAt, если не запрошены будущие
+	if !filter.IncludeFuture {
+		switch filter.Status {
+		case entity.Active:
+			conditions = append(conditions, boundFilter("$lte", filter.Started()))
+		case entity.StatusAll:
+			conditions = append(conditions, bson.M{"$or": bson.A{
+				bson.M{"status": bson.M{"$ne": entity.Active}},
+				boundFilter("$lte", filter.Started()),
+			}})
+		}
+	}
+
+	if !filter.From.Time().IsZero() {
+		conditions = append(conditions, boundFilter("$gte", filter.FromBound()))
+	}
+
+	if !filter.To.Time().IsZero() {
+		conditions = append(conditions, boundFilter("$lte", filter.ToBound()))
+	}
+
+	if filter.Overdue != nil {
+		if *filter.Overdue {
+			conditions = append(conditions, overdueFilter(filter))
+		} else {
+			conditions = append(conditions, bson.M{"$nor": bson.A{overdueFilter(filter)}})
+		}
+	}
+
+	if filter.Title != "" {
+		pattern := regexp.QuoteMeta(filter.Title)
+		if filter.TitleMatch == entity.TitlePrefix {
+			pattern = "^" + pattern
+		}
+
+		conditions = append(conditions, bson.M{"title": primitive.Regex{Pattern: pattern, Options: "i"}})
+	}
+
+	if len(filter.Priorities) > 0 {
+		priorities := bson.A{}
+		for _, priority := range filter.Priorities {
+			priorities = append(priorities, priority)
+
+			// У старых документов нет поля priority, они считаются обычными
+			if priority == entity.PriorityNormal {
+				priorities = append(priorities, nil)
+			}
+		}
+
+		conditions = append(conditions, bson.M{"priority": bson.M{"$in": priorities}})
+	}
+
+	if len(filter.Tags) > 0 {
+		conditions = append(conditions, bson.M{"tags": bson.M{"$all": filter.Tags}})
+	}
+
+	if filter.ParentID != "" {
+		conditions = append(conditions, bson.M{"parentId": filter.ParentID})
+	}
+
+	if filter.ProjectID != "" {
+		conditions = append(conditions, bson.M{"projectId": filter.ProjectID})
+	}
+
+	if filter.Assignee != "" {
+		conditions = append(conditions, bson.M{"assignees": filter.Assignee})
+	}
+
+	if len(conditions) == 0 {
+		return bson.M{}
+	}
+
+	return bson.M{"$and": conditions}
+}
+
+// sortField возвращает поле документа для ключа сортировки.
+func sortField(sortBy string) string {
+	if sortBy == entity.SortByID {
+		return "_id"
+	}
+
+	return sortBy
+}
+
+// keysetFilter строит условие "строго после курсора" в направлении чтения.
+func keysetFilter(page entity.PageRequest) (bson.M, error) {
+	boundary, err := page.Cursor.Boundary()
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := primitive.ObjectIDFromHex(boundary.ID)
+	if err != nil {
+		return nil, entity.ErrInvalidCursor
+	}
+
+	op := "$gt"
+	if !ascending(page) {
+		op = "$lt"
+	}
+
+	var value any
+
+	switch page.SortBy {
+	case entity.SortByID:
+		return bson.M{"_id": bson.M{op: id}}, nil
+	case entity.SortByActiveAt:
+		value = boundary.ActiveAt.Time()
+	default:
+		value = boundary.Title
+	}
+
+	field := sortField(page.SortBy)
+
+	return bson.M{
+		"$or": bson.A{
+			bson.M{field: bson.M{op: value}},
+			bson.M{field: value, "_id": bson.M{op: id}},
+		},
+	}, nil
+}
+
+// Search ищет задачи по текстовому индексу и сортирует их по релевантности (textScore).
+func (t taskRepository) Search(ctx context.Context, query string, limit int) ([]entity.SearchResult, error) {
+	filter := scope(ctx, bson.M{"$text": bson.M{"$search": query}})
+	score := bson.M{"$meta": "textScore"}
+
+	opts := options.Find().
+		SetProjection(bson.M{"score": score}).
+		SetSort(bson.D{{Key: "score", Value: score}, {Key: "activeAt", Value: 1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := t.collection.get(ctx).Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search tasks: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var results []entity.SearchResult
+
+	for cursor.Next(ctx) {
+		var task entity.Task
+		if err := cursor.Decode(&task); err != nil {
+			return nil, fmt.Errorf("failed to decode task: %w", err)
+		}
+
+		results = append(results, entity.SearchResult{
+			Task:  task,
+			Score: cursor.Current.Lookup("score").Double(),
+		})
+	}
+
 	if err := cursor.Err(); err != nil {
 		return nil, fmt.Errorf("cursor error occured: %w", err)
 	}
 
-	return tasks, nil
+	return results, nil
 }
 
-// Update обновляет title и activeAt задачи в колекции на основе указанных параметров(task entity.Task).
+// Update обновляет title, description, activeAt, dueAt, priority, tags, recurrence, parentId и projectId задачи в колекции на основе указанных параметров(task entity.Task).
+// Если task.Version не entity.AnyVersion, задача обновляется только в этой версии, иначе возвращается entity.ErrVersionMismatch.
+// Если у владельца уже есть другая активная задача с такими title и activeAt, возвращает entity.ErrAlreadyExists.
 func (t taskRepository) Update(ctx context.Context, task entity.Task) error {
 	// Конвертируем строку ID в тип ObjectID
 	id, err := primitive.ObjectIDFromHex(task.ID)
@@ -101,104 +366,566 @@ func (t taskRepository) Update(ctx context.Context, task entity.Task) error {
 		return entity.ErrInvalidID
 	}
 
-	// Проверка на наличие задачи с такими же полями
-	// Если присутсвуют тогда возврщает entity.ErrAlreadyExists
-	existingTask, err := t.findTask(ctx, task)
+	filter := scope(ctx, withVersion(bson.M{"_id": id}, task.Version))
+
+	update := taskUpdate(task)
+
+	result, err := t.collection.get(ctx).UpdateOne(ctx, filter, update)
 	if err != nil {
-		return fmt.Errorf("failed to check task uniqueness: %w", err)
+		if mongo.IsDuplicateKeyError(err) {
+			return entity.ErrAlreadyExists
+		}
+		return fmt.Errorf("update failed: %w", err)
 	}
-	if existingTask {
-		return entity.ErrAlreadyExists
+
+	if result.MatchedCount == 0 {
+		return t.notMatched(ctx, id, task.Version)
 	}
 
-	filter := bson.M{"_id": id}
+	return nil
+}
 
-	update := bson.M{
-		"$set": bson.M{
-			"title":    task.Title,
-			"activeAt": task.ActiveAt.Time(),
-		},
+// Patch меняет в колекции только поля задачи из patch: новые значения записываются через $set,
+// удалённые необязательные поля - через $unset. Если version не entity.AnyVersion, задача меняется только в этой версии.
+// Если у владельца уже есть другая активная задача с такими title и activeAt, возвращает entity.ErrAlreadyExists.
+func (t taskRepository) Patch(ctx context.Context, id string, version int64, patch entity.TaskPatch) error {
+	// Конвертируем строку ID в тип ObjectID
+	idObj, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return entity.ErrInvalidID
+	}
+
+	filter := scope(ctx, withVersion(bson.M{"_id": idObj}, version))
+
+	set := bson.M{}
+	unset := bson.M{}
+
+	// setOrUnset записывает значение поля или удаляет поле, если значение пустое
+	setOrUnset := func(field string, value any, empty bool) {
+		if empty {
+			unset[field] = ""
+		} else {
+			set[field] = value
+		}
+	}
+
+	if patch.Title.Set {
+		set["title"] = patch.Title.Value
+	}
+
+	if patch.Description.Set {
+		setOrUnset("description", patch.Description.Value, patch.Description.Value == "")
+	}
+
+	if patch.ActiveAt.Set {
+		set["activeAt"] = patch.ActiveAt.Value.Time()
+		set["timed"] = !patch.ActiveAt.Value.IsDateOnly()
+	}
+
+	if patch.DueAt.Set {
+		if dueAt := patch.DueAt.Value; dueAt != nil {
+			set["dueAt"] = dueAt.Time()
+			set["dueTimed"] = !dueAt.IsDateOnly()
+		} else {
+			unset["dueAt"] = ""
+			unset["dueTimed"] = ""
+		}
+	}
+
+	if patch.Priority.Set {
+		set["priority"] = patch.Priority.Value
+	}
+
+	if patch.Tags.Set {
+		setOrUnset("tags", patch.Tags.Value, len(patch.Tags.Value) == 0)
+	}
+
+	if patch.Recurrence.Set {
+		setOrUnset("recurrence", patch.Recurrence.Value, patch.Recurrence.Value == "")
+	}
+
+	if patch.ParentID.Set {
+		setOrUnset("parentId", patch.ParentID.Value, patch.ParentID.Value == "")
+	}
+
+	if patch.ProjectID.Set {
+		setOrUnset("projectId", patch.ProjectID.Value, patch.ProjectID.Value == "")
+	}
+
+	update := bson.M{"$inc": incVersion}
+	if len(set) > 0 {
+		update["$set"] = set
+	}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+
+	result, err := t.collection.get(ctx).UpdateOne(ctx, filter, update)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return entity.ErrAlreadyExists
+		}
+		return fmt.Errorf("patch failed: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return t.notMatched(ctx, idObj, version)
 	}
 
-	result, err := t.collection.UpdateOne(ctx, filter, update)
+	return nil
+}
+
+// SetStatus меняет статус задачи в колекции и момент её выполнения completedAt.
+// Если version не entity.AnyVersion, статус меняется только в этой версии задачи.
+// Если у владельца уже есть другая активная задача с такими title и activeAt, возвращает entity.ErrAlreadyExists.
+func (t taskRepository) SetStatus(ctx context.Context, id string, version int64, status string, completedAt *time.Time) error {
+	// Конвертируем строку ID в тип ObjectID
+	idObj, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
+		return entity.ErrInvalidID
+	}
+
+	filter := scope(ctx, withVersion(bson.M{"_id": idObj}, version))
+
+	update := statusUpdate(status, completedAt)
+
+	result, err := t.collection.get(ctx).UpdateOne(ctx, filter, update)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return entity.ErrAlreadyExists
+		}
 		return fmt.Errorf("update failed: %w", err)
 	}
 
-	if result.ModifiedCount == 0 {
+	// Задача без изменений тоже найдена, поэтому проверяется MatchedCount, а не ModifiedCount
+	if result.MatchedCount == 0 {
+		return t.notMatched(ctx, idObj, version)
+	}
+
+	return nil
+}
+
+// SetChecklist заменяет чек-лист задачи в колекции.
+func (t taskRepository) SetChecklist(ctx context.Context, id string, checklist []entity.ChecklistItem) error {
+	// Конвертируем строку ID в тип ObjectID
+	idObj, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return entity.ErrInvalidID
+	}
+
+	update := bson.M{"$set": bson.M{"checklist": checklist}, "$inc": incVersion}
+	if len(checklist) == 0 {
+		update = bson.M{"$unset": bson.M{"checklist": ""}, "$inc": incVersion}
+	}
+
+	result, err := t.collection.get(ctx).UpdateOne(ctx, scope(ctx, bson.M{"_id": idObj}), update)
+	if err != nil {
+		return fmt.Errorf("update failed: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
 		return entity.ErrTaskNotFound
 	}
 
 	return nil
 }
 
-// MarkDone маркирует задачу завершенной в колекции на основе указанных параметров(id).
-func (t taskRepository) MarkDone(ctx context.Context, id string) error {
+// SetBlockers заменяет список блокирующих задач в колекции.
+func (t taskRepository) SetBlockers(ctx context.Context, id string, blockedBy []string) error {
 	// Конвертируем строку ID в тип ObjectID
 	idObj, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
 		return entity.ErrInvalidID
 	}
 
-	filter := bson.M{"_id": idObj}
+	update := bson.M{"$set": bson.M{"blockedBy": blockedBy}, "$inc": incVersion}
+	if len(blockedBy) == 0 {
+		update = bson.M{"$unset": bson.M{"blockedBy": ""}, "$inc": incVersion}
+	}
 
-	update := bson.M{
-		"$set": bson.M{
-			"status": entity.Done,
-		},
+	result, err := t.collection.get(ctx).UpdateOne(ctx, scope(ctx, bson.M{"_id": idObj}), update)
+	if err != nil {
+		return fmt.Errorf("update failed: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return entity.ErrTaskNotFound
+	}
+
+	return nil
+}
+
+// SetAssignees заменяет исполнителей задачи и добавляет назначение в историю.
+func (t taskRepository) SetAssignees(ctx context.Context, id string, assignment entity.Assignment) error {
+	// Конвертируем строку ID в тип ObjectID
+	idObj, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return entity.ErrInvalidID
+	}
+
+	update := bson.M{"$push": bson.M{"assignmentHistory": assignment}, "$inc": incVersion}
+	if len(assignment.Assignees) > 0 {
+		update["$set"] = bson.M{"assignees": assignment.Assignees}
+	} else {
+		update["$unset"] = bson.M{"assignees": ""}
 	}
 
-	result, err := t.collection.UpdateOne(ctx, filter, update)
+	result, err := t.collection.get(ctx).UpdateOne(ctx, scope(ctx, bson.M{"_id": idObj}), update)
 	if err != nil {
 		return fmt.Errorf("update failed: %w", err)
 	}
 
-	if result.ModifiedCount == 0 {
+	if result.MatchedCount == 0 {
 		return entity.ErrTaskNotFound
 	}
 
 	return nil
 }
 
+// Children возвращает подзадачи задач parentIDs из колекции.
+func (t taskRepository) Children(ctx context.Context, parentIDs []string) ([]entity.Task, error) {
+	cursor, err := t.collection.get(ctx).Find(ctx, scope(ctx, bson.M{"parentId": bson.M{"$in": parentIDs}}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find subtasks: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var tasks []entity.Task
+
+	if err := cursor.All(ctx, &tasks); err != nil {
+		return nil, fmt.Errorf("failed to decode subtasks: %w", err)
+	}
+
+	return tasks, nil
+}
+
+// SetProject переносит задачу в проект projectID или, если он пустой, убирает её из проекта.
+func (t taskRepository) SetProject(ctx context.Context, id, projectID string) error {
+	// Конвертируем строку ID в тип ObjectID
+	idObj, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return entity.ErrInvalidID
+	}
+
+	update := bson.M{"$set": bson.M{"projectId": projectID}, "$inc": incVersion}
+	if projectID == "" {
+		update = bson.M{"$unset": bson.M{"projectId": ""}, "$inc": incVersion}
+	}
+
+	result, err := t.collection.get(ctx).UpdateOne(ctx, scope(ctx, bson.M{"_id": idObj}), update)
+	if err != nil {
+		return fmt.Errorf("update failed: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return entity.ErrTaskNotFound
+	}
+
+	return nil
+}
+
+// DeleteByProject удаляет все задачи проекта projectID из колекции и возвращает их количество.
+func (t taskRepository) DeleteByProject(ctx context.Context, projectID string) (int64, error) {
+	result, err := t.collection.get(ctx).DeleteMany(ctx, scope(ctx, bson.M{"projectId": projectID}))
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete project tasks: %w", err)
+	}
+
+	return result.DeletedCount, nil
+}
+
 // Delete удаляет задачу в колекции на основе указанных параметров(id).
-func (t taskRepository) Delete(ctx context.Context, id string) error {
+// Если version не entity.AnyVersion, задача удаляется только в этой версии.
+func (t taskRepository) Delete(ctx context.Context, id string, version int64) error {
 	// Конвертируем строку ID в тип ObjectID
 	idObj, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
 		return entity.ErrInvalidID
 	}
 
-	filter := bson.M{"_id": idObj}
+	filter := scope(ctx, withVersion(bson.M{"_id": idObj}, version))
 
-	result, err := t.collection.DeleteOne(ctx, filter)
+	result, err := t.collection.get(ctx).DeleteOne(ctx, filter)
 	if err != nil {
 		return fmt.Errorf("failed to delete: %w", err)
 	}
 
 	if result.DeletedCount == 0 {
-		return entity.ErrTaskNotFound
+		return t.notMatched(ctx, idObj, version)
 	}
 
 	return nil
 }
 
-// findTask ищет задачу в коллекции на основе указанных параметров(title, activeAt).
-func (t taskRepository) findTask(ctx context.Context, task entity.Task) (bool, error) {
-	filter := bson.M{
-		"title":    task.Title,
-		"activeAt": task.ActiveAt.Time(),
-		"status":   task.Status,
+// BulkWrite выполняет записи writes одной пакетной записью BulkWrite и возвращает результат каждой по порядку.
+// Без atomic записи независимы: ошибка одной не мешает остальным.
+// С atomic записи выполняются в транзакции: при ошибке любой записи не применяется ни одна.
+// Транзакции MongoDB доступны только на наборе реплик или через mongos.
+func (t taskRepository) BulkWrite(ctx context.Context, writes []entity.TaskWrite, atomic bool) ([]entity.BatchResult, error) {
+	if !atomic {
+		return t.bulkWrite(ctx, writes, false)
 	}
 
-	t.log.Debug("", "task", filter)
+	session, err := t.collection.database.Client().StartSession()
+	if err != nil {
+		return nil, fmt.Errorf("failed to start session: %w", err)
+	}
+	defer session.EndSession(ctx)
 
-	result := t.collection.FindOne(ctx, filter)
-	if err := result.Err(); err != nil {
-		if errors.Is(err, mongo.ErrNoDocuments) {
-			return false, nil
+	var results []entity.BatchResult
+
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (any, error) {
+		var bulkErr error
+
+		results, bulkErr = t.bulkWrite(sc, writes, true)
+		if bulkErr != nil {
+			return nil, bulkErr
+		}
+
+		// Ошибка откатывает транзакцию
+		if batchFailed(results) {
+			return nil, errBatchFailed
 		}
-		return false, fmt.Errorf("failed to find task: %w", err)
+
+		return nil, nil
+	})
+	if errors.Is(err, errBatchFailed) {
+		return abortBatch(writes, results), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("batch transaction failed: %w", err)
+	}
+
+	return results, nil
+}
+
+// bulkWrite отправляет записи одним запросом. С ordered запись останавливается на первой ошибке.
+func (t taskRepository) bulkWrite(ctx context.Context, writes []entity.TaskWrite, ordered bool) ([]entity.BatchResult, error) {
+	results := make([]entity.BatchResult, len(writes))
+
+	models := make([]mongo.WriteModel, 0, len(writes))
+	// indexes[i] - номер записи, из которой собрана модель models[i]
+	indexes := make([]int, 0, len(writes))
+
+	for i, write := range writes {
+		model, id, err := t.writeModel(ctx, write)
+		results[i] = entity.BatchResult{ID: id, Err: err}
+
+		if err == nil {
+			models = append(models, model)
+			indexes = append(indexes, i)
+		}
+	}
+
+	if len(models) == 0 || (ordered && batchFailed(results)) {
+		return results, nil
+	}
+
+	result, err := t.collection.get(ctx).BulkWrite(ctx, models, options.BulkWrite().SetOrdered(ordered))
+
+	var bulkErr mongo.BulkWriteException
+	if err != nil && !errors.As(err, &bulkErr) {
+		return nil, fmt.Errorf("bulk write failed: %w", err)
+	}
+
+	for _, writeErr := range bulkErr.WriteErrors {
+		i := indexes[writeErr.Index]
+
+		if mongo.IsDuplicateKeyError(writeErr) {
+			results[i].Err = entity.ErrAlreadyExists
+		} else {
+			results[i].Err = fmt.Errorf("write failed: %w", writeErr)
+		}
+
+		// Задача не создана
+		if writes[i].Op == entity.BatchCreate {
+			results[i].ID = ""
+		}
+	}
+
+	if ordered && len(bulkErr.WriteErrors) > 0 {
+		return results, nil
+	}
+
+	// BulkWrite сообщает только общее число найденных задач, поэтому записи,
+	// которые не нашли задачу, ищутся по одной, только если чего-то не хватает
+	var updates, deletes int64
+	for _, i := range indexes {
+		if results[i].Err != nil {
+			continue
+		}
+
+		if writes[i].Op == entity.BatchDelete {
+			deletes++
+		} else if writes[i].Op != entity.BatchCreate {
+			updates++
+		}
+	}
+
+	if result.MatchedCount >= updates && result.DeletedCount >= deletes {
+		return results, nil
+	}
+
+	for _, i := range indexes {
+		if results[i].Err == nil && writes[i].Op != entity.BatchCreate {
+			results[i].Err = t.checkWrite(ctx, writes[i])
+		}
+	}
+
+	return results, nil
+}
+
+// writeModel собирает модель BulkWrite для записи и возвращает id задачи.
+func (t taskRepository) writeModel(ctx context.Context, write entity.TaskWrite) (mongo.WriteModel, string, error) {
+	if write.Op == entity.BatchCreate {
+		task := write.Task
+		task.ID = primitive.NewObjectID().Hex()
+		task.OwnerID = entity.UserIDFromContext(ctx)
+		task.TenantID = entity.TenantIDFromContext(ctx)
+		task.Version = 1
+
+		return mongo.NewInsertOneModel().SetDocument(task), task.ID, nil
+	}
+
+	id, err := primitive.ObjectIDFromHex(write.ID)
+	if err != nil {
+		return nil, write.ID, entity.ErrInvalidID
+	}
+
+	filter := scope(writeOwner(ctx, write), withVersion(bson.M{"_id": id}, write.Version))
+
+	switch write.Op {
+	case entity.BatchUpdate:
+		return mongo.NewUpdateOneModel().SetFilter(filter).SetUpdate(taskUpdate(write.Task)), write.ID, nil
+	case entity.BatchDone:
+		return mongo.NewUpdateOneModel().SetFilter(filter).SetUpdate(statusUpdate(write.Task.Status, write.Task.CompletedAt)), write.ID, nil
+	case entity.BatchDelete:
+		return mongo.NewDeleteOneModel().SetFilter(filter), write.ID, nil
+	default:
+		return nil, write.ID, entity.ErrInvalidBatch
+	}
+}
+
+// checkWrite проверяет запись, которая могла не найти задачу: изменённая задача
+// должна быть в следующей версии, а удалённой задачи не должно остаться.
+func (t taskRepository) checkWrite(ctx context.Context, write entity.TaskWrite) error {
+	id, err := primitive.ObjectIDFromHex(write.ID)
+	if err != nil {
+		return entity.ErrInvalidID
+	}
+
+	var stored struct {
+		Version int64 `bson:"version"`
+	}
+
+	err = t.collection.get(ctx).FindOne(ctx, scope(writeOwner(ctx, write), bson.M{"_id": id})).Decode(&stored)
+	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		return fmt.Errorf("failed to check task: %w", err)
+	}
+
+	exists := err == nil
+
+	switch {
+	case write.Op == entity.BatchDelete && exists:
+		return entity.ErrVersionMismatch
+	case write.Op == entity.BatchDelete:
+		return nil
+	case !exists:
+		return entity.ErrTaskNotFound
+	case write.Version != entity.AnyVersion && stored.Version != write.Version+1:
+		return entity.ErrVersionMismatch
+	default:
+		return nil
+	}
+}
+
+// taskUpdate возвращает изменение документа задачи при полном обновлении её полей.
+func taskUpdate(task entity.Task) bson.M {
+	set := bson.M{
+		"title":       task.Title,
+		"description": task.Description,
+		"activeAt":    task.ActiveAt.Time(),
+		"timed":       !task.ActiveAt.IsDateOnly(),
+		"priority":    task.Priority,
+		"tags":        task.Tags,
+		"recurrence":  task.Recurrence,
+	}
+
+	update := bson.M{"$set": set, "$inc": incVersion}
+
+	unset := bson.M{}
+
+	// Срок без значения удаляется из документа, а не сохраняется как null
+	if task.DueAt != nil {
+		set["dueAt"] = task.DueAt.Time()
+		set["dueTimed"] = !task.DueAt.IsDateOnly()
+	} else {
+		unset["dueAt"] = ""
+		unset["dueTimed"] = ""
+	}
+
+	if task.ParentID != "" {
+		set["parentId"] = task.ParentID
+	} else {
+		unset["parentId"] = ""
+	}
+
+	if task.ProjectID != "" {
+		set["projectId"] = task.ProjectID
+	} else {
+		unset["projectId"] = ""
+	}
+
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+
+	return update
+}
+
+// statusUpdate возвращает изменение документа задачи при смене статуса.
+func statusUpdate(status string, completedAt *time.Time) bson.M {
+	set := bson.M{"status": status}
+
+	update := bson.M{"$set": set, "$inc": incVersion}
+
+	if completedAt != nil {
+		set["completedAt"] = *completedAt
+	} else {
+		update["$unset"] = bson.M{"completedAt": ""}
+	}
+
+	return update
+}
+
+// incVersion увеличивает версию задачи при каждом изменении.
+// У старых документов поля нет, $inc создаёт его со значением 1
+var incVersion = bson.M{"version": int64(1)}
+
+// withVersion ограничивает фильтр задачей в версии version, entity.AnyVersion - любой версией.
+func withVersion(filter bson.M, version int64) bson.M {
+	if version != entity.AnyVersion {
+		filter["version"] = version
+	}
+
+	return filter
+}
+
+// notMatched объясняет, почему изменение с фильтром по версии не нашло задачу:
+// задача есть, но в другой версии, или её нет совсем.
+func (t taskRepository) notMatched(ctx context.Context, id primitive.ObjectID, version int64) error {
+	if version == entity.AnyVersion {
+		return entity.ErrTaskNotFound
+	}
+
+	count, err := t.collection.get(ctx).CountDocuments(ctx, scope(ctx, bson.M{"_id": id}))
+	if err != nil {
+		return fmt.Errorf("failed to check task: %w", err)
+	}
+
+	if count > 0 {
+		return entity.ErrVersionMismatch
 	}
 
-	return true, nil
+	return entity.ErrTaskNotFound
 }
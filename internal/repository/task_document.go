@@ -0,0 +1,773 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/skantay/todo-list/internal/entity"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// documentStore определяет простое хранилище BSON-документов по идентификатору.
+// Поверх него documentTaskRepository реализует ту же логику, что и MongoDB.
+type documentStore interface {
+	get(ctx context.Context, id string) ([]byte, bool, error)
+	put(ctx context.Context, id string, document []byte) error
+	delete(ctx context.Context, id string) (bool, error)
+	list(ctx context.Context) ([][]byte, error)
+}
+
+// documentTaskRepository реализует TaskRepository для бэкендов без собственного языка запросов.
+type documentTaskRepository struct {
+	// mu сериализует операции записи, чтобы проверка уникальности и вставка были атомарны
+	mu    sync.Mutex
+	store documentStore
+	log   *slog.Logger
+}
+
+func newDocumentTaskRepository(store documentStore, log *slog.Logger) *documentTaskRepository {
+	return &documentTaskRepository{
+		store: store,
+		log:   log,
+	}
+}
+
+// Create создаёт задачу в хранилище.
+func (d *documentTaskRepository) Create(ctx context.Context, task entity.Task) (string, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.create(ctx, task)
+}
+
+// create создаёт задачу. Вызывается под d.mu.
+func (d *documentTaskRepository) create(ctx context.Context, task entity.Task) (string, error) {
+	task.ID = primitive.NewObjectID().Hex()
+	task.OwnerID = entity.UserIDFromContext(ctx)
+	task.TenantID = entity.TenantIDFromContext(ctx)
+
+	if err := d.checkUnique(ctx, task); err != nil {
+		return "", err
+	}
+
+	if err := d.save(ctx, task); err != nil {
+		return "", fmt.Errorf("failed to insert a task: %w", err)
+	}
+
+	return task.ID, nil
+}
+
+// Get возвращает задачу по её id.
+func (d *documentTaskRepository) Get(ctx context.Context, id string) (entity.Task, error) {
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return entity.Task{}, entity.ErrInvalidID
+	}
+
+	return d.get(ctx, id)
+}
+
+// GetMany возвращает задачи с указанными id.
+// Задачи, которых нет, и некорректные id пропускаются.
+func (d *documentTaskRepository) GetMany(ctx context.Context, ids []string) ([]entity.Task, error) {
+	var tasks []entity.Task
+
+	for _, id := range ids {
+		if _, err := primitive.ObjectIDFromHex(id); err != nil {
+			continue
+		}
+
+		task, err := d.get(ctx, id)
+		if errors.Is(err, entity.ErrTaskNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		tasks = append(tasks, task)
+	}
+
+	return tasks, nil
+}
+
+// List возвращает страницу задач на основе указанных параметров(filter, page).
+func (d *documentTaskRepository) List(ctx context.Context, filter entity.TaskFilter, page entity.PageRequest) (entity.TaskPage, error) {
+	all, err := d.all(ctx)
+	if assignedToCaller(ctx, filter) {
+		all, err = d.documents(ctx)
+	}
+	if err != nil {
+		return entity.TaskPage{}, err
+	}
+
+	var tasks []entity.Task
+
+	for _, task := range all {
+		if matchTask(task, filter) {
+			tasks = append(tasks, task)
+		}
+	}
+
+	total := int64(len(tasks))
+
+	direction := 1
+	if !ascending(page) {
+		direction = -1
+	}
+
+	sort.Slice(tasks, func(i, j int) bool {
+		return compareTasks(tasks[i], tasks[j], page.SortBy)*direction < 0
+	})
+
+	if page.Cursor != nil {
+		boundary, err := page.Cursor.Boundary()
+		if err != nil {
+			return entity.TaskPage{}, err
+		}
+
+		// Пропускаем задачи до курсора включительно
+		start := sort.Search(len(tasks), func(i int) bool {
+			return compareTasks(tasks[i], boundary, page.SortBy)*direction > 0
+		})
+		tasks = tasks[start:]
+	}
+
+	if len(tasks) > page.Limit+1 {
+		tasks = tasks[:page.Limit+1]
+	}
+
+	return buildPage(tasks, total, page), nil
+}
+
+// matchTask проверяет задачу на соответствие фильтру так же, как taskFilter для MongoDB.
+func matchTask(task entity.Task, filter entity.TaskFilter) bool {
+	if filter.Status != entity.StatusAll && task.Status != filter.Status {
+		return false
+	}
+
+	activeAt := task.ActiveAt.Time()
+
+	if !filter.IncludeFuture && task.Status == entity.Active && activeAt.After(filter.Started().For(task.ActiveAt)) {
+		return false
+	}
+
+	if !filter.From.Time().IsZero() && activeAt.Before(filter.FromBound().For(task.ActiveAt)) {
+		return false
+	}
+
+	if !filter.To.Time().IsZero() && activeAt.After(filter.ToBound().For(task.ActiveAt)) {
+		return false
+	}
+
+	if filter.Overdue != nil && task.IsOverdue(filter.Started()) != *filter.Overdue {
+		return false
+	}
+
+	if len(filter.Priorities) > 0 && !slices.Contains(filter.Priorities, task.Priority) {
+		return false
+	}
+
+	if filter.ParentID != "" && task.ParentID != filter.ParentID {
+		return false
+	}
+
+	if filter.ProjectID != "" && task.ProjectID != filter.ProjectID {
+		return false
+	}
+
+	if filter.Assignee != "" && !task.IsAssigned(filter.Assignee) {
+		return false
+	}
+
+	for _, tag := range filter.Tags {
+		if !slices.Contains(task.Tags, tag) {
+			return false
+		}
+	}
+
+	if filter.Title != "" {
+		title, query := strings.ToLower(task.Title), strings.ToLower(filter.Title)
+
+		if filter.TitleMatch == entity.TitlePrefix {
+			return strings.HasPrefix(title, query)
+		}
+
+		return strings.Contains(title, query)
+	}
+
+	return true
+}
+
+// compareTasks сравнивает задачи по ключу сортировки, при равенстве - по id.
+func compareTasks(a, b entity.Task, sortBy string) int {
+	var result int
+
+	switch sortBy {
+	case entity.SortByActiveAt:
+		result = a.ActiveAt.Time().Compare(b.ActiveAt.Time())
+	case entity.SortByTitle:
+		result = strings.Compare(a.Title, b.Title)
+	}
+
+	if result == 0 {
+		result = strings.Compare(a.ID, b.ID)
+	}
+
+	return result
+}
+
+// Search ищет задачи, в заголовке или описании которых встречаются слова запроса.
+// Релевантность считается по аналогии с textScore MongoDB (с теми же весами полей), но без стемминга и стоп-слов.
+func (d *documentTaskRepository) Search(ctx context.Context, query string, limit int) ([]entity.SearchResult, error) {
+	all, err := d.all(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	terms := entity.SearchTerms(query)
+
+	var results []entity.SearchResult
+
+	for _, task := range all {
+		score := titleTextWeight*textScore(task.Title, terms) + descriptionTextWeight*textScore(task.Description, terms)
+		if score > 0 {
+			results = append(results, entity.SearchResult{
+				Task:  task,
+				Score: score,
+			})
+		}
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+
+		return compareTasks(results[i].Task, results[j].Task, entity.SortByActiveAt) < 0
+	})
+
+	if len(results) > limit {
+		results = results[:limit]
+	}
+
+	return results, nil
+}
+
+// textScore оценивает релевантность текста: каждое найденное слово запроса
+// даёт от 0.5 до 1 в зависимости от доли, которую оно занимает в тексте.
+func textScore(text string, terms []string) float64 {
+	words := entity.Words(text)
+	if len(words) == 0 {
+		return 0
+	}
+
+	var score float64
+
+	for _, term := range terms {
+		freq := 0
+		for _, word := range words {
+			if word == term {
+				freq++
+			}
+		}
+
+		if freq > 0 {
+			score += 0.5 + 0.5*float64(freq)/float64(len(words))
+		}
+	}
+
+	return score
+}
+
+// Update обновляет title, description, activeAt, dueAt, priority, tags, recurrence, parentId и projectId задачи на основе указанных параметров(task entity.Task).
+// Если task.Version не entity.AnyVersion, задача обновляется только в этой версии.
+func (d *documentTaskRepository) Update(ctx context.Context, task entity.Task) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.update(ctx, task)
+}
+
+// update обновляет поля задачи. Вызывается под d.mu.
+func (d *documentTaskRepository) update(ctx context.Context, task entity.Task) error {
+	if _, err := primitive.ObjectIDFromHex(task.ID); err != nil {
+		return entity.ErrInvalidID
+	}
+
+	stored, err := d.getVersion(ctx, task.ID, task.Version)
+	if err != nil {
+		return err
+	}
+
+	stored.Title = task.Title
+	stored.Description = task.Description
+	stored.ActiveAt = task.ActiveAt
+	stored.DueAt = task.DueAt
+	stored.Priority = task.Priority
+	stored.Tags = task.Tags
+	stored.Recurrence = task.Recurrence
+	stored.ParentID = task.ParentID
+	stored.ProjectID = task.ProjectID
+
+	if err := d.checkUnique(ctx, stored); err != nil {
+		return err
+	}
+
+	if err := d.save(ctx, stored); err != nil {
+		return fmt.Errorf("update failed: %w", err)
+	}
+
+	return nil
+}
+
+// Patch меняет только поля задачи из patch. Если version не entity.AnyVersion, задача меняется только в этой версии.
+func (d *documentTaskRepository) Patch(ctx context.Context, id string, version int64, patch entity.TaskPatch) error {
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return entity.ErrInvalidID
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	stored, err := d.getVersion(ctx, id, version)
+	if err != nil {
+		return err
+	}
+
+	stored = patch.Apply(stored)
+
+	if err := d.checkUnique(ctx, stored); err != nil {
+		return err
+	}
+
+	if err := d.save(ctx, stored); err != nil {
+		return fmt.Errorf("patch failed: %w", err)
+	}
+
+	return nil
+}
+
+// SetStatus меняет статус задачи и момент её выполнения completedAt.
+// Если version не entity.AnyVersion, статус меняется только в этой версии задачи.
+func (d *documentTaskRepository) SetStatus(ctx context.Context, id string, version int64, status string, completedAt *time.Time) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.setStatus(ctx, id, version, status, completedAt)
+}
+
+// setStatus меняет статус задачи. Вызывается под d.mu.
+func (d *documentTaskRepository) setStatus(ctx context.Context, id string, version int64, status string, completedAt *time.Time) error {
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return entity.ErrInvalidID
+	}
+
+	stored, err := d.getVersion(ctx, id, version)
+	if err != nil {
+		return err
+	}
+
+	stored.Status = status
+	stored.CompletedAt = completedAt
+
+	if err := d.checkUnique(ctx, stored); err != nil {
+		return err
+	}
+
+	if err := d.save(ctx, stored); err != nil {
+		return fmt.Errorf("update failed: %w", err)
+	}
+
+	return nil
+}
+
+// SetChecklist заменяет чек-лист задачи.
+func (d *documentTaskRepository) SetChecklist(ctx context.Context, id string, checklist []entity.ChecklistItem) error {
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return entity.ErrInvalidID
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	stored, err := d.get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	stored.Checklist = checklist
+
+	if err := d.save(ctx, stored); err != nil {
+		return fmt.Errorf("update failed: %w", err)
+	}
+
+	return nil
+}
+
+// SetAssignees заменяет исполнителей задачи и добавляет назначение в историю.
+func (d *documentTaskRepository) SetAssignees(ctx context.Context, id string, assignment entity.Assignment) error {
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return entity.ErrInvalidID
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	stored, err := d.get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	stored.Assignees = assignment.Assignees
+	stored.AssignmentHistory = append(stored.AssignmentHistory, assignment)
+
+	if err := d.save(ctx, stored); err != nil {
+		return fmt.Errorf("update failed: %w", err)
+	}
+
+	return nil
+}
+
+// SetBlockers заменяет список блокирующих задач.
+func (d *documentTaskRepository) SetBlockers(ctx context.Context, id string, blockedBy []string) error {
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return entity.ErrInvalidID
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	stored, err := d.get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	stored.BlockedBy = blockedBy
+
+	if err := d.save(ctx, stored); err != nil {
+		return fmt.Errorf("update failed: %w", err)
+	}
+
+	return nil
+}
+
+// Children возвращает подзадачи задач parentIDs.
+func (d *documentTaskRepository) Children(ctx context.Context, parentIDs []string) ([]entity.Task, error) {
+	all, err := d.all(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var tasks []entity.Task
+
+	for _, task := range all {
+		if task.ParentID != "" && slices.Contains(parentIDs, task.ParentID) {
+			tasks = append(tasks, task)
+		}
+	}
+
+	return tasks, nil
+}
+
+// SetProject переносит задачу в проект projectID или, если он пустой, убирает её из проекта.
+func (d *documentTaskRepository) SetProject(ctx context.Context, id, projectID string) error {
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return entity.ErrInvalidID
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	stored, err := d.get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	stored.ProjectID = projectID
+
+	if err := d.save(ctx, stored); err != nil {
+		return fmt.Errorf("update failed: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteByProject удаляет все задачи проекта projectID и возвращает их количество.
+func (d *documentTaskRepository) DeleteByProject(ctx context.Context, projectID string) (int64, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	all, err := d.all(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var deleted int64
+
+	for _, task := range all {
+		if task.ProjectID != projectID {
+			continue
+		}
+
+		if _, err := d.store.delete(ctx, task.ID); err != nil {
+			return deleted, fmt.Errorf("failed to delete project tasks: %w", err)
+		}
+
+		deleted++
+	}
+
+	return deleted, nil
+}
+
+// Delete удаляет задачу на основе указанных параметров(id).
+// Если version не entity.AnyVersion, задача удаляется только в этой версии.
+func (d *documentTaskRepository) Delete(ctx context.Context, id string, version int64) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.remove(ctx, id, version)
+}
+
+// remove удаляет задачу. Вызывается под d.mu.
+func (d *documentTaskRepository) remove(ctx context.Context, id string, version int64) error {
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return entity.ErrInvalidID
+	}
+
+	if _, err := d.getVersion(ctx, id, version); err != nil {
+		return err
+	}
+
+	deleted, err := d.store.delete(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete: %w", err)
+	}
+
+	if !deleted {
+		return entity.ErrTaskNotFound
+	}
+
+	return nil
+}
+
+// BulkWrite выполняет записи writes по порядку под одной блокировкой и возвращает результат каждой.
+// С atomic при ошибке любой записи уже выполненные откатываются к копиям документов до пакета.
+func (d *documentTaskRepository) BulkWrite(ctx context.Context, writes []entity.TaskWrite, atomic bool) ([]entity.BatchResult, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	results := make([]entity.BatchResult, len(writes))
+
+	// snapshots - документы до пакета для отката, nil - документа не было
+	snapshots := make(map[string][]byte)
+
+	for i, write := range writes {
+		if atomic && write.Op != entity.BatchCreate {
+			if err := d.snapshot(ctx, snapshots, write.ID); err != nil {
+				return nil, err
+			}
+		}
+
+		id, err := d.write(ctx, write)
+		results[i] = entity.BatchResult{ID: id, Err: err}
+
+		if !atomic {
+			continue
+		}
+
+		if err != nil {
+			if err := d.restore(ctx, snapshots); err != nil {
+				return nil, fmt.Errorf("failed to roll back batch: %w", err)
+			}
+
+			return abortBatch(writes, results), nil
+		}
+
+		if write.Op == entity.BatchCreate {
+			snapshots[id] = nil
+		}
+	}
+
+	return results, nil
+}
+
+// write выполняет одну запись пакета и возвращает id задачи. Вызывается под d.mu.
+func (d *documentTaskRepository) write(ctx context.Context, write entity.TaskWrite) (string, error) {
+	ownerCtx := writeOwner(ctx, write)
+
+	switch write.Op {
+	case entity.BatchCreate:
+		return d.create(ctx, write.Task)
+	case entity.BatchUpdate:
+		task := write.Task
+		task.ID = write.ID
+		task.Version = write.Version
+
+		return write.ID, d.update(ownerCtx, task)
+	case entity.BatchDone:
+		return write.ID, d.setStatus(ownerCtx, write.ID, write.Version, write.Task.Status, write.Task.CompletedAt)
+	case entity.BatchDelete:
+		return write.ID, d.remove(ownerCtx, write.ID, write.Version)
+	default:
+		return write.ID, entity.ErrInvalidBatch
+	}
+}
+
+// snapshot запоминает документ id до первой записи пакета в него.
+func (d *documentTaskRepository) snapshot(ctx context.Context, snapshots map[string][]byte, id string) error {
+	if _, ok := snapshots[id]; ok {
+		return nil
+	}
+
+	document, _, err := d.store.get(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get task: %w", err)
+	}
+
+	snapshots[id] = document
+
+	return nil
+}
+
+// restore возвращает документы к копиям из snapshots, созданные пакетом удаляются.
+func (d *documentTaskRepository) restore(ctx context.Context, snapshots map[string][]byte) error {
+	for id, document := range snapshots {
+		var err error
+		if document == nil {
+			_, err = d.store.delete(ctx, id)
+		} else {
+			err = d.store.put(ctx, id, document)
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to restore task %s: %w", id, err)
+		}
+	}
+
+	return nil
+}
+
+// get достаёт задачу по id, возвращает entity.ErrTaskNotFound если её нет или она чужая.
+func (d *documentTaskRepository) get(ctx context.Context, id string) (entity.Task, error) {
+	document, ok, err := d.store.get(ctx, id)
+	if err != nil {
+		return entity.Task{}, fmt.Errorf("failed to get task: %w", err)
+	}
+	if !ok {
+		return entity.Task{}, entity.ErrTaskNotFound
+	}
+
+	var task entity.Task
+	if err := bson.Unmarshal(document, &task); err != nil {
+		return entity.Task{}, fmt.Errorf("failed to decode task: %w", err)
+	}
+
+	// Чужие документы и документы других пространств неотличимы от отсутствующих
+	if !isOwner(ctx, task.OwnerID) || !isTenant(ctx, task.TenantID) {
+		return entity.Task{}, entity.ErrTaskNotFound
+	}
+
+	return task, nil
+}
+
+// getVersion достаёт задачу по id и проверяет, что она в версии version.
+// entity.AnyVersion подходит к любой версии. Вызывается под d.mu.
+func (d *documentTaskRepository) getVersion(ctx context.Context, id string, version int64) (entity.Task, error) {
+	task, err := d.get(ctx, id)
+	if err != nil {
+		return entity.Task{}, err
+	}
+
+	if version != entity.AnyVersion && task.Version != version {
+		return entity.Task{}, entity.ErrVersionMismatch
+	}
+
+	return task, nil
+}
+
+// all возвращает все задачи пользователя из контекста.
+func (d *documentTaskRepository) all(ctx context.Context) ([]entity.Task, error) {
+	documents, err := d.documents(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := documents[:0]
+
+	for _, task := range documents {
+		if isOwner(ctx, task.OwnerID) {
+			tasks = append(tasks, task)
+		}
+	}
+
+	return tasks, nil
+}
+
+// documents возвращает задачи всех пользователей рабочего пространства из контекста.
+func (d *documentTaskRepository) documents(ctx context.Context) ([]entity.Task, error) {
+	documents, err := d.store.list(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tasks: %w", err)
+	}
+
+	tasks := make([]entity.Task, 0, len(documents))
+
+	for _, document := range documents {
+		var task entity.Task
+		if err := bson.Unmarshal(document, &task); err != nil {
+			return nil, fmt.Errorf("failed to decode task: %w", err)
+		}
+
+		if isTenant(ctx, task.TenantID) {
+			tasks = append(tasks, task)
+		}
+	}
+
+	return tasks, nil
+}
+
+// save кодирует задачу в BSON и сохраняет её. Каждое сохранение увеличивает версию задачи.
+func (d *documentTaskRepository) save(ctx context.Context, task entity.Task) error {
+	task.Version++
+
+	document, err := bson.Marshal(task)
+	if err != nil {
+		return fmt.Errorf("failed to encode task: %w", err)
+	}
+
+	return d.store.put(ctx, task.ID, document)
+}
+
+// checkUnique повторяет уникальный индекс задач MongoDB: у владельца в рабочем пространстве
+// не может быть другой активной задачи с теми же title и activeAt. Выполненные и отменённые задачи
+// не проверяются. Вызывается под d.mu, поэтому гонки нет.
+func (d *documentTaskRepository) checkUnique(ctx context.Context, task entity.Task) error {
+	all, err := d.all(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to check task uniqueness: %w", err)
+	}
+
+	if task.Status != entity.Active {
+		return nil
+	}
+
+	for _, existing := range all {
+		if existing.ID != task.ID &&
+			existing.Title == task.Title &&
+			existing.ActiveAt.Time().Equal(task.ActiveAt.Time()) &&
+			existing.Status == entity.Active {
+			return entity.ErrAlreadyExists
+		}
+	}
+
+	return nil
+}
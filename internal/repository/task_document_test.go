@@ -0,0 +1,974 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/skantay/todo-list/internal/entity"
+	"github.com/skantay/todo-list/pkg/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// repositories возвращает все репозитории, которым не нужен внешний сервис.
+func repositories(t *testing.T) map[string]Repository {
+	t.Helper()
+
+	db, err := sqlite.Open(context.Background(), ":memory:")
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	sqliteRepo, err := NewSQLite(context.Background(), db, nil)
+	require.NoError(t, err)
+
+	return map[string]Repository{
+		"memory": NewMemory(nil),
+		"sqlite": sqliteRepo,
+	}
+}
+
+// backends возвращает все реализации TaskRepository, которым не нужен внешний сервис.
+func backends(t *testing.T) map[string]TaskRepository {
+	t.Helper()
+
+	result := make(map[string]TaskRepository)
+	for name, repo := range repositories(t) {
+		result[name] = repo.TaskRepository
+	}
+
+	return result
+}
+
+func date(year int, month time.Month, day int) entity.TaskDate {
+	return entity.DateOf(time.Date(year, month, day, 0, 0, 0, 0, time.UTC))
+}
+
+func Test_DocumentCreate(t *testing.T) {
+	for name, repo := range backends(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			task := entity.NewTask("title", date(2024, 4, 1))
+			task.Description = "# Plan\n\n- first"
+			task.Priority = entity.PriorityUrgent
+			task.Tags = []string{"home", "work"}
+			task.Recurrence = "FREQ=MONTHLY;BYMONTHDAY=1"
+			task.Occurrence = 1
+
+			id, err := repo.Create(ctx, task)
+			require.NoError(t, err)
+			assert.NotEmpty(t, id)
+
+			got, err := repo.Get(ctx, id)
+			require.NoError(t, err)
+			task.ID = id
+			task.Version = 1
+			assert.Equal(t, task, got)
+
+			_, err = repo.Create(ctx, entity.NewTask("title", date(2024, 4, 1)))
+			if !errors.Is(err, entity.ErrAlreadyExists) {
+				t.Errorf("\nexpected error:%v \ninvalid error: %v", entity.ErrAlreadyExists, err)
+			}
+		})
+	}
+}
+
+func Test_DocumentList(t *testing.T) {
+	now := time.Date(2024, 4, 10, 0, 0, 0, 0, time.UTC)
+
+	for name, repo := range backends(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			_, err := repo.Create(ctx, entity.NewTask("second", date(2024, 4, 5)))
+			require.NoError(t, err)
+			_, err = repo.Create(ctx, entity.NewTask("first", date(2024, 4, 1)))
+			require.NoError(t, err)
+			_, err = repo.Create(ctx, entity.NewTask("future", date(2024, 5, 1)))
+			require.NoError(t, err)
+			doneID, err := repo.Create(ctx, entity.NewTask("done", date(2024, 4, 2)))
+			require.NoError(t, err)
+			require.NoError(t, repo.SetStatus(ctx, doneID, entity.AnyVersion, entity.Done, &now))
+
+			active, err := repo.List(ctx, entity.TaskFilter{Status: entity.Active, Now: now}, firstPage(10))
+			require.NoError(t, err)
+			assert.Equal(t, []string{"first", "second"}, titles(active.Tasks))
+			assert.Equal(t, int64(2), active.Total)
+
+			done, err := repo.List(ctx, entity.TaskFilter{Status: entity.Done, Now: now}, firstPage(10))
+			require.NoError(t, err)
+			assert.Equal(t, []string{"done"}, titles(done.Tasks))
+		})
+	}
+}
+
+func Test_DocumentListPagination(t *testing.T) {
+	filter := entity.TaskFilter{Status: entity.Active, Now: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)}
+
+	for name, repo := range backends(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			// Две задачи на одну дату проверяют разрешение равенства по id
+			for i, title := range []string{"a", "b", "c", "d", "e"} {
+				_, err := repo.Create(ctx, entity.NewTask(title, date(2024, 4, 1+i/2*2)))
+				require.NoError(t, err)
+			}
+
+			page := entity.PageRequest{Limit: 2, SortBy: entity.SortByActiveAt, Order: entity.OrderDesc}
+
+			first, err := repo.List(ctx, filter, page)
+			require.NoError(t, err)
+			assert.Equal(t, []string{"e", "d"}, titles(first.Tasks))
+			assert.Equal(t, int64(5), first.Total)
+			assert.Nil(t, first.Prev)
+			require.NotNil(t, first.Next)
+
+			page.Cursor = first.Next
+			second, err := repo.List(ctx, filter, page)
+			require.NoError(t, err)
+			assert.Equal(t, []string{"c", "b"}, titles(second.Tasks))
+			require.NotNil(t, second.Next)
+			require.NotNil(t, second.Prev)
+
+			page.Cursor = second.Next
+			third, err := repo.List(ctx, filter, page)
+			require.NoError(t, err)
+			assert.Equal(t, []string{"a"}, titles(third.Tasks))
+			assert.Nil(t, third.Next)
+			require.NotNil(t, third.Prev)
+
+			page.Cursor = third.Prev
+			back, err := repo.List(ctx, filter, page)
+			require.NoError(t, err)
+			assert.Equal(t, []string{"c", "b"}, titles(back.Tasks))
+			require.NotNil(t, back.Prev)
+
+			page.Cursor = back.Prev
+			start, err := repo.List(ctx, filter, page)
+			require.NoError(t, err)
+			assert.Equal(t, []string{"e", "d"}, titles(start.Tasks))
+			assert.Nil(t, start.Prev)
+
+			byTitle, err := repo.List(ctx, filter, entity.PageRequest{Limit: 3, SortBy: entity.SortByTitle, Order: entity.OrderAsc})
+			require.NoError(t, err)
+			assert.Equal(t, []string{"a", "b", "c"}, titles(byTitle.Tasks))
+		})
+	}
+}
+
+func Test_DocumentListFilter(t *testing.T) {
+	now := time.Date(2024, 4, 10, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		filter entity.TaskFilter
+		want   []string
+	}{
+		{
+			name:   "#1 all statuses without future",
+			filter: entity.TaskFilter{Status: entity.StatusAll},
+			want:   []string{"Buy milk", "Old report", "milk shake"},
+		},
+		{
+			name:   "#2 active including future",
+			filter: entity.TaskFilter{Status: entity.Active, IncludeFuture: true},
+			want:   []string{"Buy milk", "milk shake", "Future plan"},
+		},
+		{
+			name:   "#3 date range",
+			filter: entity.TaskFilter{Status: entity.StatusAll, IncludeFuture: true, From: date(2024, 4, 2), To: date(2024, 5, 1)},
+			want:   []string{"Old report", "milk shake", "Future plan"},
+		},
+		{
+			name:   "#4 title contains",
+			filter: entity.TaskFilter{Status: entity.StatusAll, Title: "MILK", TitleMatch: entity.TitleContains},
+			want:   []string{"Buy milk", "milk shake"},
+		},
+		{
+			name:   "#5 title prefix",
+			filter: entity.TaskFilter{Status: entity.StatusAll, Title: "milk", TitleMatch: entity.TitlePrefix},
+			want:   []string{"milk shake"},
+		},
+		{
+			name:   "#6 priorities",
+			filter: entity.TaskFilter{Status: entity.StatusAll, Priorities: []string{entity.PriorityNormal, entity.PriorityUrgent}},
+			want:   []string{"Old report", "milk shake"},
+		},
+		{
+			name:   "#7 all tags",
+			filter: entity.TaskFilter{Status: entity.StatusAll, IncludeFuture: true, Tags: []string{"food", "home"}},
+			want:   []string{"Buy milk"},
+		},
+	}
+
+	tagged := func(title string, activeAt entity.TaskDate, priority string, tags ...string) entity.Task {
+		task := entity.NewTask(title, activeAt)
+		task.Priority = priority
+		task.Tags = tags
+
+		return task
+	}
+
+	for name, repo := range backends(t) {
+		ctx := context.Background()
+
+		for _, task := range []entity.Task{
+			tagged("Buy milk", date(2024, 4, 1), entity.PriorityHigh, "food", "home"),
+			tagged("Old report", date(2024, 4, 2), entity.PriorityNormal, "work"),
+			tagged("milk shake", date(2024, 4, 3), entity.PriorityUrgent, "food"),
+			tagged("Future plan", date(2024, 5, 1), entity.PriorityLow, "home"),
+		} {
+			id, err := repo.Create(ctx, task)
+			require.NoError(t, err)
+
+			if task.Title == "Old report" {
+				require.NoError(t, repo.SetStatus(ctx, id, entity.AnyVersion, entity.Done, &now))
+			}
+		}
+
+		for _, tt := range tests {
+			t.Run(name+" "+tt.name, func(t *testing.T) {
+				tt.filter.Now = now
+
+				page, err := repo.List(ctx, tt.filter, firstPage(10))
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, titles(page.Tasks))
+			})
+		}
+	}
+}
+
+func Test_DocumentListTimeZone(t *testing.T) {
+	almaty := time.FixedZone("UTC+5", 5*60*60)
+	// 2024-04-10 02:00 в UTC+5, в UTC ещё 9 апреля
+	now := time.Date(2024, 4, 9, 21, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		filter entity.TaskFilter
+		want   []string
+	}{
+		{
+			name:   "#1 started in UTC",
+			filter: entity.TaskFilter{Status: entity.Active, Now: now},
+			want:   []string{"yesterday", "morning"},
+		},
+		{
+			name:   "#2 started in UTC+5",
+			filter: entity.TaskFilter{Status: entity.Active, Now: now, Location: almaty},
+			want:   []string{"yesterday", "morning", "today"},
+		},
+		{
+			name: "#3 whole day range in UTC+5",
+			filter: entity.TaskFilter{
+				Status:        entity.Active,
+				Now:           now,
+				Location:      almaty,
+				IncludeFuture: true,
+				From:          date(2024, 4, 10),
+				To:            date(2024, 4, 10),
+			},
+			want: []string{"morning", "today", "later"},
+		},
+	}
+
+	for name, repo := range backends(t) {
+		ctx := context.Background()
+
+		for _, task := range []entity.Task{
+			entity.NewTask("yesterday", date(2024, 4, 9)),
+			entity.NewTask("today", date(2024, 4, 10)),
+			// 2024-04-10 01:30 в UTC+5
+			entity.NewTask("morning", entity.TimeOf(time.Date(2024, 4, 9, 20, 30, 0, 0, time.UTC))),
+			// 2024-04-10 23:00 в UTC+5
+			entity.NewTask("later", entity.TimeOf(time.Date(2024, 4, 10, 18, 0, 0, 0, time.UTC))),
+		} {
+			_, err := repo.Create(ctx, task)
+			require.NoError(t, err)
+		}
+
+		for _, tt := range tests {
+			t.Run(name+" "+tt.name, func(t *testing.T) {
+				page, err := repo.List(ctx, tt.filter, firstPage(10))
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, titles(page.Tasks))
+			})
+		}
+	}
+}
+
+func Test_DocumentListOverdue(t *testing.T) {
+	now := time.Date(2024, 4, 10, 12, 0, 0, 0, time.UTC)
+	overdue, notOverdue := true, false
+
+	due := func(title string, dueAt entity.TaskDate) entity.Task {
+		task := entity.NewTask(title, date(2024, 4, 1))
+		task.DueAt = &dueAt
+
+		return task
+	}
+
+	for name, repo := range backends(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			for _, task := range []entity.Task{
+				due("yesterday", date(2024, 4, 9)),
+				due("today", date(2024, 4, 10)),
+				due("this morning", entity.TimeOf(time.Date(2024, 4, 10, 9, 0, 0, 0, time.UTC))),
+				entity.NewTask("no due date", date(2024, 4, 1)),
+			} {
+				_, err := repo.Create(ctx, task)
+				require.NoError(t, err)
+			}
+
+			doneID, err := repo.Create(ctx, due("done", date(2024, 4, 2)))
+			require.NoError(t, err)
+			require.NoError(t, repo.SetStatus(ctx, doneID, entity.AnyVersion, entity.Done, &now))
+
+			page, err := repo.List(ctx, entity.TaskFilter{Status: entity.StatusAll, Now: now, Overdue: &overdue}, firstPage(10))
+			require.NoError(t, err)
+			assert.ElementsMatch(t, []string{"yesterday", "this morning"}, titles(page.Tasks))
+
+			page, err = repo.List(ctx, entity.TaskFilter{Status: entity.StatusAll, Now: now, Overdue: &notOverdue}, firstPage(10))
+			require.NoError(t, err)
+			assert.ElementsMatch(t, []string{"today", "no due date", "done"}, titles(page.Tasks))
+
+			// Срок снимается обновлением без dueAt
+			page, err = repo.List(ctx, entity.TaskFilter{Status: entity.Active, Now: now, Title: "yesterday"}, firstPage(10))
+			require.NoError(t, err)
+			require.Len(t, page.Tasks, 1)
+
+			yesterday := page.Tasks[0]
+			yesterday.ActiveAt = date(2024, 4, 2)
+			yesterday.DueAt = nil
+			require.NoError(t, repo.Update(ctx, yesterday))
+
+			page, err = repo.List(ctx, entity.TaskFilter{Status: entity.Active, Now: now, Overdue: &overdue}, firstPage(10))
+			require.NoError(t, err)
+			assert.Equal(t, []string{"this morning"}, titles(page.Tasks))
+		})
+	}
+}
+
+func Test_DocumentSearch(t *testing.T) {
+	for name, repo := range backends(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			for _, title := range []string{"Weekly report", "Report to the team about the report", "Buy milk"} {
+				_, err := repo.Create(ctx, entity.NewTask(title, date(2024, 4, 1)))
+				require.NoError(t, err)
+			}
+
+			results, err := repo.Search(ctx, "REPORT -milk", 10)
+			require.NoError(t, err)
+			require.Len(t, results, 2)
+			assert.Equal(t, "Weekly report", results[0].Task.Title)
+			assert.Greater(t, results[0].Score, results[1].Score)
+
+			results, err = repo.Search(ctx, "report", 1)
+			require.NoError(t, err)
+			assert.Len(t, results, 1)
+
+			described := entity.NewTask("Groceries", date(2024, 4, 1))
+			described.Description = "attach the report"
+			_, err = repo.Create(ctx, described)
+			require.NoError(t, err)
+
+			results, err = repo.Search(ctx, "report", 10)
+			require.NoError(t, err)
+			require.Len(t, results, 3)
+			assert.Equal(t, "Groceries", results[2].Task.Title)
+
+			results, err = repo.Search(ctx, "nothing", 10)
+			require.NoError(t, err)
+			assert.Empty(t, results)
+		})
+	}
+}
+
+// assertSingleCreate создаёт одну и ту же задачу из нескольких горутин одновременно
+// и проверяет, что создана ровно одна, а остальные получили entity.ErrAlreadyExists.
+func assertSingleCreate(t *testing.T, repo TaskRepository) {
+	t.Helper()
+
+	const workers = 20
+
+	ctx := entity.WithUserID(context.Background(), "alice")
+
+	var (
+		wg      sync.WaitGroup
+		start   = make(chan struct{})
+		results = make(chan error, workers)
+	)
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+
+			_, err := repo.Create(ctx, entity.NewTask("deploy", date(2024, 4, 1)))
+			results <- err
+		}()
+	}
+
+	close(start)
+	wg.Wait()
+	close(results)
+
+	created := 0
+	for err := range results {
+		if err == nil {
+			created++
+
+			continue
+		}
+		assert.ErrorIs(t, err, entity.ErrAlreadyExists)
+	}
+	assert.Equal(t, 1, created)
+
+	page, err := repo.List(ctx, entity.TaskFilter{Status: entity.StatusAll, IncludeFuture: true}, firstPage(workers))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"deploy"}, titles(page.Tasks))
+}
+
+func Test_DocumentCreateConcurrent(t *testing.T) {
+	for name, repo := range backends(t) {
+		t.Run(name, func(t *testing.T) {
+			assertSingleCreate(t, repo)
+		})
+	}
+}
+
+func Test_DocumentUnique(t *testing.T) {
+	for name, repo := range backends(t) {
+		t.Run(name, func(t *testing.T) {
+			assertUnique(t, repo)
+		})
+	}
+}
+
+// assertUnique проверяет, что уникальны только активные задачи:
+// одинаковые выполненные задачи допустимы, а вернуть в работу дубликат активной нельзя.
+func assertUnique(t *testing.T, repo TaskRepository) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	id, err := repo.Create(ctx, entity.NewTask("title", date(2024, 4, 1)))
+	require.NoError(t, err)
+	otherID, err := repo.Create(ctx, entity.NewTask("other", date(2024, 4, 1)))
+	require.NoError(t, err)
+
+	// Задача не конфликтует сама с собой
+	task := entity.NewTask("title", date(2024, 4, 1))
+	task.ID = id
+	task.Description = "updated"
+	require.NoError(t, repo.Update(ctx, task))
+
+	task.ID = otherID
+	assert.ErrorIs(t, repo.Update(ctx, task), entity.ErrAlreadyExists)
+
+	// Выполненная задача не мешает такой же активной, но вернуть её в работу нельзя
+	require.NoError(t, repo.SetStatus(ctx, id, entity.AnyVersion, entity.Done, nil))
+	secondID, err := repo.Create(ctx, entity.NewTask("title", date(2024, 4, 1)))
+	require.NoError(t, err)
+	assert.ErrorIs(t, repo.SetStatus(ctx, id, entity.AnyVersion, entity.Active, nil), entity.ErrAlreadyExists)
+
+	// Одинаковые выполненные задачи не конфликтуют
+	require.NoError(t, repo.SetStatus(ctx, secondID, entity.AnyVersion, entity.Done, nil))
+	require.NoError(t, repo.SetStatus(ctx, id, entity.AnyVersion, entity.Active, nil))
+	require.NoError(t, repo.SetStatus(ctx, id, entity.AnyVersion, entity.Done, nil))
+
+	// Выполненную задачу можно переименовать в заголовок активной
+	task = entity.NewTask("other", date(2024, 4, 1))
+	task.ID = id
+	require.NoError(t, repo.Update(ctx, task))
+}
+
+func Test_DocumentUpdate(t *testing.T) {
+	for name, repo := range backends(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			id, err := repo.Create(ctx, entity.NewTask("title", date(2024, 4, 1)))
+			require.NoError(t, err)
+
+			task := entity.NewTask("updated", date(2024, 4, 2))
+			task.ID = id
+			require.NoError(t, repo.Update(ctx, task))
+
+			tasks, err := repo.List(ctx, entity.TaskFilter{Status: entity.Active, Now: time.Date(2024, 4, 10, 0, 0, 0, 0, time.UTC)}, firstPage(10))
+			require.NoError(t, err)
+			assert.Equal(t, []string{"updated"}, titles(tasks.Tasks))
+
+			task.ID = "invalid"
+			assert.ErrorIs(t, repo.Update(ctx, task), entity.ErrInvalidID)
+
+			task = entity.NewTask("missing", date(2024, 4, 2))
+			task.ID = "661fbb485131cd932a981b26"
+			assert.ErrorIs(t, repo.Update(ctx, task), entity.ErrTaskNotFound)
+		})
+	}
+}
+
+func Test_DocumentSetStatus(t *testing.T) {
+	completedAt := time.Date(2024, 4, 10, 12, 30, 0, 0, time.UTC)
+
+	for name, repo := range backends(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			id, err := repo.Create(ctx, entity.NewTask("title", date(2024, 4, 1)))
+			require.NoError(t, err)
+
+			require.NoError(t, repo.SetStatus(ctx, id, entity.AnyVersion, entity.Done, &completedAt))
+
+			task, err := repo.Get(ctx, id)
+			require.NoError(t, err)
+			assert.Equal(t, entity.Done, task.Status)
+			require.NotNil(t, task.CompletedAt)
+			assert.True(t, completedAt.Equal(*task.CompletedAt))
+
+			// При возврате в работу момент выполнения стирается
+			require.NoError(t, repo.SetStatus(ctx, id, entity.AnyVersion, entity.Active, nil))
+
+			task, err = repo.Get(ctx, id)
+			require.NoError(t, err)
+			assert.Equal(t, entity.Active, task.Status)
+			assert.Nil(t, task.CompletedAt)
+
+			assert.ErrorIs(t, repo.SetStatus(ctx, "invalid", entity.AnyVersion, entity.Done, nil), entity.ErrInvalidID)
+			assert.ErrorIs(t, repo.SetStatus(ctx, "661fbb485131cd932a981b26", entity.AnyVersion, entity.Done, nil), entity.ErrTaskNotFound)
+		})
+	}
+}
+
+func Test_DocumentVersion(t *testing.T) {
+	for name, repo := range backends(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			id, err := repo.Create(ctx, entity.NewTask("title", date(2024, 4, 1)))
+			require.NoError(t, err)
+
+			version := func() int64 {
+				task, err := repo.Get(ctx, id)
+				require.NoError(t, err)
+
+				return task.Version
+			}
+			assert.Equal(t, int64(1), version())
+
+			task := entity.NewTask("updated", date(2024, 4, 2))
+			task.ID = id
+			task.Version = 1
+			require.NoError(t, repo.Update(ctx, task))
+			assert.Equal(t, int64(2), version())
+
+			// Вторая вкладка с устаревшей версией не перезаписывает изменения
+			task.Title = "stale"
+			assert.ErrorIs(t, repo.Update(ctx, task), entity.ErrVersionMismatch)
+			assert.ErrorIs(t, repo.SetStatus(ctx, id, 1, entity.Done, nil), entity.ErrVersionMismatch)
+			assert.ErrorIs(t, repo.Delete(ctx, id, 1), entity.ErrVersionMismatch)
+
+			// Любое изменение задачи увеличивает версию
+			require.NoError(t, repo.SetChecklist(ctx, id, []entity.ChecklistItem{{ID: "a", Title: "item"}}))
+			assert.Equal(t, int64(3), version())
+
+			require.NoError(t, repo.SetStatus(ctx, id, 3, entity.InProgress, nil))
+			assert.Equal(t, int64(4), version())
+
+			require.NoError(t, repo.Delete(ctx, id, 4))
+			assert.ErrorIs(t, repo.Delete(ctx, id, 4), entity.ErrTaskNotFound)
+		})
+	}
+}
+
+func Test_DocumentPatch(t *testing.T) {
+	for name, repo := range backends(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			dueAt := date(2024, 4, 5)
+			task := entity.NewTask("title", date(2024, 4, 1))
+			task.Description = "description"
+			task.DueAt = &dueAt
+			task.Tags = []string{"home"}
+
+			id, err := repo.Create(ctx, task)
+			require.NoError(t, err)
+			_, err = repo.Create(ctx, entity.NewTask("other", date(2024, 4, 1)))
+			require.NoError(t, err)
+
+			// Меняются только поля из изменения, остальные остаются как были
+			require.NoError(t, repo.Patch(ctx, id, 1, entity.TaskPatch{
+				Title:       entity.Change("patched"),
+				Description: entity.Change(""),
+				DueAt:       entity.Change[*entity.TaskDate](nil),
+			}))
+
+			patched, err := repo.Get(ctx, id)
+			require.NoError(t, err)
+			assert.Equal(t, "patched", patched.Title)
+			assert.Empty(t, patched.Description)
+			assert.Nil(t, patched.DueAt)
+			assert.Equal(t, []string{"home"}, patched.Tags)
+			assert.Equal(t, int64(2), patched.Version)
+
+			assert.ErrorIs(t, repo.Patch(ctx, id, 1, entity.TaskPatch{Title: entity.Change("stale")}), entity.ErrVersionMismatch)
+			assert.ErrorIs(t, repo.Patch(ctx, id, entity.AnyVersion, entity.TaskPatch{Title: entity.Change("other")}), entity.ErrAlreadyExists)
+			assert.ErrorIs(t, repo.Patch(ctx, "661fbb485131cd932a981b26", entity.AnyVersion, entity.TaskPatch{}), entity.ErrTaskNotFound)
+		})
+	}
+}
+
+func Test_DocumentBulkWrite(t *testing.T) {
+	for name, repo := range backends(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			doneID, err := repo.Create(ctx, entity.NewTask("done", date(2024, 4, 1)))
+			require.NoError(t, err)
+			staleID, err := repo.Create(ctx, entity.NewTask("stale", date(2024, 4, 1)))
+			require.NoError(t, err)
+			deletedID, err := repo.Create(ctx, entity.NewTask("deleted", date(2024, 4, 1)))
+			require.NoError(t, err)
+
+			completedAt := time.Date(2024, 4, 2, 10, 0, 0, 0, time.UTC)
+			done := entity.NewTask("done", date(2024, 4, 1))
+			done.Status = entity.Done
+			done.CompletedAt = &completedAt
+
+			// Без atomic ошибки одних записей не мешают другим
+			results, err := repo.BulkWrite(ctx, []entity.TaskWrite{
+				{Op: entity.BatchCreate, Task: entity.NewTask("created", date(2024, 4, 1))},
+				{Op: entity.BatchCreate, Task: entity.NewTask("stale", date(2024, 4, 1))},
+				{Op: entity.BatchUpdate, ID: staleID, Version: 5, Task: entity.NewTask("updated", date(2024, 4, 1))},
+				{Op: entity.BatchDone, ID: doneID, Version: 1, Task: done},
+				{Op: entity.BatchDelete, ID: deletedID},
+				{Op: entity.BatchDelete, ID: "661fbb485131cd932a981b26"},
+			}, false)
+			require.NoError(t, err)
+			require.Len(t, results, 6)
+
+			assert.NoError(t, results[0].Err)
+			assert.NotEmpty(t, results[0].ID)
+			assert.ErrorIs(t, results[1].Err, entity.ErrAlreadyExists)
+			assert.Empty(t, results[1].ID)
+			assert.ErrorIs(t, results[2].Err, entity.ErrVersionMismatch)
+			assert.NoError(t, results[3].Err)
+			assert.NoError(t, results[4].Err)
+			assert.ErrorIs(t, results[5].Err, entity.ErrTaskNotFound)
+
+			created, err := repo.Get(ctx, results[0].ID)
+			require.NoError(t, err)
+			assert.Equal(t, int64(1), created.Version)
+
+			completed, err := repo.Get(ctx, doneID)
+			require.NoError(t, err)
+			assert.Equal(t, entity.Done, completed.Status)
+			assert.Equal(t, int64(2), completed.Version)
+
+			_, err = repo.Get(ctx, deletedID)
+			assert.ErrorIs(t, err, entity.ErrTaskNotFound)
+
+			// С atomic ошибка одной записи откатывает все
+			results, err = repo.BulkWrite(ctx, []entity.TaskWrite{
+				{Op: entity.BatchCreate, Task: entity.NewTask("rolled back", date(2024, 4, 1))},
+				{Op: entity.BatchUpdate, ID: staleID, Task: entity.NewTask("renamed", date(2024, 4, 1))},
+				{Op: entity.BatchDelete, ID: doneID, Version: 1},
+			}, true)
+			require.NoError(t, err)
+
+			assert.ErrorIs(t, results[0].Err, entity.ErrBatchAborted)
+			assert.Empty(t, results[0].ID)
+			assert.ErrorIs(t, results[1].Err, entity.ErrBatchAborted)
+			assert.ErrorIs(t, results[2].Err, entity.ErrVersionMismatch)
+
+			stale, err := repo.Get(ctx, staleID)
+			require.NoError(t, err)
+			assert.Equal(t, "stale", stale.Title)
+			assert.Equal(t, int64(1), stale.Version)
+
+			for title, want := range map[string]int{"created": 1, "rolled back": 0} {
+				page, err := repo.List(ctx, entity.TaskFilter{Status: entity.StatusAll, Now: date(2024, 4, 10).Time(), Title: title}, firstPage(10))
+				require.NoError(t, err)
+				assert.Len(t, page.Tasks, want, title)
+			}
+		})
+	}
+}
+
+func Test_DocumentSubtasks(t *testing.T) {
+	now := time.Date(2024, 4, 10, 0, 0, 0, 0, time.UTC)
+
+	for name, repo := range backends(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			parentID, err := repo.Create(ctx, entity.NewTask("release", date(2024, 4, 1)))
+			require.NoError(t, err)
+			otherID, err := repo.Create(ctx, entity.NewTask("other", date(2024, 4, 1)))
+			require.NoError(t, err)
+
+			checklist := []entity.ChecklistItem{{ID: "a", Title: "changelog", Done: true}, {ID: "b", Title: "tag"}}
+			require.NoError(t, repo.SetChecklist(ctx, parentID, checklist))
+
+			parent, err := repo.Get(ctx, parentID)
+			require.NoError(t, err)
+			assert.Equal(t, checklist, parent.Checklist)
+
+			// Обновление задачи не трогает чек-лист
+			parent.Title = "release 1.0"
+			require.NoError(t, repo.Update(ctx, parent))
+			parent, err = repo.Get(ctx, parentID)
+			require.NoError(t, err)
+			assert.Equal(t, checklist, parent.Checklist)
+
+			for _, title := range []string{"build", "deploy"} {
+				child := entity.NewTask(title, date(2024, 4, 2))
+				child.ParentID = parentID
+				_, err := repo.Create(ctx, child)
+				require.NoError(t, err)
+			}
+
+			children, err := repo.Children(ctx, []string{parentID, otherID})
+			require.NoError(t, err)
+			assert.ElementsMatch(t, []string{"build", "deploy"}, titles(children))
+
+			page, err := repo.List(ctx, entity.TaskFilter{Status: entity.Active, Now: now, ParentID: parentID}, firstPage(10))
+			require.NoError(t, err)
+			assert.Equal(t, []string{"build", "deploy"}, titles(page.Tasks))
+			assert.Equal(t, parentID, page.Tasks[0].ParentID)
+
+			require.NoError(t, repo.SetChecklist(ctx, parentID, nil))
+			parent, err = repo.Get(ctx, parentID)
+			require.NoError(t, err)
+			assert.Empty(t, parent.Checklist)
+
+			assert.ErrorIs(t, repo.SetChecklist(ctx, "661fbb485131cd932a981b26", checklist), entity.ErrTaskNotFound)
+		})
+	}
+}
+
+func Test_DocumentBlockers(t *testing.T) {
+	for name, repo := range backends(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			blockerID, err := repo.Create(ctx, entity.NewTask("design", date(2024, 4, 1)))
+			require.NoError(t, err)
+			id, err := repo.Create(ctx, entity.NewTask("build", date(2024, 4, 2)))
+			require.NoError(t, err)
+
+			require.NoError(t, repo.SetBlockers(ctx, id, []string{blockerID}))
+
+			task, err := repo.Get(ctx, id)
+			require.NoError(t, err)
+			assert.Equal(t, []string{blockerID}, task.BlockedBy)
+
+			// Отсутствующие и некорректные id пропускаются
+			tasks, err := repo.GetMany(ctx, []string{blockerID, "661fbb485131cd932a981b26", "invalid", id})
+			require.NoError(t, err)
+			assert.ElementsMatch(t, []string{"design", "build"}, titles(tasks))
+
+			require.NoError(t, repo.SetBlockers(ctx, id, nil))
+			task, err = repo.Get(ctx, id)
+			require.NoError(t, err)
+			assert.Empty(t, task.BlockedBy)
+
+			assert.ErrorIs(t, repo.SetBlockers(ctx, "661fbb485131cd932a981b26", nil), entity.ErrTaskNotFound)
+		})
+	}
+}
+
+func Test_DocumentProjectTasks(t *testing.T) {
+	for name, repo := range backends(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			projectID := "661fbb485131cd932a981b26"
+
+			task := entity.NewTask("release", date(2024, 4, 1))
+			task.ProjectID = projectID
+			_, err := repo.Create(ctx, task)
+			require.NoError(t, err)
+
+			id, err := repo.Create(ctx, entity.NewTask("inbox", date(2024, 4, 2)))
+			require.NoError(t, err)
+
+			require.NoError(t, repo.SetProject(ctx, id, projectID))
+
+			page, err := repo.List(ctx, entity.TaskFilter{Status: entity.StatusAll, IncludeFuture: true, ProjectID: projectID}, firstPage(10))
+			require.NoError(t, err)
+			assert.Equal(t, []string{"release", "inbox"}, titles(page.Tasks))
+
+			require.NoError(t, repo.SetProject(ctx, id, ""))
+			moved, err := repo.Get(ctx, id)
+			require.NoError(t, err)
+			assert.Empty(t, moved.ProjectID)
+
+			deleted, err := repo.DeleteByProject(ctx, projectID)
+			require.NoError(t, err)
+			assert.Equal(t, int64(1), deleted)
+
+			page, err = repo.List(ctx, entity.TaskFilter{Status: entity.StatusAll, IncludeFuture: true}, firstPage(10))
+			require.NoError(t, err)
+			assert.Equal(t, []string{"inbox"}, titles(page.Tasks))
+
+			assert.ErrorIs(t, repo.SetProject(ctx, projectID, ""), entity.ErrTaskNotFound)
+		})
+	}
+}
+
+func Test_DocumentAssignees(t *testing.T) {
+	for name, repo := range backends(t) {
+		t.Run(name, func(t *testing.T) {
+			alice := entity.WithUserID(context.Background(), "alice")
+			bob := entity.WithUserID(context.Background(), "bob")
+
+			id, err := repo.Create(alice, entity.NewTask("review", date(2024, 4, 1)))
+			require.NoError(t, err)
+			_, err = repo.Create(alice, entity.NewTask("deploy", date(2024, 4, 2)))
+			require.NoError(t, err)
+			_, err = repo.Create(bob, entity.NewTask("write", date(2024, 4, 3)))
+			require.NoError(t, err)
+
+			assignedAt := time.Date(2024, 4, 1, 9, 0, 0, 0, time.UTC)
+			first := entity.Assignment{Assignees: []string{"alice", "bob"}, AssignedBy: "alice", AssignedAt: assignedAt}
+			require.NoError(t, repo.SetAssignees(alice, id, first))
+
+			// Чужую задачу назначить нельзя
+			assert.ErrorIs(t, repo.SetAssignees(bob, id, first), entity.ErrTaskNotFound)
+
+			// Задачи, назначенные на bob, видны ему вместе с чужими
+			filter := entity.TaskFilter{Status: entity.StatusAll, IncludeFuture: true, Assignee: "bob"}
+			page, err := repo.List(bob, filter, firstPage(10))
+			require.NoError(t, err)
+			assert.Equal(t, []string{"review"}, titles(page.Tasks))
+
+			// Фильтр по другому исполнителю не снимает ограничение по владельцу
+			filter.Assignee = "alice"
+			page, err = repo.List(bob, filter, firstPage(10))
+			require.NoError(t, err)
+			assert.Empty(t, page.Tasks)
+
+			second := entity.Assignment{AssignedBy: "alice", AssignedAt: assignedAt.Add(time.Hour)}
+			require.NoError(t, repo.SetAssignees(alice, id, second))
+
+			task, err := repo.Get(alice, id)
+			require.NoError(t, err)
+			assert.Empty(t, task.Assignees)
+			assert.Equal(t, []entity.Assignment{first, second}, task.AssignmentHistory)
+
+			filter.Assignee = "bob"
+			page, err = repo.List(bob, filter, firstPage(10))
+			require.NoError(t, err)
+			assert.Empty(t, page.Tasks)
+		})
+	}
+}
+
+func Test_DocumentTenants(t *testing.T) {
+	for name, repo := range backends(t) {
+		t.Run(name, func(t *testing.T) {
+			assertTenants(t, repo)
+		})
+	}
+}
+
+// assertTenants проверяет, что задачи одного рабочего пространства не видны в другом,
+// в том числе исполнителю в списке назначенных на него задач.
+func assertTenants(t *testing.T, repo TaskRepository) {
+	t.Helper()
+
+	alice := entity.WithUserID(context.Background(), "alice")
+	platform := entity.WithTenant(alice, entity.Tenant{ID: "platform"})
+	ops := entity.WithTenant(alice, entity.Tenant{ID: "ops"})
+
+	id, err := repo.Create(platform, entity.NewTask("deploy", date(2024, 4, 1)))
+	require.NoError(t, err)
+
+	// Одинаковая задача в другом пространстве не считается повтором
+	opsID, err := repo.Create(ops, entity.NewTask("deploy", date(2024, 4, 1)))
+	require.NoError(t, err)
+	_, err = repo.Create(alice, entity.NewTask("inbox", date(2024, 4, 2)))
+	require.NoError(t, err)
+
+	task, err := repo.Get(platform, id)
+	require.NoError(t, err)
+	assert.Equal(t, "platform", task.TenantID)
+
+	_, err = repo.Get(ops, id)
+	assert.ErrorIs(t, err, entity.ErrTaskNotFound)
+	_, err = repo.Get(alice, id)
+	assert.ErrorIs(t, err, entity.ErrTaskNotFound)
+	assert.ErrorIs(t, repo.Delete(ops, id, entity.AnyVersion), entity.ErrTaskNotFound)
+
+	filter := entity.TaskFilter{Status: entity.StatusAll, IncludeFuture: true}
+	for ctx, want := range map[context.Context][]string{platform: {"deploy"}, ops: {"deploy"}, alice: {"inbox"}} {
+		page, err := repo.List(ctx, filter, firstPage(10))
+		require.NoError(t, err)
+		assert.Equal(t, want, titles(page.Tasks))
+	}
+
+	// Назначенные задачи тоже видны только в своём пространстве
+	assignment := entity.Assignment{Assignees: []string{"bob"}, AssignedBy: "alice"}
+	require.NoError(t, repo.SetAssignees(platform, id, assignment))
+	require.NoError(t, repo.SetAssignees(ops, opsID, assignment))
+
+	bob := entity.WithUserID(context.Background(), "bob")
+	filter.Assignee = "bob"
+	for ctx, want := range map[context.Context][]string{
+		entity.WithTenant(bob, entity.Tenant{ID: "platform"}): {"deploy"},
+		entity.WithTenant(bob, entity.Tenant{ID: "sales"}):    {},
+		bob: {},
+	} {
+		page, err := repo.List(ctx, filter, firstPage(10))
+		require.NoError(t, err)
+		assert.Equal(t, want, titles(page.Tasks))
+
+		for _, task := range page.Tasks {
+			assert.Equal(t, id, task.ID)
+		}
+	}
+}
+
+func Test_DocumentDelete(t *testing.T) {
+	for name, repo := range backends(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			id, err := repo.Create(ctx, entity.NewTask("title", date(2024, 4, 1)))
+			require.NoError(t, err)
+
+			require.NoError(t, repo.Delete(ctx, id, entity.AnyVersion))
+			assert.ErrorIs(t, repo.Delete(ctx, id, entity.AnyVersion), entity.ErrTaskNotFound)
+			assert.ErrorIs(t, repo.Delete(ctx, "invalid", entity.AnyVersion), entity.ErrInvalidID)
+		})
+	}
+}
+
+func firstPage(limit int) entity.PageRequest {
+	return entity.PageRequest{
+		Limit:  limit,
+		SortBy: entity.SortByActiveAt,
+		Order:  entity.OrderAsc,
+	}
+}
+
+func titles(tasks []entity.Task) []string {
+	result := make([]string, 0, len(tasks))
+	for _, task := range tasks {
+		result = append(result, task.Title)
+	}
+
+	return result
+}
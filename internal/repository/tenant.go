@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/skantay/todo-list/internal/entity"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// inTenant ограничивает фильтр MongoDB документами рабочего пространства из контекста.
+// В пространстве по умолчанию видны только документы без пространства.
+func inTenant(ctx context.Context, filter bson.M) bson.M {
+	if tenant := entity.TenantIDFromContext(ctx); tenant != "" {
+		filter["tenantId"] = tenant
+	} else {
+		filter["tenantId"] = nil
+	}
+
+	return filter
+}
+
+// scope ограничивает фильтр MongoDB документами владельца в рабочем пространстве из контекста.
+func scope(ctx context.Context, filter bson.M) bson.M {
+	return inTenant(ctx, ownedBy(ctx, filter))
+}
+
+// isTenant сообщает, что документ из пространства tenantID принадлежит пространству из контекста.
+func isTenant(ctx context.Context, tenantID string) bool {
+	return tenantID == entity.TenantIDFromContext(ctx)
+}
+
+// tenantCollection выбирает коллекцию MongoDB для рабочего пространства из контекста.
+// Если perTenant выключен, все пространства делят одну коллекцию и разделяются полем tenantId.
+type tenantCollection struct {
+	database  *mongo.Database
+	name      string
+	perTenant bool
+}
+
+// get возвращает коллекцию пространства из контекста.
+// У пространства по умолчанию всегда общая коллекция name.
+func (c tenantCollection) get(ctx context.Context) *mongo.Collection {
+	if tenant := entity.TenantIDFromContext(ctx); c.perTenant && tenant != "" {
+		return c.database.Collection(tenantCollectionName(c.name, tenant))
+	}
+
+	return c.database.Collection(c.name)
+}
+
+// tenantCollectionName возвращает имя отдельной коллекции пространства tenantID.
+func tenantCollectionName(name, tenantID string) string {
+	return name + "_" + tenantID
+}
@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/skantay/todo-list/internal/entity"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type userRepository struct {
+	collection *mongo.Collection
+	log        *slog.Logger
+}
+
+func newUserRepository(collection *mongo.Collection, log *slog.Logger) userRepository {
+	return userRepository{
+		collection: collection,
+		log:        log,
+	}
+}
+
+// Create создаёт пользователя в коллекции.
+func (u userRepository) Create(ctx context.Context, user entity.User) (string, error) {
+	user.ID = primitive.NewObjectID().Hex()
+
+	if _, err := u.collection.InsertOne(ctx, user); err != nil {
+		// Уникальность email обеспечивает индекс user_email
+		if mongo.IsDuplicateKeyError(err) {
+			return "", entity.ErrUserExists
+		}
+		return "", fmt.Errorf("failed to insert a user into db: %w", err)
+	}
+
+	return user.ID, nil
+}
+
+// GetByEmail возвращает пользователя из коллекции по его email.
+func (u userRepository) GetByEmail(ctx context.Context, email string) (entity.User, error) {
+	var user entity.User
+
+	if err := u.collection.FindOne(ctx, bson.M{"email": email}).Decode(&user); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return entity.User{}, entity.ErrUserNotFound
+		}
+		return entity.User{}, fmt.Errorf("failed to find user: %w", err)
+	}
+
+	return user, nil
+}
+
+// Get возвращает пользователя из коллекции по его id.
+func (u userRepository) Get(ctx context.Context, id string) (entity.User, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return entity.User{}, entity.ErrUserNotFound
+	}
+
+	var user entity.User
+
+	if err := u.collection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&user); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return entity.User{}, entity.ErrUserNotFound
+		}
+		return entity.User{}, fmt.Errorf("failed to find user: %w", err)
+	}
+
+	return user, nil
+}
+
+// AddTenant добавляет пользователю рабочее пространство tenantID. Повторное добавление ничего не меняет.
+func (u userRepository) AddTenant(ctx context.Context, id, tenantID string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return entity.ErrUserNotFound
+	}
+
+	res, err := u.collection.UpdateOne(ctx, bson.M{"_id": objectID}, bson.M{"$addToSet": bson.M{"tenants": tenantID}})
+	if err != nil {
+		return fmt.Errorf("failed to add user tenant: %w", err)
+	}
+
+	if res.MatchedCount == 0 {
+		return entity.ErrUserNotFound
+	}
+
+	return nil
+}
+
+// Activate задаёт пароль учётной записи без пароля, которую создала миграция для старых задач.
+// Если пароль у пользователя уже есть, возвращает entity.ErrUserExists.
+func (u userRepository) Activate(ctx context.Context, id, passwordHash string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return entity.ErrUserNotFound
+	}
+
+	// Условие на пустой пароль не даёт двум регистрациям забрать одну учётную запись
+	res, err := u.collection.UpdateOne(ctx,
+		bson.M{"_id": objectID, "passwordHash": ""},
+		bson.M{"$set": bson.M{"passwordHash": passwordHash}},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to activate user: %w", err)
+	}
+
+	if res.MatchedCount == 0 {
+		return entity.ErrUserExists
+	}
+
+	return nil
+}
@@ -0,0 +1,153 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+
+	"github.com/skantay/todo-list/internal/entity"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// documentUserRepository реализует UserRepository поверх documentStore.
+type documentUserRepository struct {
+	// mu сериализует регистрацию, чтобы проверка уникальности email и вставка были атомарны
+	mu    sync.Mutex
+	store documentStore
+	log   *slog.Logger
+}
+
+func newDocumentUserRepository(store documentStore, log *slog.Logger) *documentUserRepository {
+	return &documentUserRepository{
+		store: store,
+		log:   log,
+	}
+}
+
+// Create создаёт пользователя в хранилище.
+func (d *documentUserRepository) Create(ctx context.Context, user entity.User) (string, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	_, err := d.GetByEmail(ctx, user.Email)
+	if err == nil {
+		return "", entity.ErrUserExists
+	}
+	if !errors.Is(err, entity.ErrUserNotFound) {
+		return "", fmt.Errorf("failed to check user uniqueness: %w", err)
+	}
+
+	user.ID = primitive.NewObjectID().Hex()
+
+	document, err := bson.Marshal(user)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode user: %w", err)
+	}
+
+	if err := d.store.put(ctx, user.ID, document); err != nil {
+		return "", fmt.Errorf("failed to insert a user: %w", err)
+	}
+
+	return user.ID, nil
+}
+
+// GetByEmail возвращает пользователя по его email.
+func (d *documentUserRepository) GetByEmail(ctx context.Context, email string) (entity.User, error) {
+	documents, err := d.store.list(ctx)
+	if err != nil {
+		return entity.User{}, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	for _, document := range documents {
+		var user entity.User
+		if err := bson.Unmarshal(document, &user); err != nil {
+			return entity.User{}, fmt.Errorf("failed to decode user: %w", err)
+		}
+
+		if user.Email == email {
+			return user, nil
+		}
+	}
+
+	return entity.User{}, entity.ErrUserNotFound
+}
+
+// Get возвращает пользователя по его id.
+func (d *documentUserRepository) Get(ctx context.Context, id string) (entity.User, error) {
+	document, ok, err := d.store.get(ctx, id)
+	if err != nil {
+		return entity.User{}, fmt.Errorf("failed to get user: %w", err)
+	}
+	if !ok {
+		return entity.User{}, entity.ErrUserNotFound
+	}
+
+	var user entity.User
+	if err := bson.Unmarshal(document, &user); err != nil {
+		return entity.User{}, fmt.Errorf("failed to decode user: %w", err)
+	}
+
+	return user, nil
+}
+
+// AddTenant добавляет пользователю рабочее пространство tenantID. Повторное добавление ничего не меняет.
+func (d *documentUserRepository) AddTenant(ctx context.Context, id, tenantID string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	user, err := d.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if slices.Contains(user.Tenants, tenantID) {
+		return nil
+	}
+
+	user.Tenants = append(user.Tenants, tenantID)
+
+	document, err := bson.Marshal(user)
+	if err != nil {
+		return fmt.Errorf("failed to encode user: %w", err)
+	}
+
+	if err := d.store.put(ctx, id, document); err != nil {
+		return fmt.Errorf("failed to update user: %w", err)
+	}
+
+	return nil
+}
+
+// Activate задаёт пароль учётной записи без пароля.
+// Если пароль у пользователя уже есть, возвращает entity.ErrUserExists.
+func (d *documentUserRepository) Activate(ctx context.Context, id, passwordHash string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	user, err := d.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if user.PasswordHash != "" {
+		return entity.ErrUserExists
+	}
+
+	user.PasswordHash = passwordHash
+
+	document, err := bson.Marshal(user)
+	if err != nil {
+		return fmt.Errorf("failed to encode user: %w", err)
+	}
+
+	if err := d.store.put(ctx, id, document); err != nil {
+		return fmt.Errorf("failed to update user: %w", err)
+	}
+
+	return nil
+}
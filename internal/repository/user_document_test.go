@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/skantay/todo-list/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_DocumentUsers(t *testing.T) {
+	for name, repo := range repositories(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			users := repo.UserRepository
+
+			id, err := users.Create(ctx, entity.User{Email: "user@example.com", PasswordHash: "hash"})
+			require.NoError(t, err)
+
+			_, err = users.Create(ctx, entity.User{Email: "user@example.com", PasswordHash: "other"})
+			assert.ErrorIs(t, err, entity.ErrUserExists)
+
+			user, err := users.GetByEmail(ctx, "user@example.com")
+			require.NoError(t, err)
+			assert.Equal(t, entity.User{ID: id, Email: "user@example.com", PasswordHash: "hash"}, user)
+
+			_, err = users.GetByEmail(ctx, "other@example.com")
+			assert.ErrorIs(t, err, entity.ErrUserNotFound)
+
+			// Пространство добавляется пользователю один раз
+			require.NoError(t, users.AddTenant(ctx, id, "platform"))
+			require.NoError(t, users.AddTenant(ctx, id, "platform"))
+
+			user, err = users.Get(ctx, id)
+			require.NoError(t, err)
+			assert.Equal(t, []string{"platform"}, user.Tenants)
+			assert.True(t, user.IsMember("platform"))
+			assert.False(t, user.IsMember("other"))
+
+			_, err = users.Get(ctx, "661fbb485131cd932a981b26")
+			assert.ErrorIs(t, err, entity.ErrUserNotFound)
+			assert.ErrorIs(t, users.AddTenant(ctx, "661fbb485131cd932a981b26", "platform"), entity.ErrUserNotFound)
+
+			// Учётную запись без пароля можно активировать один раз
+			assert.ErrorIs(t, users.Activate(ctx, id, "new"), entity.ErrUserExists)
+
+			legacyID, err := users.Create(ctx, entity.User{Email: "legacy@example.com"})
+			require.NoError(t, err)
+			require.NoError(t, users.Activate(ctx, legacyID, "hash"))
+			assert.ErrorIs(t, users.Activate(ctx, legacyID, "other"), entity.ErrUserExists)
+
+			user, err = users.GetByEmail(ctx, "legacy@example.com")
+			require.NoError(t, err)
+			assert.Equal(t, "hash", user.PasswordHash)
+		})
+	}
+}
+
+func Test_DocumentOwnership(t *testing.T) {
+	for name, repo := range repositories(t) {
+		t.Run(name, func(t *testing.T) {
+			alice := entity.WithUserID(context.Background(), "alice")
+			bob := entity.WithUserID(context.Background(), "bob")
+			tasks := repo.TaskRepository
+
+			id, err := tasks.Create(alice, entity.NewTask("report", date(2024, 4, 1)))
+			require.NoError(t, err)
+
+			// Одинаковые задачи разных пользователей не конфликтуют
+			bobID, err := tasks.Create(bob, entity.NewTask("report", date(2024, 4, 1)))
+			require.NoError(t, err)
+
+			task, err := tasks.Get(alice, id)
+			require.NoError(t, err)
+			assert.Equal(t, "alice", task.OwnerID)
+
+			_, err = tasks.Get(bob, id)
+			assert.ErrorIs(t, err, entity.ErrTaskNotFound)
+			assert.ErrorIs(t, tasks.SetStatus(bob, id, entity.AnyVersion, entity.Done, nil), entity.ErrTaskNotFound)
+			assert.ErrorIs(t, tasks.Delete(bob, id, entity.AnyVersion), entity.ErrTaskNotFound)
+
+			page, err := tasks.List(bob, entity.TaskFilter{Status: entity.StatusAll, IncludeFuture: true}, firstPage(10))
+			require.NoError(t, err)
+			require.Len(t, page.Tasks, 1)
+			assert.Equal(t, bobID, page.Tasks[0].ID)
+
+			results, err := tasks.Search(bob, "report", 10)
+			require.NoError(t, err)
+			require.Len(t, results, 1)
+			assert.Equal(t, bobID, results[0].Task.ID)
+
+			many, err := tasks.GetMany(bob, []string{id, bobID})
+			require.NoError(t, err)
+			assert.Len(t, many, 1)
+
+			projectID, err := repo.ProjectRepository.Create(alice, entity.Project{Name: "work"})
+			require.NoError(t, err)
+			_, err = repo.ProjectRepository.Create(bob, entity.Project{Name: "work"})
+			require.NoError(t, err)
+
+			_, err = repo.ProjectRepository.Get(bob, projectID)
+			assert.ErrorIs(t, err, entity.ErrProjectNotFound)
+
+			projects, err := repo.ProjectRepository.List(alice, true)
+			require.NoError(t, err)
+			assert.Equal(t, []string{"work"}, projectNames(projects))
+		})
+	}
+}
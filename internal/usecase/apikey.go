@@ -0,0 +1,145 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/skantay/todo-list/internal/entity"
+)
+
+// Константы API-ключей
+const (
+	apiKeyPrefix    = "tdl_"
+	apiKeyBytes     = 32
+	apiKeyPrefixLen = 12 // apiKeyPrefix и первые 8 символов ключа
+	maxAPIKeyName   = 100
+)
+
+// apiKeyRepo определяет интерфейс для repository API-ключей
+type apiKeyRepo interface {
+	Create(ctx context.Context, key entity.APIKey) (string, error)
+	GetByHash(ctx context.Context, hash string) (entity.APIKey, error)
+	List(ctx context.Context) ([]entity.APIKey, error)
+	Revoke(ctx context.Context, id string, revokedAt time.Time) error
+}
+
+// CreateAPIKey создаёт API-ключ пользователя из контекста в его рабочем пространстве. Ключ возвращается один раз,
+// хранится только его хеш. Пустой scope означает read_write, nil expiresAt - бессрочный ключ.
+func (u userUsecase) CreateAPIKey(ctx context.Context, name, scope string, expiresAt *time.Time) (entity.CreatedAPIKey, error) {
+	name = strings.TrimSpace(name)
+
+	if name == "" {
+		return entity.CreatedAPIKey{}, entity.NewValidationError("name", "must not be empty", entity.ErrInvalidAPIKey)
+	}
+
+	if utf8.RuneCountInString(name) > maxAPIKeyName {
+		return entity.CreatedAPIKey{}, entity.NewValidationError("name", fmt.Sprintf("must not exceed %d characters", maxAPIKeyName), entity.ErrInvalidAPIKey)
+	}
+
+	if scope == "" {
+		scope = entity.ScopeReadWrite
+	}
+
+	if !slices.Contains(entity.Scopes, scope) {
+		return entity.CreatedAPIKey{}, entity.NewValidationError("scope", fmt.Sprintf("must be one of %s", strings.Join(entity.Scopes, ", ")), entity.ErrInvalidAPIKey)
+	}
+
+	if err := u.checkMember(ctx, entity.UserIDFromContext(ctx)); err != nil {
+		return entity.CreatedAPIKey{}, err
+	}
+
+	now := time.Now().UTC()
+
+	if expiresAt != nil {
+		if !expiresAt.After(now) {
+			return entity.CreatedAPIKey{}, entity.NewValidationError("expiresAt", "must be in the future", entity.ErrInvalidAPIKey)
+		}
+
+		expires := expiresAt.UTC()
+		expiresAt = &expires
+	}
+
+	secret := make([]byte, apiKeyBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return entity.CreatedAPIKey{}, fmt.Errorf("failed to generate api key: %w", err)
+	}
+
+	key := apiKeyPrefix + base64.RawURLEncoding.EncodeToString(secret)
+
+	apiKey := entity.APIKey{
+		Name:      name,
+		Prefix:    key[:apiKeyPrefixLen],
+		Hash:      entity.HashAPIKey(key),
+		Scope:     scope,
+		CreatedAt: now,
+		ExpiresAt: expiresAt,
+		// Ключ действует только в рабочем пространстве, в котором создан
+		TenantID: entity.TenantIDFromContext(ctx),
+	}
+
+	id, err := u.apiKeys.Create(ctx, apiKey)
+	if err != nil {
+		return entity.CreatedAPIKey{}, fmt.Errorf("failed to create api key: %w", err)
+	}
+
+	apiKey.ID = id
+	apiKey.OwnerID = entity.UserIDFromContext(ctx)
+
+	return entity.CreatedAPIKey{
+		APIKey: apiKey,
+		Key:    key,
+	}, nil
+}
+
+// ListAPIKeys возвращает API-ключи пользователя из контекста, включая отозванные и истёкшие
+func (u userUsecase) ListAPIKeys(ctx context.Context) ([]entity.APIKey, error) {
+	keys, err := u.apiKeys.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+
+	if keys == nil {
+		keys = []entity.APIKey{}
+	}
+
+	return keys, nil
+}
+
+// RevokeAPIKey отзывает API-ключ. Повторный отзыв ничего не меняет.
+func (u userUsecase) RevokeAPIKey(ctx context.Context, id string) error {
+	if err := u.apiKeys.Revoke(ctx, id, time.Now().UTC()); err != nil {
+		return fmt.Errorf("failed to revoke api key: %w", err)
+	}
+
+	return nil
+}
+
+// AuthenticateAPIKey находит действующий API-ключ по его значению.
+// Неизвестный, отозванный и истёкший ключи неотличимы для клиента.
+// Ключ пространства действует, только пока владелец остаётся участником этого пространства.
+func (u userUsecase) AuthenticateAPIKey(ctx context.Context, key string) (entity.APIKey, error) {
+	apiKey, err := u.apiKeys.GetByHash(ctx, entity.HashAPIKey(key))
+	if errors.Is(err, entity.ErrAPIKeyNotFound) {
+		return entity.APIKey{}, fmt.Errorf("%w: unknown api key", entity.ErrUnauthorized)
+	}
+	if err != nil {
+		return entity.APIKey{}, fmt.Errorf("failed to get api key: %w", err)
+	}
+
+	if !apiKey.IsActive(time.Now()) {
+		return entity.APIKey{}, fmt.Errorf("%w: api key is revoked or expired", entity.ErrUnauthorized)
+	}
+
+	if err := u.checkMember(entity.WithTenant(ctx, entity.Tenant{ID: apiKey.TenantID}), apiKey.OwnerID); err != nil {
+		return entity.APIKey{}, err
+	}
+
+	return apiKey, nil
+}
@@ -0,0 +1,96 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/usecase/apikey.go
+
+// Package mock_usecase is a generated GoMock package.
+package usecase
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	entity "github.com/skantay/todo-list/internal/entity"
+)
+
+// MockapiKeyRepo is a mock of apiKeyRepo interface.
+type MockapiKeyRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockapiKeyRepoMockRecorder
+}
+
+// MockapiKeyRepoMockRecorder is the mock recorder for MockapiKeyRepo.
+type MockapiKeyRepoMockRecorder struct {
+	mock *MockapiKeyRepo
+}
+
+// NewMockapiKeyRepo creates a new mock instance.
+func NewMockapiKeyRepo(ctrl *gomock.Controller) *MockapiKeyRepo {
+	mock := &MockapiKeyRepo{ctrl: ctrl}
+	mock.recorder = &MockapiKeyRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockapiKeyRepo) EXPECT() *MockapiKeyRepoMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockapiKeyRepo) Create(ctx context.Context, key entity.APIKey) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, key)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockapiKeyRepoMockRecorder) Create(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockapiKeyRepo)(nil).Create), ctx, key)
+}
+
+// GetByHash mocks base method.
+func (m *MockapiKeyRepo) GetByHash(ctx context.Context, hash string) (entity.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByHash", ctx, hash)
+	ret0, _ := ret[0].(entity.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByHash indicates an expected call of GetByHash.
+func (mr *MockapiKeyRepoMockRecorder) GetByHash(ctx, hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByHash", reflect.TypeOf((*MockapiKeyRepo)(nil).GetByHash), ctx, hash)
+}
+
+// List mocks base method.
+func (m *MockapiKeyRepo) List(ctx context.Context) ([]entity.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx)
+	ret0, _ := ret[0].([]entity.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockapiKeyRepoMockRecorder) List(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockapiKeyRepo)(nil).List), ctx)
+}
+
+// Revoke mocks base method.
+func (m *MockapiKeyRepo) Revoke(ctx context.Context, id string, revokedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, id, revokedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockapiKeyRepoMockRecorder) Revoke(ctx, id, revokedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockapiKeyRepo)(nil).Revoke), ctx, id, revokedAt)
+}
@@ -0,0 +1,195 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/skantay/todo-list/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CreateAPIKey(t *testing.T) {
+	future := time.Now().Add(time.Hour)
+	past := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name      string
+		keyName   string
+		scope     string
+		expiresAt *time.Time
+		// tenantID - пространство запроса, tenants - пространства пользователя
+		tenantID  string
+		tenants   []string
+		wantScope string
+		wantErr   error
+	}{
+		{
+			name:      "#1 default scope",
+			keyName:   " CI ",
+			wantScope: entity.ScopeReadWrite,
+		},
+		{
+			name:      "#2 read scope with expiry",
+			keyName:   "CI",
+			scope:     entity.ScopeRead,
+			expiresAt: &future,
+			wantScope: entity.ScopeRead,
+		},
+		{
+			name:    "#3 empty name",
+			keyName: " ",
+			wantErr: entity.ErrInvalidAPIKey,
+		},
+		{
+			name:    "#4 long name",
+			keyName: strings.Repeat("a", maxAPIKeyName+1),
+			wantErr: entity.ErrInvalidAPIKey,
+		},
+		{
+			name:    "#5 unknown scope",
+			keyName: "CI",
+			scope:   "admin",
+			wantErr: entity.ErrInvalidAPIKey,
+		},
+		{
+			name:      "#6 expiry in the past",
+			keyName:   "CI",
+			expiresAt: &past,
+			wantErr:   entity.ErrInvalidAPIKey,
+		},
+		{
+			name:      "#7 member of workspace",
+			keyName:   "CI",
+			tenantID:  "platform",
+			tenants:   []string{"platform"},
+			wantScope: entity.ScopeReadWrite,
+		},
+		{
+			name:     "#8 foreign workspace",
+			keyName:  "CI",
+			tenantID: "platform",
+			tenants:  []string{"other"},
+			wantErr:  entity.ErrForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			apiKeyRepo := NewMockapiKeyRepo(ctrl)
+			userRepo := NewMockuserRepo(ctrl)
+
+			if tt.tenantID != "" {
+				userRepo.EXPECT().Get(gomock.Any(), userID).Return(entity.User{ID: userID, Tenants: tt.tenants}, nil)
+			}
+
+			var stored entity.APIKey
+
+			if tt.wantErr == nil {
+				apiKeyRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, key entity.APIKey) (string, error) {
+					stored = key
+
+					return "661fbb485131cd932a981b29", nil
+				})
+			}
+
+			ctx := entity.WithUserID(context.Background(), userID)
+			if tt.tenantID != "" {
+				ctx = entity.WithTenant(ctx, entity.Tenant{ID: tt.tenantID})
+			}
+
+			created, err := newUserUsecase(userRepo, apiKeyRepo, nil).CreateAPIKey(ctx, tt.keyName, tt.scope, tt.expiresAt)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), "unexpected error: %v", err)
+
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, "661fbb485131cd932a981b29", created.ID)
+			assert.Equal(t, userID, created.OwnerID)
+			assert.Equal(t, "CI", created.Name)
+			assert.Equal(t, tt.wantScope, created.Scope)
+			assert.Equal(t, tt.tenantID, stored.TenantID)
+			assert.True(t, strings.HasPrefix(created.Key, apiKeyPrefix))
+			assert.Equal(t, created.Key[:apiKeyPrefixLen], created.Prefix)
+
+			// Хранится только хеш ключа
+			assert.Equal(t, entity.HashAPIKey(created.Key), stored.Hash)
+			assert.NotContains(t, stored.Hash, created.Key)
+		})
+	}
+}
+
+func Test_AuthenticateAPIKey(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name   string
+		key    entity.APIKey
+		getErr error
+		// owner - владелец ключа пространства, nil - ключ пространства по умолчанию
+		owner   *entity.User
+		wantErr error
+	}{
+		{
+			name: "#1 active",
+			key:  entity.APIKey{OwnerID: userID, Scope: entity.ScopeRead, ExpiresAt: &future},
+		},
+		{
+			name:    "#2 unknown",
+			getErr:  entity.ErrAPIKeyNotFound,
+			wantErr: entity.ErrUnauthorized,
+		},
+		{
+			name:    "#3 revoked",
+			key:     entity.APIKey{OwnerID: userID, RevokedAt: &past},
+			wantErr: entity.ErrUnauthorized,
+		},
+		{
+			name:    "#4 expired",
+			key:     entity.APIKey{OwnerID: userID, ExpiresAt: &past},
+			wantErr: entity.ErrUnauthorized,
+		},
+		{
+			name:  "#5 owner is a member of workspace",
+			key:   entity.APIKey{OwnerID: userID, TenantID: "platform"},
+			owner: &entity.User{ID: userID, Tenants: []string{"platform"}},
+		},
+		{
+			name:    "#6 owner is not a member of workspace",
+			key:     entity.APIKey{OwnerID: userID, TenantID: "platform"},
+			owner:   &entity.User{ID: userID},
+			wantErr: entity.ErrForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			apiKeyRepo := NewMockapiKeyRepo(ctrl)
+			apiKeyRepo.EXPECT().GetByHash(gomock.Any(), entity.HashAPIKey("tdl_key")).Return(tt.key, tt.getErr)
+
+			userRepo := NewMockuserRepo(ctrl)
+			if tt.owner != nil {
+				userRepo.EXPECT().Get(gomock.Any(), userID).Return(*tt.owner, nil)
+			}
+
+			key, err := newUserUsecase(userRepo, apiKeyRepo, nil).AuthenticateAPIKey(context.Background(), "tdl_key")
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), "unexpected error: %v", err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, userID, key.OwnerID)
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/skantay/todo-list/internal/entity"
+)
+
+// maxAssignees - максимальное количество исполнителей задачи
+const maxAssignees = 10
+
+// Assign заменяет исполнителей задачи и записывает назначение в историю.
+// Исполнителем может быть владелец задачи или пользователь, принявший приглашение к задаче или её проекту.
+// "me" означает текущего пользователя, пустой список снимает всех исполнителей.
+// Назначать может пользователь с ролью editor, тот же состав исполнителей ничего не меняет.
+func (t taskUsecase) Assign(ctx context.Context, id string, assignees []string) error {
+	callerID := entity.UserIDFromContext(ctx)
+
+	assignees, err := normalizeAssignees(assignees, callerID)
+	if err != nil {
+		return err
+	}
+
+	ctx, err = taskAccess(ctx, t.repo, t.shares, id, entity.RoleEditor)
+	if err != nil {
+		return err
+	}
+
+	task, err := t.repo.Get(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get task: %w", err)
+	}
+
+	if slices.Equal(task.Assignees, assignees) {
+		return nil
+	}
+
+	if err := t.validateAssignees(ctx, task, assignees); err != nil {
+		return err
+	}
+
+	assignment := entity.Assignment{
+		Assignees:  assignees,
+		AssignedBy: callerID,
+		AssignedAt: time.Now().UTC(),
+	}
+
+	if err := t.repo.SetAssignees(ctx, id, assignment); err != nil {
+		return fmt.Errorf("failed to assign task: %w", err)
+	}
+
+	return nil
+}
+
+// Assignments возвращает историю назначений задачи, от старых к новым
+func (t taskUsecase) Assignments(ctx context.Context, id string) ([]entity.Assignment, error) {
+	ctx, err := taskAccess(ctx, t.repo, t.shares, id, entity.RoleViewer)
+	if err != nil {
+		return nil, err
+	}
+
+	task, err := t.repo.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task: %w", err)
+	}
+
+	if task.AssignmentHistory == nil {
+		return []entity.Assignment{}, nil
+	}
+
+	return task.AssignmentHistory, nil
+}
+
+// validateAssignees проверяет, что у каждого исполнителя есть доступ к задаче
+func (t taskUsecase) validateAssignees(ctx context.Context, task entity.Task, assignees []string) error {
+	// Приглашения читаются только если назначается кто-то кроме владельца
+	if !slices.ContainsFunc(assignees, func(assignee string) bool { return assignee != task.OwnerID }) {
+		return nil
+	}
+
+	allowed, err := t.allowedAssignees(ctx, task)
+	if err != nil {
+		return err
+	}
+
+	for _, assignee := range assignees {
+		if !allowed[assignee] {
+			return entity.NewValidationError("assignees", fmt.Sprintf("%s must be the owner or a user the task is shared with", assignee), entity.ErrInvalidAssignee)
+		}
+	}
+
+	return nil
+}
+
+// allowedAssignees возвращает пользователей с доступом к задаче:
+// владельца и принявших приглашение к задаче или её проекту.
+func (t taskUsecase) allowedAssignees(ctx context.Context, task entity.Task) (map[string]bool, error) {
+	allowed := map[string]bool{task.OwnerID: true}
+
+	filters := []entity.ShareFilter{{ResourceType: entity.ResourceTask, ResourceID: task.ID, Status: entity.ShareAccepted}}
+	if task.ProjectID != "" {
+		filters = append(filters, entity.ShareFilter{ResourceType: entity.ResourceProject, ResourceID: task.ProjectID, Status: entity.ShareAccepted})
+	}
+
+	for _, filter := range filters {
+		shares, err := t.shares.List(ctx, filter)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list shares: %w", err)
+		}
+
+		for _, share := range shares {
+			allowed[share.UserID] = true
+		}
+	}
+
+	return allowed, nil
+}
+
+// normalizeAssignees заменяет "me" на id текущего пользователя, убирает повторы и сортирует исполнителей
+func normalizeAssignees(assignees []string, callerID string) ([]string, error) {
+	normalized := make([]string, 0, len(assignees))
+
+	for _, assignee := range assignees {
+		if assignee == entity.AssigneeMe {
+			assignee = callerID
+		}
+
+		if assignee == "" {
+			return nil, entity.NewValidationError("assignees", "must not contain empty ids", entity.ErrInvalidAssignee)
+		}
+
+		normalized = append(normalized, assignee)
+	}
+
+	slices.Sort(normalized)
+	normalized = slices.Compact(normalized)
+
+	if len(normalized) > maxAssignees {
+		return nil, entity.NewValidationError("assignees", fmt.Sprintf("must not exceed %d users", maxAssignees), entity.ErrInvalidAssignee)
+	}
+
+	if len(normalized) == 0 {
+		return nil, nil
+	}
+
+	return normalized, nil
+}
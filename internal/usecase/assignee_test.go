@@ -0,0 +1,153 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/skantay/todo-list/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Assign(t *testing.T) {
+	task := entity.Task{ID: sharedTaskID, OwnerID: ownerID, ProjectID: projectID}
+
+	tooMany := make([]string, maxAssignees+1)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf("user-%d", i)
+	}
+
+	tests := []struct {
+		name          string
+		assignees     []string
+		current       []string
+		taskShares    []entity.Share
+		wantAssignees []string
+		wantUnchanged bool
+		wantErr       error
+	}{
+		{
+			name:          "#1 me",
+			assignees:     []string{entity.AssigneeMe},
+			wantAssignees: []string{ownerID},
+		},
+		{
+			name:          "#2 shared user twice",
+			assignees:     []string{userID, entity.AssigneeMe, userID},
+			taskShares:    []entity.Share{{UserID: userID}},
+			wantAssignees: []string{userID, ownerID},
+		},
+		{
+			name:      "#3 user without access",
+			assignees: []string{userID},
+			wantErr:   entity.ErrInvalidAssignee,
+		},
+		{
+			name:          "#4 same assignees",
+			assignees:     []string{entity.AssigneeMe},
+			current:       []string{ownerID},
+			wantUnchanged: true,
+		},
+		{
+			name:          "#5 unassign everyone",
+			current:       []string{ownerID},
+			wantAssignees: nil,
+		},
+		{
+			name:      "#6 empty id",
+			assignees: []string{""},
+			wantErr:   entity.ErrInvalidAssignee,
+		},
+		{
+			name:      "#7 too many",
+			assignees: tooMany,
+			wantErr:   entity.ErrInvalidAssignee,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			taskRepo := NewMocktaskRepo(ctrl)
+			shareRepo := NewMockshareRepo(ctrl)
+
+			ctx := entity.WithUserID(context.Background(), ownerID)
+
+			current := task
+			current.Assignees = tt.current
+
+			taskRepo.EXPECT().Get(gomock.Any(), sharedTaskID).Return(current, nil).AnyTimes()
+			shareRepo.EXPECT().List(gomock.Any(), entity.ShareFilter{UserID: ownerID, Status: entity.ShareAccepted}).Return(nil, nil).AnyTimes()
+			shareRepo.EXPECT().List(gomock.Any(), entity.ShareFilter{ResourceType: entity.ResourceTask, ResourceID: sharedTaskID, Status: entity.ShareAccepted}).Return(tt.taskShares, nil).AnyTimes()
+			shareRepo.EXPECT().List(gomock.Any(), entity.ShareFilter{ResourceType: entity.ResourceProject, ResourceID: projectID, Status: entity.ShareAccepted}).Return(nil, nil).AnyTimes()
+
+			if tt.wantErr == nil && !tt.wantUnchanged {
+				taskRepo.EXPECT().SetAssignees(gomock.Any(), sharedTaskID, gomock.Any()).DoAndReturn(func(_ context.Context, _ string, assignment entity.Assignment) error {
+					assert.Equal(t, tt.wantAssignees, assignment.Assignees)
+					assert.Equal(t, ownerID, assignment.AssignedBy)
+					assert.False(t, assignment.AssignedAt.IsZero())
+
+					return nil
+				})
+			}
+
+			err := newTaskUsecase(taskRepo, nil, shareRepo, nil).Assign(ctx, sharedTaskID, tt.assignees)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), "unexpected error: %v", err)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func Test_AssignedEditor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	taskRepo := NewMocktaskRepo(ctrl)
+	shareRepo := NewMockshareRepo(ctrl)
+
+	// Исполнитель с ролью viewer может менять задачу, но не удалять её
+	taskRepo.EXPECT().Get(gomock.Any(), sharedTaskID).DoAndReturn(func(ctx context.Context, id string) (entity.Task, error) {
+		if entity.UserIDFromContext(ctx) != ownerID {
+			return entity.Task{}, entity.ErrTaskNotFound
+		}
+
+		return entity.Task{ID: id, OwnerID: ownerID, Assignees: []string{userID}}, nil
+	}).AnyTimes()
+	shareRepo.EXPECT().List(gomock.Any(), entity.ShareFilter{UserID: userID, Status: entity.ShareAccepted}).
+		Return([]entity.Share{{ResourceType: entity.ResourceTask, ResourceID: sharedTaskID, OwnerID: ownerID, UserID: userID, Role: entity.RoleViewer, Status: entity.ShareAccepted}}, nil).
+		AnyTimes()
+
+	ctx := entity.WithUserID(context.Background(), userID)
+
+	ownerCtx, err := taskAccess(ctx, taskRepo, shareRepo, sharedTaskID, entity.RoleEditor)
+	require.NoError(t, err)
+	assert.Equal(t, ownerID, entity.UserIDFromContext(ownerCtx))
+
+	_, err = taskAccess(ctx, taskRepo, shareRepo, sharedTaskID, entity.RoleOwner)
+	assert.ErrorIs(t, err, entity.ErrForbidden)
+}
+
+func Test_StrangerAssign(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	taskRepo := NewMocktaskRepo(ctrl)
+	shareRepo := NewMockshareRepo(ctrl)
+
+	// Приглашения к чужой задаче repository вернул бы, но посторонний не должен до них дойти
+	ownedTasks(taskRepo)
+	shareRepo.EXPECT().List(gomock.Any(), entity.ShareFilter{UserID: strangerID, Status: entity.ShareAccepted}).Return(nil, nil).AnyTimes()
+
+	ctx := entity.WithUserID(context.Background(), strangerID)
+	usecase := newTaskUsecase(taskRepo, nil, shareRepo, nil)
+
+	err := usecase.Assign(ctx, sharedTaskID, []string{entity.AssigneeMe})
+	assert.ErrorIs(t, err, entity.ErrTaskNotFound)
+
+	_, err = usecase.Assignments(ctx, sharedTaskID)
+	assert.ErrorIs(t, err, entity.ErrTaskNotFound)
+}
@@ -0,0 +1,200 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/skantay/todo-list/internal/entity"
+)
+
+// Batch выполняет операции пакета одной пакетной записью в хранилище и возвращает результат каждой операции по порядку.
+// Операции проверяются так же, как одиночные запросы, и с теми же правами; не прошедшая проверку операция
+// не записывается и получает свою ошибку. Без atomic остальные операции выполняются,
+// с atomic при ошибке любой операции не выполняется ни одна, остальные получают entity.ErrBatchAborted.
+// Задача с открытыми пунктами чек-листа или подзадачами в пакете не выполняется даже в каскадном режиме,
+// потому что каскад меняет задачи вне пакета. Следующие повторения создаются после записи пакета.
+func (t taskUsecase) Batch(ctx context.Context, operations []entity.BatchOperation, atomic bool) ([]entity.BatchResult, error) {
+	if len(operations) == 0 {
+		return nil, entity.NewValidationError("operations", "must not be empty", entity.ErrInvalidBatch)
+	}
+
+	if len(operations) > t.maxBatch {
+		return nil, entity.NewValidationError("operations", fmt.Sprintf("must not contain more than %d operations", t.maxBatch), entity.ErrBatchTooLarge)
+	}
+
+	results := make([]entity.BatchResult, len(operations))
+	writes := make([]entity.TaskWrite, 0, len(operations))
+	// indexes[i] - номер операции, из которой собрана запись writes[i]
+	indexes := make([]int, 0, len(operations))
+	changed := make(map[string]bool, len(operations))
+
+	for i, operation := range operations {
+		results[i].ID = operation.ID
+
+		// Пакетная запись не гарантирует порядок, поэтому задачу меняет только одна операция пакета
+		if operation.ID != "" {
+			if changed[operation.ID] {
+				results[i].Err = entity.NewValidationError("id", "is already changed by another operation of the batch", entity.ErrInvalidBatch)
+
+				continue
+			}
+
+			changed[operation.ID] = true
+		}
+
+		write, ok, err := t.prepareWrite(ctx, operation)
+		if err != nil {
+			results[i].Err = err
+
+			continue
+		}
+
+		// Нечего записывать, например задача уже выполнена
+		if !ok {
+			continue
+		}
+
+		writes = append(writes, write)
+		indexes = append(indexes, i)
+	}
+
+	if atomic && batchFailed(results) {
+		for i := range results {
+			if results[i].Err == nil {
+				results[i].Err = entity.ErrBatchAborted
+			}
+		}
+
+		return results, nil
+	}
+
+	if len(writes) == 0 {
+		return results, nil
+	}
+
+	written, err := t.repo.BulkWrite(ctx, writes, atomic)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write batch: %w", err)
+	}
+
+	for j, result := range written {
+		i := indexes[j]
+		results[i] = result
+
+		if result.Err == nil {
+			results[i].Err = t.afterWrite(ctx, writes[j])
+		}
+	}
+
+	return results, nil
+}
+
+// prepareWrite проверяет операцию пакета и собирает запись для хранилища.
+// false означает, что операция выполнена без записи.
+func (t taskUsecase) prepareWrite(ctx context.Context, operation entity.BatchOperation) (entity.TaskWrite, bool, error) {
+	write := entity.TaskWrite{
+		Op:      operation.Op,
+		ID:      operation.ID,
+		Version: operation.Version,
+	}
+
+	switch operation.Op {
+	case entity.BatchCreate:
+		task, err := t.prepareCreate(ctx, operation.Task)
+		write.Task = task
+
+		return write, true, err
+	case entity.BatchUpdate:
+		task := operation.Task
+		task.ID = operation.ID
+
+		ownerCtx, task, err := t.prepareUpdate(ctx, task)
+		write.OwnerID = entity.UserIDFromContext(ownerCtx)
+		write.Task = task
+
+		return write, true, err
+	case entity.BatchDone:
+		return t.prepareDone(ctx, write)
+	case entity.BatchDelete:
+		ownerCtx, err := taskAccess(ctx, t.repo, t.shares, operation.ID, entity.RoleOwner)
+		write.OwnerID = entity.UserIDFromContext(ownerCtx)
+
+		return write, true, err
+	default:
+		return write, false, entity.NewValidationError("op", "must be create, update, done or delete", entity.ErrInvalidBatch)
+	}
+}
+
+// prepareDone проверяет выполнение задачи так же, как ChangeStatus, но без каскада.
+func (t taskUsecase) prepareDone(ctx context.Context, write entity.TaskWrite) (entity.TaskWrite, bool, error) {
+	ctx, err := taskAccess(ctx, t.repo, t.shares, write.ID, entity.RoleEditor)
+	if err != nil {
+		return write, false, err
+	}
+	write.OwnerID = entity.UserIDFromContext(ctx)
+
+	task, err := t.repo.Get(ctx, write.ID)
+	if err != nil {
+		return write, false, fmt.Errorf("failed to get task: %w", err)
+	}
+
+	if write.Version != entity.AnyVersion && task.Version != write.Version {
+		return write, false, entity.ErrVersionMismatch
+	}
+
+	// Повторное выполнение ничего не меняет
+	if task.Status == entity.Done {
+		return write, false, nil
+	}
+
+	if err := t.checkTransition(ctx, task, entity.Done); err != nil {
+		return write, false, err
+	}
+
+	open, err := t.openSubtasks(ctx, task)
+	if err != nil {
+		return write, false, err
+	}
+
+	if items := task.OpenChecklistItems(); items > 0 || len(open) > 0 {
+		return write, false, openSubtasksError(items, len(open))
+	}
+
+	task.SetStatus(entity.Done, time.Now())
+	write.Task = task
+
+	return write, true, nil
+}
+
+// afterWrite завершает записанную операцию: удаляет приглашения к удалённой задаче
+// и создаёт следующее повторение выполненной, как одиночные запросы.
+func (t taskUsecase) afterWrite(ctx context.Context, write entity.TaskWrite) error {
+	if write.OwnerID != "" {
+		ctx = entity.WithUserID(ctx, write.OwnerID)
+	}
+
+	switch write.Op {
+	case entity.BatchDelete:
+		if err := t.shares.DeleteByResource(ctx, entity.ResourceTask, write.ID); err != nil {
+			return fmt.Errorf("failed to delete task shares: %w", err)
+		}
+	case entity.BatchDone:
+		if _, err := t.createNextOccurrence(ctx, write.Task); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// batchFailed сообщает, что хотя бы одна операция пакета не выполнится.
+func batchFailed(results []entity.BatchResult) bool {
+	for _, result := range results {
+		if result.Err != nil {
+			return true
+		}
+	}
+
+	return false
+}
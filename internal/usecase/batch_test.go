@@ -0,0 +1,149 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/skantay/todo-list/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Batch(t *testing.T) {
+	activeAt := entity.DateOf(time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC))
+	valid := entity.NewTask("title", activeAt)
+	invalid := entity.NewTask(" ", activeAt)
+
+	tests := []struct {
+		name       string
+		operations []entity.BatchOperation
+		atomic     bool
+		setup      func(tasks *MocktaskRepo, shares *MockshareRepo)
+		wantErrs   []error
+		wantErr    error
+	}{
+		{
+			name:    "#1 empty",
+			wantErr: entity.ErrInvalidBatch,
+		},
+		{
+			name: "#2 too large",
+			operations: []entity.BatchOperation{
+				{Op: entity.BatchDelete, ID: "1"}, {Op: entity.BatchDelete, ID: "2"},
+				{Op: entity.BatchDelete, ID: "3"}, {Op: entity.BatchDelete, ID: "4"},
+			},
+			wantErr: entity.ErrBatchTooLarge,
+		},
+		{
+			name: "#3 failed operations are not written",
+			operations: []entity.BatchOperation{
+				{Op: entity.BatchCreate, Task: valid},
+				{Op: entity.BatchUpdate, ID: "2", Task: invalid},
+			},
+			setup: func(tasks *MocktaskRepo, _ *MockshareRepo) {
+				tasks.EXPECT().BulkWrite(gomock.Any(), gomock.Any(), false).DoAndReturn(func(_ context.Context, writes []entity.TaskWrite, _ bool) ([]entity.BatchResult, error) {
+					require.Len(t, writes, 1)
+					assert.Equal(t, entity.BatchCreate, writes[0].Op)
+					assert.Equal(t, entity.PriorityNormal, writes[0].Task.Priority)
+
+					return []entity.BatchResult{{ID: "new"}}, nil
+				})
+			},
+			wantErrs: []error{nil, entity.ErrInvalidTitle},
+		},
+		{
+			name:   "#4 atomic batch with failed operation",
+			atomic: true,
+			operations: []entity.BatchOperation{
+				{Op: entity.BatchCreate, Task: valid},
+				{Op: entity.BatchUpdate, ID: "2", Task: invalid},
+			},
+			wantErrs: []error{entity.ErrBatchAborted, entity.ErrInvalidTitle},
+		},
+		{
+			name: "#5 same task twice",
+			operations: []entity.BatchOperation{
+				{Op: entity.BatchDelete, ID: "3"},
+				{Op: entity.BatchDone, ID: "3"},
+			},
+			setup: func(tasks *MocktaskRepo, shares *MockshareRepo) {
+				tasks.EXPECT().BulkWrite(gomock.Any(), []entity.TaskWrite{{Op: entity.BatchDelete, ID: "3"}}, false).
+					Return([]entity.BatchResult{{ID: "3"}}, nil)
+				shares.EXPECT().DeleteByResource(gomock.Any(), entity.ResourceTask, "3").Return(nil)
+			},
+			wantErrs: []error{nil, entity.ErrInvalidBatch},
+		},
+		{
+			name: "#6 done",
+			operations: []entity.BatchOperation{
+				{Op: entity.BatchDone, ID: "4", Version: 2},
+				{Op: entity.BatchDone, ID: "5"},
+				{Op: entity.BatchDone, ID: "6"},
+			},
+			setup: func(tasks *MocktaskRepo, _ *MockshareRepo) {
+				recurring := entity.Task{ID: "4", Title: "daily", ActiveAt: activeAt, Status: entity.Active, Recurrence: "FREQ=DAILY", Occurrence: 1, Version: 2}
+				tasks.EXPECT().Get(gomock.Any(), "4").Return(recurring, nil)
+				tasks.EXPECT().Get(gomock.Any(), "5").Return(entity.Task{ID: "5", Status: entity.Done}, nil)
+				tasks.EXPECT().Get(gomock.Any(), "6").Return(entity.Task{ID: "6", Status: entity.Active, Checklist: []entity.ChecklistItem{{ID: "a"}}}, nil)
+				tasks.EXPECT().Children(gomock.Any(), gomock.Any()).Return(nil, nil).Times(2)
+
+				tasks.EXPECT().BulkWrite(gomock.Any(), gomock.Any(), false).DoAndReturn(func(_ context.Context, writes []entity.TaskWrite, _ bool) ([]entity.BatchResult, error) {
+					require.Len(t, writes, 1)
+					assert.Equal(t, int64(2), writes[0].Version)
+					assert.Equal(t, entity.Done, writes[0].Task.Status)
+					assert.NotNil(t, writes[0].Task.CompletedAt)
+
+					return []entity.BatchResult{{ID: "4"}}, nil
+				})
+
+				// Следующее повторение создаётся после записи пакета
+				tasks.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, next entity.Task) (string, error) {
+					assert.Equal(t, 2, next.Occurrence)
+
+					return "7", nil
+				})
+			},
+			wantErrs: []error{nil, nil, entity.ErrOpenSubtasks},
+		},
+		{
+			name:       "#7 unknown operation",
+			operations: []entity.BatchOperation{{Op: "archive", ID: "8"}},
+			wantErrs:   []error{entity.ErrInvalidBatch},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			taskRepo := NewMocktaskRepo(ctrl)
+			shareRepo := NewMockshareRepo(ctrl)
+
+			if tt.setup != nil {
+				tt.setup(taskRepo, shareRepo)
+			}
+
+			taskUsecase := newTaskUsecase(taskRepo, nil, shareRepo, nil)
+			taskUsecase.maxBatch = 3
+
+			results, err := taskUsecase.Batch(context.Background(), tt.operations, tt.atomic)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, results, len(tt.wantErrs))
+
+			for i, wantErr := range tt.wantErrs {
+				if wantErr != nil {
+					assert.ErrorIs(t, results[i].Err, wantErr, "operation %d", i)
+				} else {
+					assert.NoError(t, results[i].Err, "operation %d", i)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/skantay/todo-list/internal/entity"
+)
+
+// maxChecklistItems - максимальное количество пунктов в чек-листе задачи
+const maxChecklistItems = 100
+
+// AddChecklistItem добавляет пункт в конец чек-листа задачи
+func (t taskUsecase) AddChecklistItem(ctx context.Context, taskID, title string) (entity.ChecklistItem, error) {
+	title = strings.TrimSpace(title)
+
+	if err := validateChecklistTitle(title); err != nil {
+		return entity.ChecklistItem{}, err
+	}
+
+	task, err := t.repo.Get(ctx, taskID)
+	if err != nil {
+		return entity.ChecklistItem{}, fmt.Errorf("failed to get task: %w", err)
+	}
+
+	if len(task.Checklist) >= maxChecklistItems {
+		return entity.ChecklistItem{}, entity.NewValidationError("checklist", fmt.Sprintf("must not contain more than %d items", maxChecklistItems), entity.ErrInvalidChecklist)
+	}
+
+	item := entity.NewChecklistItem(title)
+
+	if err := t.repo.SetChecklist(ctx, taskID, append(task.Checklist, item)); err != nil {
+		return entity.ChecklistItem{}, fmt.Errorf("failed to update checklist: %w", err)
+	}
+
+	return item, nil
+}
+
+// ToggleChecklistItem меняет отметку о выполнении пункта чек-листа на противоположную
+func (t taskUsecase) ToggleChecklistItem(ctx context.Context, taskID, itemID string) (entity.ChecklistItem, error) {
+	task, err := t.repo.Get(ctx, taskID)
+	if err != nil {
+		return entity.ChecklistItem{}, fmt.Errorf("failed to get task: %w", err)
+	}
+
+	i := task.ChecklistIndex(itemID)
+	if i < 0 {
+		return entity.ChecklistItem{}, entity.ErrChecklistItemNotFound
+	}
+
+	task.Checklist[i].Done = !task.Checklist[i].Done
+
+	if err := t.repo.SetChecklist(ctx, taskID, task.Checklist); err != nil {
+		return entity.ChecklistItem{}, fmt.Errorf("failed to update checklist: %w", err)
+	}
+
+	return task.Checklist[i], nil
+}
+
+// ReorderChecklist расставляет пункты чек-листа в порядке itemIDs.
+// itemIDs должен содержать каждый пункт ровно один раз.
+func (t taskUsecase) ReorderChecklist(ctx context.Context, taskID string, itemIDs []string) ([]entity.ChecklistItem, error) {
+	task, err := t.repo.Get(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task: %w", err)
+	}
+
+	if len(itemIDs) != len(task.Checklist) {
+		return nil, entity.NewValidationError("items", "must list every checklist item exactly once", entity.ErrInvalidChecklist)
+	}
+
+	checklist := make([]entity.ChecklistItem, 0, len(itemIDs))
+
+	for _, id := range itemIDs {
+		i := task.ChecklistIndex(id)
+		if i < 0 || slices.ContainsFunc(checklist, func(item entity.ChecklistItem) bool { return item.ID == id }) {
+			return nil, entity.NewValidationError("items", "must list every checklist item exactly once", entity.ErrInvalidChecklist)
+		}
+
+		checklist = append(checklist, task.Checklist[i])
+	}
+
+	if err := t.repo.SetChecklist(ctx, taskID, checklist); err != nil {
+		return nil, fmt.Errorf("failed to update checklist: %w", err)
+	}
+
+	return checklist, nil
+}
+
+// DeleteChecklistItem удаляет пункт из чек-листа задачи
+func (t taskUsecase) DeleteChecklistItem(ctx context.Context, taskID, itemID string) error {
+	task, err := t.repo.Get(ctx, taskID)
+	if err != nil {
+		return fmt.Errorf("failed to get task: %w", err)
+	}
+
+	i := task.ChecklistIndex(itemID)
+	if i < 0 {
+		return entity.ErrChecklistItemNotFound
+	}
+
+	if err := t.repo.SetChecklist(ctx, taskID, slices.Delete(task.Checklist, i, i+1)); err != nil {
+		return fmt.Errorf("failed to update checklist: %w", err)
+	}
+
+	return nil
+}
+
+// validateChecklistTitle проверяет, что заголовок пункта не пустой и не превышает maxTitleLen символов
+func validateChecklistTitle(title string) error {
+	if title == "" {
+		return entity.NewValidationError("title", "must not be empty", entity.ErrInvalidChecklist)
+	}
+
+	if utf8.RuneCountInString(title) > maxTitleLen {
+		return entity.NewValidationError("title", fmt.Sprintf("must not exceed %d characters", maxTitleLen), entity.ErrInvalidChecklist)
+	}
+
+	return nil
+}
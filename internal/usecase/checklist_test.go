@@ -0,0 +1,194 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/skantay/todo-list/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func checklistTask() entity.Task {
+	task := entity.NewTask("release", entity.DateOf(time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)))
+	task.ID = "661fbb485131cd932a981b26"
+	task.Checklist = []entity.ChecklistItem{
+		{ID: "a", Title: "changelog", Done: true},
+		{ID: "b", Title: "tag"},
+		{ID: "c", Title: "announce"},
+	}
+
+	return task
+}
+
+func Test_AddChecklistItem(t *testing.T) {
+	full := checklistTask()
+	for len(full.Checklist) < maxChecklistItems {
+		full.Checklist = append(full.Checklist, entity.ChecklistItem{ID: "x"})
+	}
+
+	tests := []struct {
+		name    string
+		title   string
+		setup   func(m *MocktaskRepo)
+		wantErr error
+	}{
+		{
+			name:  "#1 valid",
+			title: "  publish  ",
+			setup: func(m *MocktaskRepo) {
+				m.EXPECT().Get(gomock.Any(), gomock.Any()).Return(checklistTask(), nil)
+				m.EXPECT().SetChecklist(gomock.Any(), gomock.Any(), gomock.Len(4)).Return(nil)
+			},
+		},
+		{
+			name:    "#2 empty title",
+			title:   "   ",
+			wantErr: entity.ErrInvalidChecklist,
+		},
+		{
+			name:    "#3 long title",
+			title:   strings.Repeat("a", maxTitleLen+1),
+			wantErr: entity.ErrInvalidChecklist,
+		},
+		{
+			name:  "#4 too many items",
+			title: "publish",
+			setup: func(m *MocktaskRepo) {
+				m.EXPECT().Get(gomock.Any(), gomock.Any()).Return(full, nil)
+			},
+			wantErr: entity.ErrInvalidChecklist,
+		},
+		{
+			name:  "#5 task not found",
+			title: "publish",
+			setup: func(m *MocktaskRepo) {
+				m.EXPECT().Get(gomock.Any(), gomock.Any()).Return(entity.Task{}, entity.ErrTaskNotFound)
+			},
+			wantErr: entity.ErrTaskNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			taskRepo := NewMocktaskRepo(ctrl)
+
+			if tt.setup != nil {
+				tt.setup(taskRepo)
+			}
+
+			item, err := newTaskUsecase(taskRepo, nil, nil, nil).AddChecklistItem(context.Background(), "661fbb485131cd932a981b26", tt.title)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), "unexpected error: %v", err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.NotEmpty(t, item.ID)
+			assert.Equal(t, "publish", item.Title)
+			assert.False(t, item.Done)
+		})
+	}
+}
+
+func Test_ToggleChecklistItem(t *testing.T) {
+	tests := []struct {
+		name     string
+		itemID   string
+		setup    func(m *MocktaskRepo)
+		wantDone bool
+		wantErr  error
+	}{
+		{
+			name:   "#1 check",
+			itemID: "b",
+			setup: func(m *MocktaskRepo) {
+				m.EXPECT().Get(gomock.Any(), gomock.Any()).Return(checklistTask(), nil)
+				m.EXPECT().SetChecklist(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+			wantDone: true,
+		},
+		{
+			name:   "#2 uncheck",
+			itemID: "a",
+			setup: func(m *MocktaskRepo) {
+				m.EXPECT().Get(gomock.Any(), gomock.Any()).Return(checklistTask(), nil)
+				m.EXPECT().SetChecklist(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+			wantDone: false,
+		},
+		{
+			name:   "#3 item not found",
+			itemID: "z",
+			setup: func(m *MocktaskRepo) {
+				m.EXPECT().Get(gomock.Any(), gomock.Any()).Return(checklistTask(), nil)
+			},
+			wantErr: entity.ErrChecklistItemNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			taskRepo := NewMocktaskRepo(ctrl)
+
+			tt.setup(taskRepo)
+
+			item, err := newTaskUsecase(taskRepo, nil, nil, nil).ToggleChecklistItem(context.Background(), "661fbb485131cd932a981b26", tt.itemID)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), "unexpected error: %v", err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.itemID, item.ID)
+			assert.Equal(t, tt.wantDone, item.Done)
+		})
+	}
+}
+
+func Test_ReorderChecklist(t *testing.T) {
+	tests := []struct {
+		name    string
+		itemIDs []string
+		wantErr error
+	}{
+		{name: "#1 valid", itemIDs: []string{"c", "a", "b"}},
+		{name: "#2 missing item", itemIDs: []string{"c", "a"}, wantErr: entity.ErrInvalidChecklist},
+		{name: "#3 repeated item", itemIDs: []string{"c", "a", "a"}, wantErr: entity.ErrInvalidChecklist},
+		{name: "#4 unknown item", itemIDs: []string{"c", "a", "z"}, wantErr: entity.ErrInvalidChecklist},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			taskRepo := NewMocktaskRepo(ctrl)
+
+			taskRepo.EXPECT().Get(gomock.Any(), gomock.Any()).Return(checklistTask(), nil)
+			if tt.wantErr == nil {
+				taskRepo.EXPECT().SetChecklist(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			}
+
+			checklist, err := newTaskUsecase(taskRepo, nil, nil, nil).ReorderChecklist(context.Background(), "661fbb485131cd932a981b26", tt.itemIDs)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), "unexpected error: %v", err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+
+			ids := make([]string, 0, len(checklist))
+			for _, item := range checklist {
+				ids = append(ids, item.ID)
+			}
+			assert.Equal(t, tt.itemIDs, ids)
+		})
+	}
+}
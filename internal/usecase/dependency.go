@@ -0,0 +1,207 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/skantay/todo-list/internal/entity"
+)
+
+// maxBlockers - максимальное количество блокирующих задач у одной задачи
+const maxBlockers = 50
+
+// AddBlocker отмечает, что задача blockerID блокирует задачу id.
+// Повторное добавление ничего не меняет, зависимость, замыкающая цикл, отклоняется.
+func (t taskUsecase) AddBlocker(ctx context.Context, id, blockerID string) error {
+	if id == blockerID {
+		return entity.NewValidationError("blockerId", "task must not block itself", entity.ErrDependencyCycle)
+	}
+
+	task, err := t.repo.Get(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get task: %w", err)
+	}
+
+	if slices.Contains(task.BlockedBy, blockerID) {
+		return nil
+	}
+
+	if len(task.BlockedBy) >= maxBlockers {
+		return entity.NewValidationError("blockerId", fmt.Sprintf("task must not have more than %d blockers", maxBlockers), entity.ErrInvalidBlocker)
+	}
+
+	if _, err := t.repo.Get(ctx, blockerID); err != nil {
+		if errors.Is(err, entity.ErrTaskNotFound) || errors.Is(err, entity.ErrInvalidID) {
+			return entity.NewValidationError("blockerId", "must be an existing task", entity.ErrInvalidBlocker)
+		}
+
+		return fmt.Errorf("failed to get blocker: %w", err)
+	}
+
+	if err := t.checkCycle(ctx, id, blockerID); err != nil {
+		return err
+	}
+
+	if err := t.repo.SetBlockers(ctx, id, append(task.BlockedBy, blockerID)); err != nil {
+		return fmt.Errorf("failed to update blockers: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveBlocker снимает блокировку задачи id задачей blockerID.
+// Если такой блокировки нет, ничего не меняется.
+func (t taskUsecase) RemoveBlocker(ctx context.Context, id, blockerID string) error {
+	task, err := t.repo.Get(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get task: %w", err)
+	}
+
+	i := slices.Index(task.BlockedBy, blockerID)
+	if i < 0 {
+		return nil
+	}
+
+	if err := t.repo.SetBlockers(ctx, id, slices.Delete(task.BlockedBy, i, i+1)); err != nil {
+		return fmt.Errorf("failed to update blockers: %w", err)
+	}
+
+	return nil
+}
+
+// Next возвращает до limit активных наступивших задач, у которых не осталось
+// незавершённых блокирующих задач, в порядке activeAt.
+func (t taskUsecase) Next(ctx context.Context, limit int) ([]entity.Task, error) {
+	if limit == 0 {
+		limit = defaultLimit
+	}
+
+	if limit < 0 || limit > maxLimit {
+		return nil, entity.NewValidationError("limit", fmt.Sprintf("must be between 1 and %d", maxLimit), entity.ErrInvalidLimit)
+	}
+
+	filter := entity.TaskFilter{
+		Status:   entity.Active,
+		Now:      time.Now(),
+		Location: entity.LocationFromContext(ctx),
+	}
+
+	page := entity.PageRequest{
+		Limit:  maxLimit,
+		SortBy: entity.SortByActiveAt,
+		Order:  entity.OrderAsc,
+	}
+
+	var ready []entity.Task
+
+	// Задачи читаются страницами, пока не наберётся limit готовых
+	for len(ready) < limit {
+		result, err := t.repo.List(ctx, filter, page)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get tasks: %w", err)
+		}
+
+		blockers, err := t.blockers(ctx, result.Tasks)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, task := range result.Tasks {
+			if len(ready) < limit && len(task.OpenBlockers(blockers)) == 0 {
+				ready = append(ready, task)
+			}
+		}
+
+		if result.Next == nil {
+			break
+		}
+		page.Cursor = result.Next
+	}
+
+	if err := t.withProgress(ctx, ready); err != nil {
+		return nil, err
+	}
+
+	prefix := entity.TenantFromContext(ctx).WeekendPrefix
+	for i := range ready {
+		present(&ready[i], filter.Now, filter.Location, prefix)
+	}
+
+	return ready, nil
+}
+
+// checkBlockers отклоняет выполнение задачи, пока не завершены блокирующие её задачи
+func (t taskUsecase) checkBlockers(ctx context.Context, task entity.Task) error {
+	if len(task.BlockedBy) == 0 {
+		return nil
+	}
+
+	blockers, err := t.blockers(ctx, []entity.Task{task})
+	if err != nil {
+		return err
+	}
+
+	if open := task.OpenBlockers(blockers); len(open) > 0 {
+		return entity.NewValidationError("status", fmt.Sprintf("blocked by %d open tasks", len(open)), entity.ErrBlocked)
+	}
+
+	return nil
+}
+
+// blockers возвращает блокирующие задачи tasks по id
+func (t taskUsecase) blockers(ctx context.Context, tasks []entity.Task) (map[string]entity.Task, error) {
+	var ids []string
+	for _, task := range tasks {
+		ids = append(ids, task.BlockedBy...)
+	}
+
+	result := make(map[string]entity.Task)
+	if len(ids) == 0 {
+		return result, nil
+	}
+
+	blockers, err := t.repo.GetMany(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blockers: %w", err)
+	}
+
+	for _, blocker := range blockers {
+		result[blocker.ID] = blocker
+	}
+
+	return result, nil
+}
+
+// checkCycle проверяет, что задача blockerID не зависит от задачи id ни напрямую, ни через другие задачи.
+// Граф обходится в ширину, каждый уровень читается одним запросом.
+func (t taskUsecase) checkCycle(ctx context.Context, id, blockerID string) error {
+	visited := map[string]bool{blockerID: true}
+	frontier := []string{blockerID}
+
+	for len(frontier) > 0 {
+		tasks, err := t.repo.GetMany(ctx, frontier)
+		if err != nil {
+			return fmt.Errorf("failed to get blockers: %w", err)
+		}
+
+		frontier = nil
+
+		for _, task := range tasks {
+			for _, next := range task.BlockedBy {
+				if next == id {
+					return entity.NewValidationError("blockerId", "would create a dependency cycle", entity.ErrDependencyCycle)
+				}
+
+				if !visited[next] {
+					visited[next] = true
+					frontier = append(frontier, next)
+				}
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,148 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/skantay/todo-list/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_AddBlocker(t *testing.T) {
+	tests := []struct {
+		name      string
+		id        string
+		blockerID string
+		setup     func(m *MocktaskRepo)
+		wantErr   error
+	}{
+		{
+			name:      "#1 valid",
+			id:        "b",
+			blockerID: "a",
+			setup: func(m *MocktaskRepo) {
+				m.EXPECT().Get(gomock.Any(), "b").Return(entity.Task{ID: "b", BlockedBy: []string{"c"}}, nil)
+				m.EXPECT().Get(gomock.Any(), "a").Return(entity.Task{ID: "a"}, nil)
+				m.EXPECT().GetMany(gomock.Any(), []string{"a"}).Return([]entity.Task{{ID: "a"}}, nil)
+				m.EXPECT().SetBlockers(gomock.Any(), "b", []string{"c", "a"}).Return(nil)
+			},
+		},
+		{
+			name:      "#2 blocks itself",
+			id:        "a",
+			blockerID: "a",
+			wantErr:   entity.ErrDependencyCycle,
+		},
+		{
+			name:      "#3 indirect cycle",
+			id:        "a",
+			blockerID: "c",
+			setup: func(m *MocktaskRepo) {
+				// a блокирует b, b блокирует c: c не может блокировать a
+				m.EXPECT().Get(gomock.Any(), "a").Return(entity.Task{ID: "a"}, nil)
+				m.EXPECT().Get(gomock.Any(), "c").Return(entity.Task{ID: "c", BlockedBy: []string{"b"}}, nil)
+				m.EXPECT().GetMany(gomock.Any(), []string{"c"}).Return([]entity.Task{{ID: "c", BlockedBy: []string{"b"}}}, nil)
+				m.EXPECT().GetMany(gomock.Any(), []string{"b"}).Return([]entity.Task{{ID: "b", BlockedBy: []string{"a"}}}, nil)
+			},
+			wantErr: entity.ErrDependencyCycle,
+		},
+		{
+			name:      "#4 already blocked",
+			id:        "b",
+			blockerID: "a",
+			setup: func(m *MocktaskRepo) {
+				m.EXPECT().Get(gomock.Any(), "b").Return(entity.Task{ID: "b", BlockedBy: []string{"a"}}, nil)
+			},
+		},
+		{
+			name:      "#5 blocker not found",
+			id:        "b",
+			blockerID: "a",
+			setup: func(m *MocktaskRepo) {
+				m.EXPECT().Get(gomock.Any(), "b").Return(entity.Task{ID: "b"}, nil)
+				m.EXPECT().Get(gomock.Any(), "a").Return(entity.Task{}, entity.ErrTaskNotFound)
+			},
+			wantErr: entity.ErrInvalidBlocker,
+		},
+		{
+			name:      "#6 task not found",
+			id:        "b",
+			blockerID: "a",
+			setup: func(m *MocktaskRepo) {
+				m.EXPECT().Get(gomock.Any(), "b").Return(entity.Task{}, entity.ErrTaskNotFound)
+			},
+			wantErr: entity.ErrTaskNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			taskRepo := NewMocktaskRepo(ctrl)
+
+			if tt.setup != nil {
+				tt.setup(taskRepo)
+			}
+
+			err := newTaskUsecase(taskRepo, nil, nil, nil).AddBlocker(context.Background(), tt.id, tt.blockerID)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), "unexpected error: %v", err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func Test_Next(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	taskRepo := NewMocktaskRepo(ctrl)
+
+	cursor := &entity.Cursor{SortBy: entity.SortByActiveAt, Order: entity.OrderAsc, ID: "3"}
+
+	firstPage := entity.PageRequest{Limit: maxLimit, SortBy: entity.SortByActiveAt, Order: entity.OrderAsc}
+	secondPage := firstPage
+	secondPage.Cursor = cursor
+
+	taskRepo.EXPECT().List(gomock.Any(), gomock.Any(), firstPage).Return(entity.TaskPage{
+		Tasks: []entity.Task{
+			{ID: "1", Title: "free", Status: entity.Active},
+			{ID: "2", Title: "blocked", Status: entity.Active, BlockedBy: []string{"9"}},
+			{ID: "3", Title: "unblocked", Status: entity.Active, BlockedBy: []string{"8", "7"}},
+		},
+		Next: cursor,
+	}, nil)
+	taskRepo.EXPECT().GetMany(gomock.Any(), []string{"9", "8", "7"}).Return([]entity.Task{
+		{ID: "9", Status: entity.InProgress},
+		{ID: "8", Status: entity.Done},
+		// 7 удалена и не блокирует
+	}, nil)
+
+	taskRepo.EXPECT().List(gomock.Any(), gomock.Any(), secondPage).Return(entity.TaskPage{
+		Tasks: []entity.Task{
+			{ID: "4", Title: "after cancelled", Status: entity.Active, BlockedBy: []string{"6"}},
+			{ID: "5", Title: "over limit", Status: entity.Active},
+		},
+	}, nil)
+	taskRepo.EXPECT().GetMany(gomock.Any(), []string{"6"}).Return([]entity.Task{{ID: "6", Status: entity.Cancelled}}, nil)
+
+	taskRepo.EXPECT().Children(gomock.Any(), []string{"1", "3", "4"}).Return(nil, nil)
+
+	tasks, err := newTaskUsecase(taskRepo, nil, nil, nil).Next(context.Background(), 3)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"free", "unblocked", "after cancelled"}, func() []string {
+		var titles []string
+		for _, task := range tasks {
+			titles = append(titles, task.Title)
+		}
+
+		return titles
+	}())
+
+	_, err = newTaskUsecase(taskRepo, nil, nil, nil).Next(context.Background(), maxLimit+1)
+	assert.True(t, errors.Is(err, entity.ErrInvalidLimit), "unexpected error: %v", err)
+}
@@ -0,0 +1,54 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/skantay/todo-list/internal/entity"
+)
+
+// PatchTask частично обновляет задачу: меняются только поля из patch. Чужую задачу может изменить пользователь с ролью editor.
+// Задача с применённым изменением проверяется так же, как при полном обновлении,
+// а в хранилище записываются только изменённые поля.
+// Если задана версия из If-Match, задача меняется только в этой версии.
+func (t taskUsecase) PatchTask(ctx context.Context, id string, patch entity.TaskPatch) error {
+	ctx, err := taskAccess(ctx, t.repo, t.shares, id, entity.RoleEditor)
+	if err != nil {
+		return err
+	}
+
+	current, err := t.repo.Get(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get task: %w", err)
+	}
+
+	version := entity.IfMatchFromContext(ctx)
+	if version != entity.AnyVersion && current.Version != version {
+		return entity.ErrVersionMismatch
+	}
+
+	if patch.IsEmpty() {
+		return nil
+	}
+
+	// Проверка и нормализация задачи целиком: например, новый срок сравнивается с сохранённой датой начала
+	task, err := normalizeTask(patch.Apply(current), entity.LocationFromContext(ctx))
+	if err != nil {
+		return err
+	}
+
+	if err := t.validateParent(ctx, task); err != nil {
+		return err
+	}
+
+	if err := t.validateProject(ctx, task); err != nil {
+		return err
+	}
+
+	// Вызов метода репозитория для изменения полей задачи
+	if err := t.repo.Patch(ctx, id, version, patch.From(task)); err != nil {
+		return fmt.Errorf("failed to patch task: %w", err)
+	}
+
+	return nil
+}
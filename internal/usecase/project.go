@@ -0,0 +1,253 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/skantay/todo-list/internal/entity"
+)
+
+// maxProjectNameLen - максимальная длина имени проекта
+const maxProjectNameLen = 100
+
+// projectRepo определяет интерфейс для repository проектов
+type projectRepo interface {
+	Create(ctx context.Context, project entity.Project) (string, error)
+	Get(ctx context.Context, id string) (entity.Project, error)
+	List(ctx context.Context, includeArchived bool) ([]entity.Project, error)
+	Update(ctx context.Context, project entity.Project) error
+	SetArchived(ctx context.Context, id string, archivedAt *time.Time) error
+	Delete(ctx context.Context, id string) error
+}
+
+// projectTaskRepo определяет операции repository задач, которые нужны usecase проектов
+type projectTaskRepo interface {
+	DeleteByProject(ctx context.Context, projectID string) (int64, error)
+}
+
+type projectUsecase struct {
+	repo   projectRepo
+	tasks  projectTaskRepo
+	shares shareRepo
+	log    *slog.Logger
+}
+
+func newProjectUsecase(projectRepo projectRepo, taskRepo projectTaskRepo, shareRepo shareRepo, log *slog.Logger) projectUsecase {
+	return projectUsecase{
+		repo:   projectRepo,
+		tasks:  taskRepo,
+		shares: shareRepo,
+		log:    log,
+	}
+}
+
+// Create создаёт новый проект
+func (p projectUsecase) Create(ctx context.Context, project entity.Project) (string, error) {
+	project, err := normalizeProject(project)
+	if err != nil {
+		return "", err
+	}
+
+	// Новый проект всегда действующий
+	project.ArchivedAt = nil
+
+	id, err := p.repo.Create(ctx, project)
+	if err != nil {
+		return "", fmt.Errorf("failed to create project: %w", err)
+	}
+
+	return id, nil
+}
+
+// Get возвращает проект по его id. Чужой проект доступен с ролью viewer
+func (p projectUsecase) Get(ctx context.Context, id string) (entity.Project, error) {
+	ctx, err := projectAccess(ctx, p.repo, p.shares, id, entity.RoleViewer)
+	if err != nil {
+		return entity.Project{}, err
+	}
+
+	project, err := p.repo.Get(ctx, id)
+	if err != nil {
+		return entity.Project{}, fmt.Errorf("failed to get project: %w", err)
+	}
+
+	return project, nil
+}
+
+// List возвращает проекты в порядке имени, архивные - только если includeArchived
+func (p projectUsecase) List(ctx context.Context, includeArchived bool) ([]entity.Project, error) {
+	projects, err := p.repo.List(ctx, includeArchived)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get projects: %w", err)
+	}
+
+	return projects, nil
+}
+
+// Update обновляет имя и описание проекта. Чужой проект может обновить пользователь с ролью editor
+func (p projectUsecase) Update(ctx context.Context, project entity.Project) error {
+	project, err := normalizeProject(project)
+	if err != nil {
+		return err
+	}
+
+	ctx, err = projectAccess(ctx, p.repo, p.shares, project.ID, entity.RoleEditor)
+	if err != nil {
+		return err
+	}
+
+	if err := p.repo.Update(ctx, project); err != nil {
+		return fmt.Errorf("failed to update project: %w", err)
+	}
+
+	return nil
+}
+
+// Delete удаляет проект способом mode.
+// По умолчанию проект переносится в архив вместе с задачами, повторный перенос ничего не меняет.
+// При каскадном удалении сначала удаляются задачи проекта, затем сам проект и приглашения к нему.
+// Чужой проект может удалить пользователь с ролью owner.
+func (p projectUsecase) Delete(ctx context.Context, id, mode string) error {
+	if mode == "" {
+		mode = entity.DeleteArchive
+	}
+
+	if mode != entity.DeleteArchive && mode != entity.DeleteCascade {
+		return entity.NewValidationError("mode", "must be archive or cascade", entity.ErrInvalidDeleteMode)
+	}
+
+	ctx, err := projectAccess(ctx, p.repo, p.shares, id, entity.RoleOwner)
+	if err != nil {
+		return err
+	}
+
+	switch mode {
+	case entity.DeleteArchive:
+		project, err := p.repo.Get(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to get project: %w", err)
+		}
+
+		if project.IsArchived() {
+			return nil
+		}
+
+		now := time.Now().UTC()
+		if err := p.repo.SetArchived(ctx, id, &now); err != nil {
+			return fmt.Errorf("failed to archive project: %w", err)
+		}
+	case entity.DeleteCascade:
+		// Проверка существования, чтобы не удалять задачи несуществующего проекта
+		if _, err := p.repo.Get(ctx, id); err != nil {
+			return fmt.Errorf("failed to get project: %w", err)
+		}
+
+		if _, err := p.tasks.DeleteByProject(ctx, id); err != nil {
+			return fmt.Errorf("failed to delete project tasks: %w", err)
+		}
+
+		if err := p.repo.Delete(ctx, id); err != nil {
+			return fmt.Errorf("failed to delete project: %w", err)
+		}
+
+		if err := p.shares.DeleteByResource(ctx, entity.ResourceProject, id); err != nil {
+			return fmt.Errorf("failed to delete project shares: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Restore возвращает проект из архива. Чужой проект может вернуть пользователь с ролью owner
+func (p projectUsecase) Restore(ctx context.Context, id string) error {
+	ctx, err := projectAccess(ctx, p.repo, p.shares, id, entity.RoleOwner)
+	if err != nil {
+		return err
+	}
+
+	if err := p.repo.SetArchived(ctx, id, nil); err != nil {
+		return fmt.Errorf("failed to restore project: %w", err)
+	}
+
+	return nil
+}
+
+// normalizeProject проверяет поля проекта и обрезает пробелы вокруг имени
+func normalizeProject(project entity.Project) (entity.Project, error) {
+	project.Name = strings.TrimSpace(project.Name)
+
+	if project.Name == "" {
+		return project, entity.NewValidationError("name", "must not be empty", entity.ErrInvalidProject)
+	}
+
+	if utf8.RuneCountInString(project.Name) > maxProjectNameLen {
+		return project, entity.NewValidationError("name", fmt.Sprintf("must not exceed %d characters", maxProjectNameLen), entity.ErrInvalidProject)
+	}
+
+	if utf8.RuneCountInString(project.Description) > maxDescriptionLen {
+		return project, entity.NewValidationError("description", fmt.Sprintf("must not exceed %d characters", maxDescriptionLen), entity.ErrInvalidProject)
+	}
+
+	return project, nil
+}
+
+// MoveTask переносит задачу в проект projectID, пустой projectID убирает задачу из проекта
+func (t taskUsecase) MoveTask(ctx context.Context, id, projectID string) error {
+	task, err := t.repo.Get(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get task: %w", err)
+	}
+
+	if task.ProjectID == projectID {
+		return nil
+	}
+
+	task.ProjectID = projectID
+	if err := t.validateProject(ctx, task); err != nil {
+		return err
+	}
+
+	if err := t.repo.SetProject(ctx, id, projectID); err != nil {
+		return fmt.Errorf("failed to move task: %w", err)
+	}
+
+	return nil
+}
+
+// validateProject проверяет, что проект задачи существует.
+// В архивный проект нельзя добавить задачу, но задача, которая уже в нём, может оставаться там при обновлении.
+func (t taskUsecase) validateProject(ctx context.Context, task entity.Task) error {
+	if task.ProjectID == "" {
+		return nil
+	}
+
+	project, err := t.projects.Get(ctx, task.ProjectID)
+	if errors.Is(err, entity.ErrProjectNotFound) || errors.Is(err, entity.ErrInvalidID) {
+		return entity.NewValidationError("projectId", "must be an existing project", entity.ErrInvalidProject)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get project: %w", err)
+	}
+
+	if !project.IsArchived() {
+		return nil
+	}
+
+	if task.ID != "" {
+		stored, err := t.repo.Get(ctx, task.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get task: %w", err)
+		}
+
+		if stored.ProjectID == task.ProjectID {
+			return nil
+		}
+	}
+
+	return entity.NewValidationError("projectId", "must not be an archived project", entity.ErrInvalidProject)
+}
@@ -0,0 +1,162 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/usecase/project.go
+
+// Package mock_usecase is a generated GoMock package.
+package usecase
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	entity "github.com/skantay/todo-list/internal/entity"
+)
+
+// MockprojectRepo is a mock of projectRepo interface.
+type MockprojectRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockprojectRepoMockRecorder
+}
+
+// MockprojectRepoMockRecorder is the mock recorder for MockprojectRepo.
+type MockprojectRepoMockRecorder struct {
+	mock *MockprojectRepo
+}
+
+// NewMockprojectRepo creates a new mock instance.
+func NewMockprojectRepo(ctrl *gomock.Controller) *MockprojectRepo {
+	mock := &MockprojectRepo{ctrl: ctrl}
+	mock.recorder = &MockprojectRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockprojectRepo) EXPECT() *MockprojectRepoMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockprojectRepo) Create(ctx context.Context, project entity.Project) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, project)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockprojectRepoMockRecorder) Create(ctx, project interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockprojectRepo)(nil).Create), ctx, project)
+}
+
+// Delete mocks base method.
+func (m *MockprojectRepo) Delete(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockprojectRepoMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockprojectRepo)(nil).Delete), ctx, id)
+}
+
+// Get mocks base method.
+func (m *MockprojectRepo) Get(ctx context.Context, id string) (entity.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(entity.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockprojectRepoMockRecorder) Get(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockprojectRepo)(nil).Get), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockprojectRepo) List(ctx context.Context, includeArchived bool) ([]entity.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, includeArchived)
+	ret0, _ := ret[0].([]entity.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockprojectRepoMockRecorder) List(ctx, includeArchived interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockprojectRepo)(nil).List), ctx, includeArchived)
+}
+
+// SetArchived mocks base method.
+func (m *MockprojectRepo) SetArchived(ctx context.Context, id string, archivedAt *time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetArchived", ctx, id, archivedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetArchived indicates an expected call of SetArchived.
+func (mr *MockprojectRepoMockRecorder) SetArchived(ctx, id, archivedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetArchived", reflect.TypeOf((*MockprojectRepo)(nil).SetArchived), ctx, id, archivedAt)
+}
+
+// Update mocks base method.
+func (m *MockprojectRepo) Update(ctx context.Context, project entity.Project) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, project)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockprojectRepoMockRecorder) Update(ctx, project interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockprojectRepo)(nil).Update), ctx, project)
+}
+
+// MockprojectTaskRepo is a mock of projectTaskRepo interface.
+type MockprojectTaskRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockprojectTaskRepoMockRecorder
+}
+
+// MockprojectTaskRepoMockRecorder is the mock recorder for MockprojectTaskRepo.
+type MockprojectTaskRepoMockRecorder struct {
+	mock *MockprojectTaskRepo
+}
+
+// NewMockprojectTaskRepo creates a new mock instance.
+func NewMockprojectTaskRepo(ctrl *gomock.Controller) *MockprojectTaskRepo {
+	mock := &MockprojectTaskRepo{ctrl: ctrl}
+	mock.recorder = &MockprojectTaskRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockprojectTaskRepo) EXPECT() *MockprojectTaskRepoMockRecorder {
+	return m.recorder
+}
+
+// DeleteByProject mocks base method.
+func (m *MockprojectTaskRepo) DeleteByProject(ctx context.Context, projectID string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByProject", ctx, projectID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteByProject indicates an expected call of DeleteByProject.
+func (mr *MockprojectTaskRepoMockRecorder) DeleteByProject(ctx, projectID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByProject", reflect.TypeOf((*MockprojectTaskRepo)(nil).DeleteByProject), ctx, projectID)
+}
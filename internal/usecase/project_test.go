@@ -0,0 +1,216 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/skantay/todo-list/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+const projectID = "661fbb485131cd932a981b27"
+
+func archivedProject() entity.Project {
+	archivedAt := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	return entity.Project{ID: projectID, Name: "work", ArchivedAt: &archivedAt}
+}
+
+func Test_CreateProject(t *testing.T) {
+	tests := []struct {
+		name     string
+		project  entity.Project
+		setup    func(m *MockprojectRepo)
+		wantName string
+		wantErr  error
+	}{
+		{
+			name:    "#1 valid",
+			project: entity.Project{Name: "  work  "},
+			setup: func(m *MockprojectRepo) {
+				m.EXPECT().Create(gomock.Any(), entity.Project{Name: "work"}).Return(projectID, nil)
+			},
+		},
+		{
+			name:    "#2 empty name",
+			project: entity.Project{Name: "   "},
+			wantErr: entity.ErrInvalidProject,
+		},
+		{
+			name:    "#3 long name",
+			project: entity.Project{Name: strings.Repeat("a", maxProjectNameLen+1)},
+			wantErr: entity.ErrInvalidProject,
+		},
+		{
+			name:    "#4 already exists",
+			project: entity.Project{Name: "work"},
+			setup: func(m *MockprojectRepo) {
+				m.EXPECT().Create(gomock.Any(), gomock.Any()).Return("", entity.ErrProjectExists)
+			},
+			wantErr: entity.ErrProjectExists,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			projectRepo := NewMockprojectRepo(ctrl)
+
+			if tt.setup != nil {
+				tt.setup(projectRepo)
+			}
+
+			id, err := newProjectUsecase(projectRepo, nil, nil, nil).Create(context.Background(), tt.project)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), "unexpected error: %v", err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, projectID, id)
+		})
+	}
+}
+
+func Test_DeleteProject(t *testing.T) {
+	tests := []struct {
+		name    string
+		mode    string
+		setup   func(projects *MockprojectRepo, tasks *MockprojectTaskRepo, shares *MockshareRepo)
+		wantErr error
+	}{
+		{
+			name: "#1 archive by default",
+			setup: func(projects *MockprojectRepo, tasks *MockprojectTaskRepo, shares *MockshareRepo) {
+				projects.EXPECT().Get(gomock.Any(), projectID).Return(entity.Project{ID: projectID, Name: "work"}, nil)
+				projects.EXPECT().SetArchived(gomock.Any(), projectID, gomock.Not(gomock.Nil())).Return(nil)
+			},
+		},
+		{
+			name: "#2 archive again",
+			mode: entity.DeleteArchive,
+			setup: func(projects *MockprojectRepo, tasks *MockprojectTaskRepo, shares *MockshareRepo) {
+				projects.EXPECT().Get(gomock.Any(), projectID).Return(archivedProject(), nil)
+			},
+		},
+		{
+			name: "#3 cascade",
+			mode: entity.DeleteCascade,
+			setup: func(projects *MockprojectRepo, tasks *MockprojectTaskRepo, shares *MockshareRepo) {
+				gomock.InOrder(
+					projects.EXPECT().Get(gomock.Any(), projectID).Return(entity.Project{ID: projectID, Name: "work"}, nil),
+					tasks.EXPECT().DeleteByProject(gomock.Any(), projectID).Return(int64(3), nil),
+					projects.EXPECT().Delete(gomock.Any(), projectID).Return(nil),
+					shares.EXPECT().DeleteByResource(gomock.Any(), entity.ResourceProject, projectID).Return(nil),
+				)
+			},
+		},
+		{
+			name: "#4 cascade not found",
+			mode: entity.DeleteCascade,
+			setup: func(projects *MockprojectRepo, tasks *MockprojectTaskRepo, shares *MockshareRepo) {
+				projects.EXPECT().Get(gomock.Any(), projectID).Return(entity.Project{}, entity.ErrProjectNotFound)
+			},
+			wantErr: entity.ErrProjectNotFound,
+		},
+		{
+			name:    "#5 invalid mode",
+			mode:    "purge",
+			setup:   func(projects *MockprojectRepo, tasks *MockprojectTaskRepo, shares *MockshareRepo) {},
+			wantErr: entity.ErrInvalidDeleteMode,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			projectRepo := NewMockprojectRepo(ctrl)
+			taskRepo := NewMockprojectTaskRepo(ctrl)
+			shareRepo := NewMockshareRepo(ctrl)
+
+			tt.setup(projectRepo, taskRepo, shareRepo)
+
+			err := newProjectUsecase(projectRepo, taskRepo, shareRepo, nil).Delete(context.Background(), projectID, tt.mode)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), "unexpected error: %v", err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func Test_MoveTask(t *testing.T) {
+	task := entity.NewTask("release", entity.DateOf(time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)))
+	task.ID = "661fbb485131cd932a981b26"
+
+	tests := []struct {
+		name      string
+		projectID string
+		setup     func(tasks *MocktaskRepo, projects *MockprojectRepo)
+		wantErr   error
+	}{
+		{
+			name:      "#1 valid",
+			projectID: projectID,
+			setup: func(tasks *MocktaskRepo, projects *MockprojectRepo) {
+				tasks.EXPECT().Get(gomock.Any(), task.ID).Return(task, nil)
+				projects.EXPECT().Get(gomock.Any(), projectID).Return(entity.Project{ID: projectID, Name: "work"}, nil)
+				tasks.EXPECT().SetProject(gomock.Any(), task.ID, projectID).Return(nil)
+			},
+		},
+		{
+			name: "#2 out of project",
+			setup: func(tasks *MocktaskRepo, projects *MockprojectRepo) {
+				inProject := task
+				inProject.ProjectID = projectID
+				tasks.EXPECT().Get(gomock.Any(), task.ID).Return(inProject, nil)
+				tasks.EXPECT().SetProject(gomock.Any(), task.ID, "").Return(nil)
+			},
+		},
+		{
+			name:      "#3 project not found",
+			projectID: projectID,
+			setup: func(tasks *MocktaskRepo, projects *MockprojectRepo) {
+				tasks.EXPECT().Get(gomock.Any(), task.ID).Return(task, nil)
+				projects.EXPECT().Get(gomock.Any(), projectID).Return(entity.Project{}, entity.ErrProjectNotFound)
+			},
+			wantErr: entity.ErrInvalidProject,
+		},
+		{
+			name:      "#4 archived project",
+			projectID: projectID,
+			setup: func(tasks *MocktaskRepo, projects *MockprojectRepo) {
+				tasks.EXPECT().Get(gomock.Any(), task.ID).Return(task, nil).Times(2)
+				projects.EXPECT().Get(gomock.Any(), projectID).Return(archivedProject(), nil)
+			},
+			wantErr: entity.ErrInvalidProject,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			taskRepo := NewMocktaskRepo(ctrl)
+			projectRepo := NewMockprojectRepo(ctrl)
+
+			tt.setup(taskRepo, projectRepo)
+
+			err := newTaskUsecase(taskRepo, projectRepo, nil, nil).MoveTask(context.Background(), task.ID, tt.projectID)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), "unexpected error: %v", err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
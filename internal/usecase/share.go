@@ -0,0 +1,478 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/skantay/todo-list/internal/entity"
+)
+
+// shareRepo определяет интерфейс для repository приглашений
+type shareRepo interface {
+	Create(ctx context.Context, share entity.Share) (string, error)
+	Get(ctx context.Context, id string) (entity.Share, error)
+	List(ctx context.Context, filter entity.ShareFilter) ([]entity.Share, error)
+	Accept(ctx context.Context, id string, acceptedAt time.Time) error
+	Delete(ctx context.Context, id string) error
+	DeleteByResource(ctx context.Context, resourceType, resourceID string) error
+}
+
+// taskGetter определяет чтение задачи, по которому проверяется доступ к ней
+type taskGetter interface {
+	Get(ctx context.Context, id string) (entity.Task, error)
+}
+
+// shareTaskRepo определяет операции repository задач, которые нужны usecase приглашений
+type shareTaskRepo interface {
+	taskGetter
+	List(ctx context.Context, filter entity.TaskFilter, page entity.PageRequest) (entity.TaskPage, error)
+	SetAssignees(ctx context.Context, id string, assignment entity.Assignment) error
+}
+
+type shareUsecase struct {
+	repo     shareRepo
+	tasks    shareTaskRepo
+	projects projectRepo
+	users    userRepo
+	log      *slog.Logger
+}
+
+func newShareUsecase(shareRepo shareRepo, taskRepo shareTaskRepo, projectRepo projectRepo, userRepo userRepo, log *slog.Logger) shareUsecase {
+	return shareUsecase{
+		repo:     shareRepo,
+		tasks:    taskRepo,
+		projects: projectRepo,
+		users:    userRepo,
+		log:      log,
+	}
+}
+
+// Share приглашает пользователя с email к задаче или проекту с ролью role.
+// Приглашённый становится участником рабочего пространства ресурса.
+// Приглашать может владелец ресурса или пользователь с ролью owner.
+func (s shareUsecase) Share(ctx context.Context, resourceType, resourceID, email, role string) (string, error) {
+	if !slices.Contains(entity.Roles, role) {
+		return "", entity.NewValidationError("role", fmt.Sprintf("must be one of %s", strings.Join(entity.Roles, ", ")), entity.ErrInvalidShare)
+	}
+
+	ownerCtx, err := s.access(ctx, resourceType, resourceID, entity.RoleOwner)
+	if err != nil {
+		return "", err
+	}
+
+	email, err = normalizeEmail(email)
+	if err != nil {
+		return "", err
+	}
+
+	user, err := s.users.GetByEmail(ctx, email)
+	if errors.Is(err, entity.ErrUserNotFound) {
+		return "", entity.NewValidationError("email", "must be a registered user", entity.ErrInvalidShare)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if user.ID == entity.UserIDFromContext(ownerCtx) {
+		return "", entity.NewValidationError("email", "must not be the owner", entity.ErrInvalidShare)
+	}
+
+	// Без участия в пространстве приглашённый не сможет в него войти
+	if tenantID := entity.TenantIDFromContext(ctx); !user.IsMember(tenantID) {
+		if err := s.users.AddTenant(ctx, user.ID, tenantID); err != nil {
+			return "", fmt.Errorf("failed to add user tenant: %w", err)
+		}
+	}
+
+	// Приглашение создаётся от имени владельца ресурса
+	id, err := s.repo.Create(ownerCtx, entity.Share{
+		ResourceType: resourceType,
+		ResourceID:   resourceID,
+		UserID:       user.ID,
+		Email:        user.Email,
+		Role:         role,
+		Status:       entity.SharePending,
+		CreatedAt:    time.Now().UTC(),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to create share: %w", err)
+	}
+
+	return id, nil
+}
+
+// Shares возвращает приглашения к задаче или проекту. Список доступен пользователю с ролью owner.
+func (s shareUsecase) Shares(ctx context.Context, resourceType, resourceID string) ([]entity.Share, error) {
+	if _, err := s.access(ctx, resourceType, resourceID, entity.RoleOwner); err != nil {
+		return nil, err
+	}
+
+	shares, err := s.repo.List(ctx, entity.ShareFilter{ResourceType: resourceType, ResourceID: resourceID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list shares: %w", err)
+	}
+
+	if shares == nil {
+		shares = []entity.Share{}
+	}
+
+	return shares, nil
+}
+
+// Invitations возвращает непринятые приглашения пользователя из контекста
+func (s shareUsecase) Invitations(ctx context.Context) ([]entity.Share, error) {
+	shares, err := s.repo.List(ctx, entity.ShareFilter{
+		UserID: entity.UserIDFromContext(ctx),
+		Status: entity.SharePending,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list invitations: %w", err)
+	}
+
+	if shares == nil {
+		shares = []entity.Share{}
+	}
+
+	return shares, nil
+}
+
+// Accept принимает приглашение. Принять его может только приглашённый, повторное принятие ничего не меняет.
+func (s shareUsecase) Accept(ctx context.Context, id string) error {
+	share, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get share: %w", err)
+	}
+
+	if share.UserID != entity.UserIDFromContext(ctx) {
+		return entity.ErrShareNotFound
+	}
+
+	if share.Status == entity.ShareAccepted {
+		return nil
+	}
+
+	if err := s.repo.Accept(ctx, id, time.Now().UTC()); err != nil {
+		return fmt.Errorf("failed to accept share: %w", err)
+	}
+
+	return nil
+}
+
+// Revoke удаляет приглашение. Приглашённый так отклоняет приглашение или отказывается от доступа,
+// пользователь с ролью owner в ресурсе - отзывает доступ. Потерявший доступ снимается с задач ресурса.
+func (s shareUsecase) Revoke(ctx context.Context, id string) error {
+	share, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get share: %w", err)
+	}
+
+	if share.UserID != entity.UserIDFromContext(ctx) {
+		_, err := s.access(ctx, share.ResourceType, share.ResourceID, entity.RoleOwner)
+		if errors.Is(err, entity.ErrTaskNotFound) || errors.Is(err, entity.ErrProjectNotFound) {
+			return entity.ErrShareNotFound
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete share: %w", err)
+	}
+
+	if share.Status == entity.ShareAccepted {
+		if err := s.unassign(ctx, share); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// unassign снимает пользователя, потерявшего доступ по приглашению share, с задач ресурса
+func (s shareUsecase) unassign(ctx context.Context, share entity.Share) error {
+	callerID := entity.UserIDFromContext(ctx)
+	ownerCtx := entity.WithUserID(ctx, share.OwnerID)
+
+	var tasks []entity.Task
+
+	switch share.ResourceType {
+	case entity.ResourceTask:
+		task, err := s.tasks.Get(ownerCtx, share.ResourceID)
+		if errors.Is(err, entity.ErrTaskNotFound) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get task: %w", err)
+		}
+
+		tasks = append(tasks, task)
+	case entity.ResourceProject:
+		projectTasks, err := s.projectTasks(ownerCtx, share.ResourceID)
+		if err != nil {
+			return err
+		}
+
+		tasks = projectTasks
+	}
+
+	for _, task := range tasks {
+		if !task.IsAssigned(share.UserID) {
+			continue
+		}
+
+		assignees := slices.DeleteFunc(slices.Clone(task.Assignees), func(assignee string) bool {
+			return assignee == share.UserID
+		})
+		if len(assignees) == 0 {
+			assignees = nil
+		}
+
+		assignment := entity.Assignment{
+			Assignees:  assignees,
+			AssignedBy: callerID,
+			AssignedAt: time.Now().UTC(),
+		}
+
+		if err := s.tasks.SetAssignees(ownerCtx, task.ID, assignment); err != nil {
+			return fmt.Errorf("failed to unassign task: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// SharedTasks возвращает задачи других пользователей, доступные по принятым приглашениям:
+// задачи, к которым пригласили напрямую, и задачи проектов, к которым пригласили.
+func (s shareUsecase) SharedTasks(ctx context.Context) ([]entity.Task, error) {
+	shares, err := receivedShares(ctx, s.repo)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := []entity.Task{}
+	seen := make(map[string]bool)
+
+	add := func(task entity.Task) {
+		if !seen[task.ID] {
+			seen[task.ID] = true
+			tasks = append(tasks, task)
+		}
+	}
+
+	for _, share := range shares {
+		ownerCtx := entity.WithUserID(ctx, share.OwnerID)
+
+		switch share.ResourceType {
+		case entity.ResourceTask:
+			task, err := s.tasks.Get(ownerCtx, share.ResourceID)
+			if errors.Is(err, entity.ErrTaskNotFound) {
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to get task: %w", err)
+			}
+
+			add(task)
+		case entity.ResourceProject:
+			projectTasks, err := s.projectTasks(ownerCtx, share.ResourceID)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, task := range projectTasks {
+				add(task)
+			}
+		}
+	}
+
+	now, loc, prefix := time.Now(), entity.LocationFromContext(ctx), entity.TenantFromContext(ctx).WeekendPrefix
+	for i := range tasks {
+		present(&tasks[i], now, loc, prefix)
+	}
+
+	return tasks, nil
+}
+
+// projectTasks возвращает все задачи проекта постранично
+func (s shareUsecase) projectTasks(ctx context.Context, projectID string) ([]entity.Task, error) {
+	filter := entity.TaskFilter{Status: entity.StatusAll, IncludeFuture: true, ProjectID: projectID}
+	page := entity.PageRequest{Limit: maxLimit, SortBy: defaultSortBy, Order: defaultOrder}
+
+	var tasks []entity.Task
+
+	for {
+		result, err := s.tasks.List(ctx, filter, page)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get project tasks: %w", err)
+		}
+
+		tasks = append(tasks, result.Tasks...)
+
+		if result.Next == nil {
+			return tasks, nil
+		}
+
+		page.Cursor = result.Next
+	}
+}
+
+// access проверяет роль пользователя в задаче или проекте и возвращает контекст владельца ресурса.
+// Repository приглашений не ограничен владельцем, поэтому доступ не по приглашению
+// подтверждается чтением ресурса от имени пользователя: чужой ресурс не найдётся.
+func (s shareUsecase) access(ctx context.Context, resourceType, resourceID, required string) (context.Context, error) {
+	switch resourceType {
+	case entity.ResourceTask:
+		ownerCtx, err := taskAccess(ctx, s.tasks, s.repo, resourceID, required)
+		if err != nil || sharedAccess(ctx, ownerCtx) {
+			return ownerCtx, err
+		}
+
+		if _, err := s.tasks.Get(ctx, resourceID); err != nil {
+			return nil, fmt.Errorf("failed to get task: %w", err)
+		}
+
+		return ctx, nil
+	case entity.ResourceProject:
+		ownerCtx, err := projectAccess(ctx, s.projects, s.repo, resourceID, required)
+		if err != nil || sharedAccess(ctx, ownerCtx) {
+			return ownerCtx, err
+		}
+
+		if _, err := s.projects.Get(ctx, resourceID); err != nil {
+			return nil, fmt.Errorf("failed to get project: %w", err)
+		}
+
+		return ctx, nil
+	default:
+		return nil, entity.NewValidationError("resourceType", "must be task or project", entity.ErrInvalidShare)
+	}
+}
+
+// sharedAccess сообщает, что доступ к ресурсу получен по приглашению, то есть от имени другого владельца
+func sharedAccess(ctx, ownerCtx context.Context) bool {
+	return entity.UserIDFromContext(ownerCtx) != entity.UserIDFromContext(ctx)
+}
+
+// taskAccess проверяет, что у пользователя из контекста есть роль не ниже required в задаче id,
+// и возвращает контекст, от имени которого с задачей работает repository.
+// Своя задача доступна с любой ролью. Чужая - по принятому приглашению к ней или к её проекту,
+// тогда возвращается контекст владельца задачи. Без приглашения задача не видна,
+// с недостаточной ролью возвращается ErrForbidden.
+func taskAccess(ctx context.Context, tasks taskGetter, shares shareRepo, id, required string) (context.Context, error) {
+	received, err := receivedShares(ctx, shares)
+	if err != nil || len(received) == 0 {
+		return ctx, err
+	}
+
+	_, err = tasks.Get(ctx, id)
+	if !errors.Is(err, entity.ErrTaskNotFound) {
+		// Своя задача или ошибка, которую вернёт сама операция
+		return ctx, nil
+	}
+
+	var (
+		role     string
+		ownerCtx context.Context
+	)
+
+	for _, share := range received {
+		if share.ResourceType == entity.ResourceTask && share.ResourceID != id {
+			continue
+		}
+
+		shareCtx := entity.WithUserID(ctx, share.OwnerID)
+
+		task, err := tasks.Get(shareCtx, id)
+		if errors.Is(err, entity.ErrTaskNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get task: %w", err)
+		}
+
+		if share.ResourceType == entity.ResourceProject && task.ProjectID != share.ResourceID {
+			continue
+		}
+
+		// Исполнитель задачи может менять её как editor
+		shareRole := share.Role
+		if task.IsAssigned(share.UserID) && !entity.HasRole(shareRole, entity.RoleEditor) {
+			shareRole = entity.RoleEditor
+		}
+
+		if role == "" || entity.HasRole(shareRole, role) {
+			role, ownerCtx = shareRole, shareCtx
+		}
+	}
+
+	if role == "" {
+		return nil, entity.ErrTaskNotFound
+	}
+
+	if !entity.HasRole(role, required) {
+		return nil, fmt.Errorf("%w: %s role required", entity.ErrForbidden, required)
+	}
+
+	return ownerCtx, nil
+}
+
+// projectAccess проверяет, что у пользователя из контекста есть роль не ниже required в проекте id,
+// и возвращает контекст, от имени которого с проектом работает repository.
+func projectAccess(ctx context.Context, projects projectRepo, shares shareRepo, id, required string) (context.Context, error) {
+	received, err := receivedShares(ctx, shares)
+	if err != nil || len(received) == 0 {
+		return ctx, err
+	}
+
+	_, err = projects.Get(ctx, id)
+	if !errors.Is(err, entity.ErrProjectNotFound) {
+		return ctx, nil
+	}
+
+	var (
+		role     string
+		ownerCtx context.Context
+	)
+
+	for _, share := range received {
+		if share.ResourceType != entity.ResourceProject || share.ResourceID != id {
+			continue
+		}
+
+		if role == "" || entity.HasRole(share.Role, role) {
+			role, ownerCtx = share.Role, entity.WithUserID(ctx, share.OwnerID)
+		}
+	}
+
+	if role == "" {
+		return nil, entity.ErrProjectNotFound
+	}
+
+	if !entity.HasRole(role, required) {
+		return nil, fmt.Errorf("%w: %s role required", entity.ErrForbidden, required)
+	}
+
+	return ownerCtx, nil
+}
+
+// receivedShares возвращает принятые приглашения пользователя из контекста.
+// Без пользователя в контексте приглашений нет.
+func receivedShares(ctx context.Context, shares shareRepo) ([]entity.Share, error) {
+	userID := entity.UserIDFromContext(ctx)
+	if userID == "" {
+		return nil, nil
+	}
+
+	received, err := shares.List(ctx, entity.ShareFilter{UserID: userID, Status: entity.ShareAccepted})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list shares: %w", err)
+	}
+
+	return received, nil
+}
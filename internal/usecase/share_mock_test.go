@@ -0,0 +1,229 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/usecase/share.go
+
+// Package mock_usecase is a generated GoMock package.
+package usecase
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	entity "github.com/skantay/todo-list/internal/entity"
+)
+
+// MockshareRepo is a mock of shareRepo interface.
+type MockshareRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockshareRepoMockRecorder
+}
+
+// MockshareRepoMockRecorder is the mock recorder for MockshareRepo.
+type MockshareRepoMockRecorder struct {
+	mock *MockshareRepo
+}
+
+// NewMockshareRepo creates a new mock instance.
+func NewMockshareRepo(ctrl *gomock.Controller) *MockshareRepo {
+	mock := &MockshareRepo{ctrl: ctrl}
+	mock.recorder = &MockshareRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockshareRepo) EXPECT() *MockshareRepoMockRecorder {
+	return m.recorder
+}
+
+// Accept mocks base method.
+func (m *MockshareRepo) Accept(ctx context.Context, id string, acceptedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Accept", ctx, id, acceptedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Accept indicates an expected call of Accept.
+func (mr *MockshareRepoMockRecorder) Accept(ctx, id, acceptedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Accept", reflect.TypeOf((*MockshareRepo)(nil).Accept), ctx, id, acceptedAt)
+}
+
+// Create mocks base method.
+func (m *MockshareRepo) Create(ctx context.Context, share entity.Share) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, share)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockshareRepoMockRecorder) Create(ctx, share interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockshareRepo)(nil).Create), ctx, share)
+}
+
+// Delete mocks base method.
+func (m *MockshareRepo) Delete(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockshareRepoMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockshareRepo)(nil).Delete), ctx, id)
+}
+
+// DeleteByResource mocks base method.
+func (m *MockshareRepo) DeleteByResource(ctx context.Context, resourceType, resourceID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByResource", ctx, resourceType, resourceID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByResource indicates an expected call of DeleteByResource.
+func (mr *MockshareRepoMockRecorder) DeleteByResource(ctx, resourceType, resourceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByResource", reflect.TypeOf((*MockshareRepo)(nil).DeleteByResource), ctx, resourceType, resourceID)
+}
+
+// Get mocks base method.
+func (m *MockshareRepo) Get(ctx context.Context, id string) (entity.Share, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(entity.Share)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockshareRepoMockRecorder) Get(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockshareRepo)(nil).Get), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockshareRepo) List(ctx context.Context, filter entity.ShareFilter) ([]entity.Share, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, filter)
+	ret0, _ := ret[0].([]entity.Share)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockshareRepoMockRecorder) List(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockshareRepo)(nil).List), ctx, filter)
+}
+
+// MocktaskGetter is a mock of taskGetter interface.
+type MocktaskGetter struct {
+	ctrl     *gomock.Controller
+	recorder *MocktaskGetterMockRecorder
+}
+
+// MocktaskGetterMockRecorder is the mock recorder for MocktaskGetter.
+type MocktaskGetterMockRecorder struct {
+	mock *MocktaskGetter
+}
+
+// NewMocktaskGetter creates a new mock instance.
+func NewMocktaskGetter(ctrl *gomock.Controller) *MocktaskGetter {
+	mock := &MocktaskGetter{ctrl: ctrl}
+	mock.recorder = &MocktaskGetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocktaskGetter) EXPECT() *MocktaskGetterMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MocktaskGetter) Get(ctx context.Context, id string) (entity.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(entity.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MocktaskGetterMockRecorder) Get(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MocktaskGetter)(nil).Get), ctx, id)
+}
+
+// MockshareTaskRepo is a mock of shareTaskRepo interface.
+type MockshareTaskRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockshareTaskRepoMockRecorder
+}
+
+// MockshareTaskRepoMockRecorder is the mock recorder for MockshareTaskRepo.
+type MockshareTaskRepoMockRecorder struct {
+	mock *MockshareTaskRepo
+}
+
+// NewMockshareTaskRepo creates a new mock instance.
+func NewMockshareTaskRepo(ctrl *gomock.Controller) *MockshareTaskRepo {
+	mock := &MockshareTaskRepo{ctrl: ctrl}
+	mock.recorder = &MockshareTaskRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockshareTaskRepo) EXPECT() *MockshareTaskRepoMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockshareTaskRepo) Get(ctx context.Context, id string) (entity.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(entity.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockshareTaskRepoMockRecorder) Get(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockshareTaskRepo)(nil).Get), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockshareTaskRepo) List(ctx context.Context, filter entity.TaskFilter, page entity.PageRequest) (entity.TaskPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, filter, page)
+	ret0, _ := ret[0].(entity.TaskPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockshareTaskRepoMockRecorder) List(ctx, filter, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockshareTaskRepo)(nil).List), ctx, filter, page)
+}
+
+// SetAssignees mocks base method.
+func (m *MockshareTaskRepo) SetAssignees(ctx context.Context, id string, assignment entity.Assignment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAssignees", ctx, id, assignment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetAssignees indicates an expected call of SetAssignees.
+func (mr *MockshareTaskRepoMockRecorder) SetAssignees(ctx, id, assignment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAssignees", reflect.TypeOf((*MockshareTaskRepo)(nil).SetAssignees), ctx, id, assignment)
+}
@@ -0,0 +1,332 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/skantay/todo-list/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	sharedTaskID = "661fbb485131cd932a981b2a"
+	ownerID      = "661fbb485131cd932a981b2b"
+	strangerID   = "661fbb485131cd932a981b2e"
+)
+
+// ownedTasks настраивает Get репозитория так, что задача sharedTaskID из проекта projectID
+// видна только её владельцу ownerID.
+func ownedTasks(taskRepo *MocktaskRepo) {
+	taskRepo.EXPECT().Get(gomock.Any(), sharedTaskID).DoAndReturn(func(ctx context.Context, id string) (entity.Task, error) {
+		if entity.UserIDFromContext(ctx) != ownerID {
+			return entity.Task{}, entity.ErrTaskNotFound
+		}
+
+		return entity.Task{ID: id, OwnerID: ownerID, ProjectID: projectID}, nil
+	}).AnyTimes()
+}
+
+func Test_TaskAccess(t *testing.T) {
+	accepted := func(resourceType, resourceID, role string) entity.Share {
+		return entity.Share{ResourceType: resourceType, ResourceID: resourceID, OwnerID: ownerID, UserID: userID, Role: role, Status: entity.ShareAccepted}
+	}
+
+	tests := []struct {
+		name      string
+		userID    string
+		shares    []entity.Share
+		required  string
+		wantOwner string
+		wantErr   error
+	}{
+		{
+			name:      "#1 without user",
+			required:  entity.RoleOwner,
+			wantOwner: "",
+		},
+		{
+			name:      "#2 own task",
+			userID:    ownerID,
+			required:  entity.RoleOwner,
+			wantOwner: ownerID,
+		},
+		{
+			name:      "#3 shared task as editor",
+			userID:    userID,
+			shares:    []entity.Share{accepted(entity.ResourceTask, sharedTaskID, entity.RoleEditor)},
+			required:  entity.RoleEditor,
+			wantOwner: ownerID,
+		},
+		{
+			name:     "#4 viewer updates",
+			userID:   userID,
+			shares:   []entity.Share{accepted(entity.ResourceTask, sharedTaskID, entity.RoleViewer)},
+			required: entity.RoleEditor,
+			wantErr:  entity.ErrForbidden,
+		},
+		{
+			name:      "#5 highest role wins",
+			userID:    userID,
+			shares:    []entity.Share{accepted(entity.ResourceTask, sharedTaskID, entity.RoleViewer), accepted(entity.ResourceProject, projectID, entity.RoleOwner)},
+			required:  entity.RoleOwner,
+			wantOwner: ownerID,
+		},
+		{
+			name:     "#6 another project",
+			userID:   userID,
+			shares:   []entity.Share{accepted(entity.ResourceProject, "661fbb485131cd932a981b2c", entity.RoleOwner)},
+			required: entity.RoleViewer,
+			wantErr:  entity.ErrTaskNotFound,
+		},
+		{
+			name:     "#7 another task",
+			userID:   userID,
+			shares:   []entity.Share{accepted(entity.ResourceTask, "661fbb485131cd932a981b2c", entity.RoleOwner)},
+			required: entity.RoleViewer,
+			wantErr:  entity.ErrTaskNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			taskRepo := NewMocktaskRepo(ctrl)
+			shareRepo := NewMockshareRepo(ctrl)
+
+			ownedTasks(taskRepo)
+
+			ctx := context.Background()
+			if tt.userID != "" {
+				ctx = entity.WithUserID(ctx, tt.userID)
+
+				// У владельца задачи есть приглашение к чужому ресурсу, поэтому проверяется и его задача
+				shares := tt.shares
+				if tt.userID == ownerID {
+					shares = []entity.Share{accepted(entity.ResourceTask, "661fbb485131cd932a981b2c", entity.RoleViewer)}
+				}
+
+				shareRepo.EXPECT().List(gomock.Any(), entity.ShareFilter{UserID: tt.userID, Status: entity.ShareAccepted}).Return(shares, nil)
+			}
+
+			ctx, err := taskAccess(ctx, taskRepo, shareRepo, sharedTaskID, tt.required)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), "unexpected error: %v", err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantOwner, entity.UserIDFromContext(ctx))
+		})
+	}
+}
+
+func Test_Share(t *testing.T) {
+	tests := []struct {
+		name     string
+		userID   string
+		tenantID string
+		email    string
+		role     string
+		setup    func(shares *MockshareRepo, users *MockuserRepo)
+		wantErr  error
+	}{
+		{
+			name:   "#1 valid",
+			userID: ownerID,
+			email:  "Bob@example.com",
+			role:   entity.RoleEditor,
+			setup: func(shares *MockshareRepo, users *MockuserRepo) {
+				users.EXPECT().GetByEmail(gomock.Any(), "bob@example.com").Return(entity.User{ID: userID, Email: "bob@example.com"}, nil)
+				shares.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, share entity.Share) (string, error) {
+					assert.Equal(t, ownerID, entity.UserIDFromContext(ctx))
+					assert.Equal(t, entity.Share{
+						ResourceType: entity.ResourceTask,
+						ResourceID:   sharedTaskID,
+						UserID:       userID,
+						Email:        "bob@example.com",
+						Role:         entity.RoleEditor,
+						Status:       entity.SharePending,
+						CreatedAt:    share.CreatedAt,
+					}, share)
+
+					return "661fbb485131cd932a981b2d", nil
+				})
+			},
+		},
+		{
+			name:    "#2 unknown role",
+			userID:  ownerID,
+			email:   "bob@example.com",
+			role:    "admin",
+			wantErr: entity.ErrInvalidShare,
+		},
+		{
+			name:   "#3 unregistered user",
+			userID: ownerID,
+			email:  "nobody@example.com",
+			role:   entity.RoleViewer,
+			setup: func(shares *MockshareRepo, users *MockuserRepo) {
+				users.EXPECT().GetByEmail(gomock.Any(), "nobody@example.com").Return(entity.User{}, entity.ErrUserNotFound)
+			},
+			wantErr: entity.ErrInvalidShare,
+		},
+		{
+			name:   "#4 owner invites themselves",
+			userID: ownerID,
+			email:  "owner@example.com",
+			role:   entity.RoleViewer,
+			setup: func(shares *MockshareRepo, users *MockuserRepo) {
+				users.EXPECT().GetByEmail(gomock.Any(), "owner@example.com").Return(entity.User{ID: ownerID, Email: "owner@example.com"}, nil)
+			},
+			wantErr: entity.ErrInvalidShare,
+		},
+		{
+			name:   "#5 editor invites",
+			userID: userID,
+			email:  "carol@example.com",
+			role:   entity.RoleViewer,
+			setup: func(shares *MockshareRepo, users *MockuserRepo) {
+				shares.EXPECT().List(gomock.Any(), entity.ShareFilter{UserID: userID, Status: entity.ShareAccepted}).Return([]entity.Share{
+					{ResourceType: entity.ResourceTask, ResourceID: sharedTaskID, OwnerID: ownerID, UserID: userID, Role: entity.RoleEditor, Status: entity.ShareAccepted},
+				}, nil)
+			},
+			wantErr: entity.ErrForbidden,
+		},
+		{
+			name:     "#6 invitee joins workspace",
+			userID:   ownerID,
+			tenantID: "platform",
+			email:    "bob@example.com",
+			role:     entity.RoleViewer,
+			setup: func(shares *MockshareRepo, users *MockuserRepo) {
+				users.EXPECT().GetByEmail(gomock.Any(), "bob@example.com").Return(entity.User{ID: userID, Email: "bob@example.com"}, nil)
+				users.EXPECT().AddTenant(gomock.Any(), userID, "platform").Return(nil)
+				shares.EXPECT().Create(gomock.Any(), gomock.Any()).Return("661fbb485131cd932a981b2d", nil)
+			},
+		},
+		{
+			name:     "#7 invitee already in workspace",
+			userID:   ownerID,
+			tenantID: "platform",
+			email:    "bob@example.com",
+			role:     entity.RoleViewer,
+			setup: func(shares *MockshareRepo, users *MockuserRepo) {
+				users.EXPECT().GetByEmail(gomock.Any(), "bob@example.com").Return(entity.User{ID: userID, Email: "bob@example.com", Tenants: []string{"platform"}}, nil)
+				shares.EXPECT().Create(gomock.Any(), gomock.Any()).Return("661fbb485131cd932a981b2d", nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			taskRepo := NewMocktaskRepo(ctrl)
+			shareRepo := NewMockshareRepo(ctrl)
+			userRepo := NewMockuserRepo(ctrl)
+
+			ownedTasks(taskRepo)
+
+			if tt.userID == ownerID {
+				shareRepo.EXPECT().List(gomock.Any(), entity.ShareFilter{UserID: ownerID, Status: entity.ShareAccepted}).Return(nil, nil).AnyTimes()
+			}
+
+			if tt.setup != nil {
+				tt.setup(shareRepo, userRepo)
+			}
+
+			ctx := entity.WithUserID(context.Background(), tt.userID)
+			if tt.tenantID != "" {
+				ctx = entity.WithTenant(ctx, entity.Tenant{ID: tt.tenantID})
+			}
+
+			id, err := newShareUsecase(shareRepo, taskRepo, nil, userRepo, nil).Share(ctx, entity.ResourceTask, sharedTaskID, tt.email, tt.role)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), "unexpected error: %v", err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, "661fbb485131cd932a981b2d", id)
+		})
+	}
+}
+
+func Test_StrangerShares(t *testing.T) {
+	taskShare := entity.Share{
+		ID:           "661fbb485131cd932a981b2d",
+		ResourceType: entity.ResourceTask,
+		ResourceID:   sharedTaskID,
+		OwnerID:      ownerID,
+		UserID:       userID,
+		Email:        "bob@example.com",
+		Role:         entity.RoleEditor,
+		Status:       entity.ShareAccepted,
+	}
+
+	tests := []struct {
+		name    string
+		call    func(ctx context.Context, s shareUsecase) error
+		wantErr error
+	}{
+		{
+			name: "#1 list task shares",
+			call: func(ctx context.Context, s shareUsecase) error {
+				_, err := s.Shares(ctx, entity.ResourceTask, sharedTaskID)
+
+				return err
+			},
+			wantErr: entity.ErrTaskNotFound,
+		},
+		{
+			name: "#2 list project shares",
+			call: func(ctx context.Context, s shareUsecase) error {
+				_, err := s.Shares(ctx, entity.ResourceProject, projectID)
+
+				return err
+			},
+			wantErr: entity.ErrProjectNotFound,
+		},
+		{
+			name: "#3 share task",
+			call: func(ctx context.Context, s shareUsecase) error {
+				_, err := s.Share(ctx, entity.ResourceTask, sharedTaskID, "carol@example.com", entity.RoleOwner)
+
+				return err
+			},
+			wantErr: entity.ErrTaskNotFound,
+		},
+		{
+			name: "#4 revoke share",
+			call: func(ctx context.Context, s shareUsecase) error {
+				return s.Revoke(ctx, taskShare.ID)
+			},
+			wantErr: entity.ErrShareNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			taskRepo := NewMocktaskRepo(ctrl)
+			projectRepo := NewMockprojectRepo(ctrl)
+			shareRepo := NewMockshareRepo(ctrl)
+
+			// Задача и проект видны только владельцу, у постороннего нет принятых приглашений,
+			// а repository приглашений вернул бы приглашения любого владельца
+			ownedTasks(taskRepo)
+			projectRepo.EXPECT().Get(gomock.Any(), projectID).Return(entity.Project{}, entity.ErrProjectNotFound).AnyTimes()
+			shareRepo.EXPECT().List(gomock.Any(), entity.ShareFilter{UserID: strangerID, Status: entity.ShareAccepted}).Return(nil, nil).AnyTimes()
+			shareRepo.EXPECT().Get(gomock.Any(), taskShare.ID).Return(taskShare, nil).AnyTimes()
+
+			ctx := entity.WithUserID(context.Background(), strangerID)
+
+			err := tt.call(ctx, newShareUsecase(shareRepo, taskRepo, projectRepo, nil, nil))
+			assert.True(t, errors.Is(err, tt.wantErr), "unexpected error: %v", err)
+		})
+	}
+}
@@ -2,52 +2,72 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"html"
 	"log/slog"
+	"slices"
+	"strings"
 	"time"
+	"unicode"
 	"unicode/utf8"
 
 	"github.com/skantay/todo-list/internal/entity"
+	"github.com/skantay/todo-list/internal/repository"
 )
 
 // Константы для usecase
 const (
 	maxTitleLen        = 200
-	weekendTitlePrefix = "ВЫХОДНОЙ - "
+	maxDescriptionLen  = 10000
+	maxTags            = 20
+	maxTagLen          = 32
 	defaultStatus      = entity.Active
+	defaultLimit       = 50
+	maxLimit           = 100
+	defaultSortBy      = entity.SortByActiveAt
+	defaultOrder       = entity.OrderAsc
+	defaultSearchLimit = 20
+	snippetRadius      = 60  // Количество символов описания вокруг найденного слова
+	maxSubtaskDepth    = 10  // Максимальная вложенность подзадач
+	defaultMaxBatch    = 100 // Количество операций в пакете, если не настроено иное
 )
 
-// taskRepo определяет интерфейс для repository
+// taskRepo - хранилище задач, с которым работает usecase задач.
+// Контракт задаёт repository.TaskRepository, его реализуют бэкенды MongoDB, memory и SQLite.
 type taskRepo interface {
-	Create(ctx context.Context, task entity.Task) (string, error)
-	List(ctx context.Context, status string, now time.Time) ([]entity.Task, error)
-	Update(ctx context.Context, task entity.Task) error
-	MarkDone(ctx context.Context, id string) error
-	Delete(ctx context.Context, id string) error
+	repository.TaskRepository
 }
 
 type taskUsecase struct {
-	repo taskRepo
-	log  *slog.Logger
+	repo     taskRepo
+	projects projectRepo
+	shares   shareRepo
+	log      *slog.Logger
+	// cascade включает выполнение открытых пунктов чек-листа и подзадач вместе с задачей
+	cascade bool
+	// maxBatch - сколько операций можно передать в одном пакете
+	maxBatch int
 }
 
-func newTaskUsecase(taskRepo taskRepo, log *slog.Logger) taskUsecase {
+func newTaskUsecase(taskRepo taskRepo, projectRepo projectRepo, shareRepo shareRepo, log *slog.Logger) taskUsecase {
 	return taskUsecase{
-		repo: taskRepo,
-		log:  log,
+		repo:     taskRepo,
+		projects: projectRepo,
+		shares:   shareRepo,
+		log:      log,
+		maxBatch: defaultMaxBatch,
 	}
 }
 
 // Create создает новую задачу
-func (t taskUsecase) Create(ctx context.Context, title string, activeAt entity.TaskDate) (string, error) {
-	// Проверка максимальной длины заголовка
-	if utf8.RuneCountInString(title) > maxTitleLen {
-		return "", entity.ErrInvalidTitle
+// Статус новой задачи всегда active, приоритет по умолчанию normal
+func (t taskUsecase) Create(ctx context.Context, task entity.Task) (string, error) {
+	task, err := t.prepareCreate(ctx, task)
+	if err != nil {
+		return "", err
 	}
 
-	// Создание новой задачи
-	task := entity.NewTask(title, activeAt)
-
 	// Вызов метода репозитория для создания задачи
 	id, err := t.repo.Create(ctx, task)
 	if err != nil {
@@ -57,39 +77,248 @@ func (t taskUsecase) Create(ctx context.Context, title string, activeAt entity.T
 	return id, nil
 }
 
-// List возвращает список задач на основе указанного статуса
-func (t taskUsecase) List(ctx context.Context, status string) ([]entity.Task, error) {
+// prepareCreate проверяет новую задачу и готовит её к записи
+func (t taskUsecase) prepareCreate(ctx context.Context, task entity.Task) (entity.Task, error) {
+	task.SetStatusActive()
+
+	// Проверка и нормализация полей задачи
+	task, err := normalizeTask(task, entity.LocationFromContext(ctx))
+	if err != nil {
+		return task, err
+	}
+
+	// Новая повторяющаяся задача - первое повторение серии
+	task.Occurrence = 0
+	if task.Recurrence != "" {
+		task.Occurrence = 1
+	}
+
+	if err := t.validateParent(ctx, task); err != nil {
+		return task, err
+	}
+
+	if err := t.validateProject(ctx, task); err != nil {
+		return task, err
+	}
+
+	return task, nil
+}
+
+// Get возвращает задачу по её id. Чужая задача доступна с ролью viewer
+func (t taskUsecase) Get(ctx context.Context, id string) (entity.Task, error) {
+	ctx, err := taskAccess(ctx, t.repo, t.shares, id, entity.RoleViewer)
+	if err != nil {
+		return entity.Task{}, err
+	}
+
+	task, err := t.repo.Get(ctx, id)
+	if err != nil {
+		return entity.Task{}, fmt.Errorf("failed to get task: %w", err)
+	}
+
+	tasks := []entity.Task{task}
+	if err := t.withProgress(ctx, tasks); err != nil {
+		return entity.Task{}, err
+	}
+	task = tasks[0]
+
+	present(&task, time.Now(), entity.LocationFromContext(ctx), entity.TenantFromContext(ctx).WeekendPrefix)
+
+	return task, nil
+}
+
+// List возвращает страницу задач на основе указанного фильтра и параметров пагинации
+func (t taskUsecase) List(ctx context.Context, filter entity.TaskFilter, page entity.PageRequest) (entity.TaskPage, error) {
+	filter, err := normalizeFilter(filter)
+	if err != nil {
+		return entity.TaskPage{}, err
+	}
+
+	if filter.Assignee == entity.AssigneeMe {
+		filter.Assignee = entity.UserIDFromContext(ctx)
+	}
+
+	// Наступление задач считается в часовом поясе пользователя
+	filter.Now = time.Now()
+	filter.Location = entity.LocationFromContext(ctx)
+
+	page, err = normalizePage(page)
+	if err != nil {
+		return entity.TaskPage{}, err
+	}
+
+	// Задачи несуществующего проекта - ошибка, а не пустая страница.
+	// Задачи чужого проекта доступны с ролью viewer
+	if filter.ProjectID != "" {
+		ctx, err = projectAccess(ctx, t.projects, t.shares, filter.ProjectID, entity.RoleViewer)
+		if err != nil {
+			return entity.TaskPage{}, err
+		}
+
+		if _, err := t.projects.Get(ctx, filter.ProjectID); err != nil {
+			return entity.TaskPage{}, fmt.Errorf("failed to get project: %w", err)
+		}
+	}
+
+	// Получение страницы задач из репозитория
+	result, err := t.repo.List(ctx, filter, page)
+	if err != nil {
+		return entity.TaskPage{}, fmt.Errorf("failed to get tasks: %w", err)
+	}
+
+	if err := t.withProgress(ctx, result.Tasks); err != nil {
+		return entity.TaskPage{}, err
+	}
+
+	prefix := entity.TenantFromContext(ctx).WeekendPrefix
+	for i := range result.Tasks {
+		present(&result.Tasks[i], filter.Now, filter.Location, prefix)
+	}
+
+	return result, nil
+}
+
+// normalizeFilter проверяет фильтр списка задач и подставляет значения по умолчанию.
+func normalizeFilter(filter entity.TaskFilter) (entity.TaskFilter, error) {
 	// Проверка валидности статуса
-	if status != entity.Active && status != entity.Done && status != "" {
-		return nil, entity.ErrInvalidStatus
+	if !entity.IsStatus(filter.Status) && filter.Status != entity.StatusAll && filter.Status != "" {
+		return filter, entity.ErrInvalidStatus
 	}
 
 	// Установка статуса по умолчанию, если не указан
-	if status == "" {
-		status = defaultStatus
+	if filter.Status == "" {
+		filter.Status = defaultStatus
+	}
+
+	from, to := filter.From.Time(), filter.To.Time()
+	if !from.IsZero() && !to.IsZero() && to.Before(from) {
+		return filter, entity.NewValidationError("to", "must not be before from", entity.ErrInvalidDate)
 	}
 
-	// Получение списка задач из репозитория
-	tasks, err := t.repo.List(ctx, status, time.Now())
+	if utf8.RuneCountInString(filter.Title) > maxTitleLen {
+		return filter, entity.NewValidationError("title", fmt.Sprintf("must not exceed %d characters", maxTitleLen), entity.ErrInvalidFilter)
+	}
+
+	for _, priority := range filter.Priorities {
+		if err := validatePriority(priority); err != nil {
+			return filter, filterError("priority", err)
+		}
+	}
+
+	tags, err := normalizeTags(filter.Tags)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get tasks: %w", err)
+		return filter, filterError("tag", err)
 	}
+	filter.Tags = tags
 
-	// Добавление префикса к заголовку задачи, если она выпадает на выходные
-	for i := range tasks {
-		if tasks[i].ActiveAt.Time().Weekday() == time.Saturday || tasks[i].ActiveAt.Time().Weekday() == time.Sunday {
-			tasks[i].Title = weekendTitlePrefix + tasks[i].Title
+	if filter.TitleMatch == "" {
+		filter.TitleMatch = entity.TitleContains
+	}
+
+	if filter.TitleMatch != entity.TitleContains && filter.TitleMatch != entity.TitlePrefix {
+		return filter, entity.NewValidationError("titleMatch", "must be contains or prefix", entity.ErrInvalidFilter)
+	}
+
+	return filter, nil
+}
+
+// normalizePage проверяет параметры пагинации и подставляет значения по умолчанию.
+// Сортировка берётся из курсора, если клиент не указал её явно.
+func normalizePage(page entity.PageRequest) (entity.PageRequest, error) {
+	if page.Limit == 0 {
+		page.Limit = defaultLimit
+	}
+
+	if page.Limit < 0 || page.Limit > maxLimit {
+		return page, entity.NewValidationError("limit", fmt.Sprintf("must be between 1 and %d", maxLimit), entity.ErrInvalidLimit)
+	}
+
+	if page.Cursor != nil {
+		if page.SortBy == "" {
+			page.SortBy = page.Cursor.SortBy
 		}
+		if page.Order == "" {
+			page.Order = page.Cursor.Order
+		}
+	}
+
+	if page.SortBy == "" {
+		page.SortBy = defaultSortBy
+	}
+
+	if page.Order == "" {
+		page.Order = defaultOrder
+	}
+
+	switch page.SortBy {
+	case entity.SortByActiveAt, entity.SortByTitle, entity.SortByID:
+	default:
+		return page, entity.NewValidationError("sort", "must be one of activeAt, title, id", entity.ErrInvalidSort)
+	}
+
+	if page.Order != entity.OrderAsc && page.Order != entity.OrderDesc {
+		return page, entity.NewValidationError("order", "must be asc or desc", entity.ErrInvalidSort)
 	}
 
-	return tasks, nil
+	// Курсор действителен только для той сортировки, в которой был выдан
+	if page.Cursor != nil && (page.Cursor.SortBy != page.SortBy || page.Cursor.Order != page.Order) {
+		return page, entity.NewValidationError("cursor", "does not match sort and order", entity.ErrInvalidCursor)
+	}
+
+	return page, nil
 }
 
-// UpdateTask обновляет информацию о задаче
+// Search выполняет полнотекстовый поиск задач и подсвечивает найденные слова
+func (t taskUsecase) Search(ctx context.Context, query string, limit int) ([]entity.SearchResult, error) {
+	query = strings.TrimSpace(query)
+
+	// Проверка поискового запроса
+	terms := entity.SearchTerms(query)
+	if len(terms) == 0 {
+		return nil, entity.NewValidationError("q", "must contain at least one word", entity.ErrInvalidQuery)
+	}
+
+	if utf8.RuneCountInString(query) > maxTitleLen {
+		return nil, entity.NewValidationError("q", fmt.Sprintf("must not exceed %d characters", maxTitleLen), entity.ErrInvalidQuery)
+	}
+
+	if limit == 0 {
+		limit = defaultSearchLimit
+	}
+
+	if limit < 0 || limit > maxLimit {
+		return nil, entity.NewValidationError("limit", fmt.Sprintf("must be between 1 and %d", maxLimit), entity.ErrInvalidLimit)
+	}
+
+	results, err := t.repo.Search(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search tasks: %w", err)
+	}
+
+	prefix := entity.TenantFromContext(ctx).WeekendPrefix
+	for i := range results {
+		results[i].Highlights = map[string]string{
+			"title": highlight(results[i].Task.Title, terms),
+		}
+
+		if fragment, ok := snippet(results[i].Task.Description, terms); ok {
+			results[i].Highlights["description"] = highlight(fragment, terms)
+		}
+
+		present(&results[i].Task, time.Now(), entity.LocationFromContext(ctx), prefix)
+	}
+
+	return results, nil
+}
+
+// UpdateTask обновляет информацию о задаче. Чужую задачу может обновить пользователь с ролью editor.
+// Версия из If-Match запроса проверяется при записи: изменённая с тех пор задача не перезаписывается.
 func (t taskUsecase) UpdateTask(ctx context.Context, task entity.Task) error {
-	// Проверка максимальной длины заголовка
-	if utf8.RuneCountInString(task.Title) > maxTitleLen {
-		return entity.ErrInvalidTitle
+	task.Version = entity.IfMatchFromContext(ctx)
+
+	ctx, task, err := t.prepareUpdate(ctx, task)
+	if err != nil {
+		return err
 	}
 
 	// Вызов метода репозитория для обновления задачи
@@ -100,22 +329,399 @@ func (t taskUsecase) UpdateTask(ctx context.Context, task entity.Task) error {
 	return nil
 }
 
-// MarkTaskDone помечает задачу как выполненную
-func (t taskUsecase) MarkTaskDone(ctx context.Context, id string) error {
-	// Вызов метода репозитория для пометки задачи как выполненной
-	if err := t.repo.MarkDone(ctx, id); err != nil {
-		return fmt.Errorf("failed to mark task done: %w", err)
+// prepareUpdate проверяет новые поля задачи и права на её изменение.
+// Возвращает контекст владельца задачи, от имени которого она записывается.
+func (t taskUsecase) prepareUpdate(ctx context.Context, task entity.Task) (context.Context, entity.Task, error) {
+	// Проверка и нормализация полей задачи
+	task, err := normalizeTask(task, entity.LocationFromContext(ctx))
+	if err != nil {
+		return ctx, task, err
+	}
+
+	ctx, err = taskAccess(ctx, t.repo, t.shares, task.ID, entity.RoleEditor)
+	if err != nil {
+		return ctx, task, err
+	}
+
+	if err := t.validateParent(ctx, task); err != nil {
+		return ctx, task, err
+	}
+
+	if err := t.validateProject(ctx, task); err != nil {
+		return ctx, task, err
+	}
+
+	return ctx, task, nil
+}
+
+// ChangeStatus переводит задачу в статус status, если entity разрешает такой переход.
+// Переход в текущий статус ничего не меняет, поэтому повторный запрос безопасен.
+// Задача не выполняется, пока открыты блокирующие её задачи.
+// Задача с открытыми пунктами чек-листа или подзадачами выполняется только в каскадном режиме.
+// При выполнении повторяющейся задачи создаётся следующее повторение серии.
+// Статус чужой задачи может менять пользователь с ролью editor.
+// Если задана версия из If-Match, статус меняется только в этой версии задачи.
+func (t taskUsecase) ChangeStatus(ctx context.Context, id, status string) error {
+	return t.changeStatus(ctx, id, status, entity.IfMatchFromContext(ctx))
+}
+
+// changeStatus переводит задачу в статус status. version - ожидаемая версия задачи, entity.AnyVersion - любая.
+func (t taskUsecase) changeStatus(ctx context.Context, id, status string, version int64) error {
+	if !entity.IsStatus(status) {
+		return entity.ErrInvalidStatus
+	}
+
+	ctx, err := taskAccess(ctx, t.repo, t.shares, id, entity.RoleEditor)
+	if err != nil {
+		return err
+	}
+
+	task, err := t.repo.Get(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get task: %w", err)
+	}
+
+	// Устаревшая версия отклоняется до каскада, чтобы не менять подзадачи зря
+	if version != entity.AnyVersion && task.Version != version {
+		return entity.ErrVersionMismatch
+	}
+
+	if task.Status == status {
+		return nil
+	}
+
+	if err := t.checkTransition(ctx, task, status); err != nil {
+		return err
+	}
+
+	// completed - подзадачи, выполненные каскадом. Если дальше задача не выполнится,
+	// ошибка перечислит их, потому что отменить их выполнение уже нельзя
+	var completed []string
+
+	if status == entity.Done {
+		completed, err = t.completeSubtasks(ctx, &task)
+		if err != nil {
+			return err
+		}
+	}
+
+	task.SetStatus(status, time.Now())
+
+	if version != entity.AnyVersion {
+		version = task.Version
+	}
+
+	// Следующее повторение создаётся до смены статуса: если его не создать,
+	// задача остаётся невыполненной, а не выполненной без продолжения серии
+	nextID, err := t.createNextOccurrence(ctx, task)
+	if err != nil {
+		return cascadeError(completed, id, err)
+	}
+
+	// Вызов метода репозитория для смены статуса задачи
+	if err := t.repo.SetStatus(ctx, id, version, task.Status, task.CompletedAt); err != nil {
+		err = fmt.Errorf("failed to change task status: %w", err)
+
+		// Статус не сменился, поэтому созданное повторение удаляется
+		if nextID != "" {
+			if deleteErr := t.repo.Delete(ctx, nextID, entity.AnyVersion); deleteErr != nil {
+				err = fmt.Errorf("%w, failed to delete next occurrence %s: %w", err, nextID, deleteErr)
+			}
+		}
+
+		return cascadeError(completed, id, err)
 	}
 
 	return nil
 }
 
-// Delete удаляет задачу
+// createNextOccurrence создаёт следующее повторение выполненной задачи task и возвращает его id.
+// Пустой id означает, что повторение не создано: задача не выполнена, серия закончилась
+// или повторение с тем же заголовком и датой уже есть, например создано вручную.
+func (t taskUsecase) createNextOccurrence(ctx context.Context, task entity.Task) (string, error) {
+	if task.Status != entity.Done {
+		return "", nil
+	}
+
+	next, ok, err := task.NextOccurrence(entity.LocationFromContext(ctx))
+	if err != nil {
+		return "", fmt.Errorf("failed to schedule next occurrence: %w", err)
+	}
+	if !ok {
+		return "", nil
+	}
+
+	id, err := t.repo.Create(ctx, next)
+	if errors.Is(err, entity.ErrAlreadyExists) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to create next occurrence: %w", err)
+	}
+
+	return id, nil
+}
+
+// Delete удаляет задачу вместе с приглашениями к ней. Чужую задачу может удалить пользователь с ролью owner.
+// Если задана версия из If-Match, задача удаляется только в этой версии.
 func (t taskUsecase) Delete(ctx context.Context, id string) error {
+	ctx, err := taskAccess(ctx, t.repo, t.shares, id, entity.RoleOwner)
+	if err != nil {
+		return err
+	}
+
 	// Вызов метода репозитория для удаления задачи
-	if err := t.repo.Delete(ctx, id); err != nil {
+	if err := t.repo.Delete(ctx, id, entity.IfMatchFromContext(ctx)); err != nil {
 		return fmt.Errorf("failed to delete task: %w", err)
 	}
 
+	if err := t.shares.DeleteByResource(ctx, entity.ResourceTask, id); err != nil {
+		return fmt.Errorf("failed to delete task shares: %w", err)
+	}
+
+	return nil
+}
+
+// checkTransition проверяет, что задачу можно перевести в статус status.
+// Выполнение отклоняется, пока открыты блокирующие задачи.
+func (t taskUsecase) checkTransition(ctx context.Context, task entity.Task, status string) error {
+	if !entity.CanTransition(task.Status, status) {
+		return entity.NewValidationError("status", fmt.Sprintf("cannot change from %s to %s", task.Status, status), entity.ErrInvalidTransition)
+	}
+
+	if status == entity.Done {
+		return t.checkBlockers(ctx, task)
+	}
+
+	return nil
+}
+
+// completeSubtasks проверяет открытые пункты чек-листа и подзадачи перед выполнением задачи
+// и возвращает id подзадач, выполненных каскадом.
+// Без каскада выполнение отклоняется, в каскадном режиме они выполняются раньше самой задачи.
+// Подзадачи выполняются по одной, поэтому при ошибке уже выполненные перечисляются в entity.CascadeError.
+func (t taskUsecase) completeSubtasks(ctx context.Context, task *entity.Task) ([]string, error) {
+	open, err := t.openSubtasks(ctx, *task)
+	if err != nil {
+		return nil, err
+	}
+
+	openItems := task.OpenChecklistItems()
+	if openItems == 0 && len(open) == 0 {
+		return nil, nil
+	}
+
+	if !t.cascade {
+		return nil, openSubtasksError(openItems, len(open))
+	}
+
+	completed := make([]string, 0, len(open))
+
+	for _, child := range open {
+		if err := t.changeStatus(ctx, child.ID, entity.Done, entity.AnyVersion); err != nil {
+			return nil, cascadeError(completed, child.ID, fmt.Errorf("failed to complete subtask %s: %w", child.ID, err))
+		}
+
+		completed = append(completed, child.ID)
+	}
+
+	if openItems > 0 {
+		for i := range task.Checklist {
+			task.Checklist[i].Done = true
+		}
+
+		if err := t.repo.SetChecklist(ctx, task.ID, task.Checklist); err != nil {
+			return nil, cascadeError(completed, task.ID, fmt.Errorf("failed to update checklist: %w", err))
+		}
+
+		// Запись чек-листа увеличила версию задачи
+		task.Version++
+	}
+
+	return completed, nil
+}
+
+// cascadeError дополняет ошибку выполнения задачи failedID списком подзадач completed, уже выполненных каскадом.
+// Ошибка вложенного каскада добавляет в список его подзадачи. Если ничего не выполнено, err возвращается как есть.
+func cascadeError(completed []string, failedID string, err error) error {
+	var nested entity.CascadeError
+	if errors.As(err, &nested) {
+		completed = append(completed, nested.Completed...)
+		failedID, err = nested.FailedID, nested.Err
+	}
+
+	if len(completed) == 0 {
+		return err
+	}
+
+	return entity.CascadeError{
+		Completed: completed,
+		FailedID:  failedID,
+		Err:       err,
+	}
+}
+
+// openSubtasks возвращает незавершённые подзадачи задачи
+func (t taskUsecase) openSubtasks(ctx context.Context, task entity.Task) ([]entity.Task, error) {
+	children, err := t.repo.Children(ctx, []string{task.ID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get subtasks: %w", err)
+	}
+
+	var open []entity.Task
+	for _, child := range children {
+		if entity.IsOpen(child.Status) {
+			open = append(open, child)
+		}
+	}
+
+	return open, nil
+}
+
+// openSubtasksError отклоняет выполнение задачи с открытыми пунктами чек-листа и подзадачами
+func openSubtasksError(items, subtasks int) error {
+	return entity.NewValidationError("status", fmt.Sprintf("has %d open checklist items and %d open subtasks", items, subtasks), entity.ErrOpenSubtasks)
+}
+
+// validateParent проверяет, что родительская задача существует,
+// а сама задача не становится подзадачей самой себя или своей подзадачи.
+func (t taskUsecase) validateParent(ctx context.Context, task entity.Task) error {
+	parentID := task.ParentID
+
+	for depth := 0; parentID != ""; depth++ {
+		if parentID == task.ID {
+			return entity.NewValidationError("parentId", "must not be the task itself or its subtask", entity.ErrInvalidParent)
+		}
+
+		if depth == maxSubtaskDepth {
+			return entity.NewValidationError("parentId", fmt.Sprintf("subtasks must not be nested deeper than %d levels", maxSubtaskDepth), entity.ErrInvalidParent)
+		}
+
+		parent, err := t.repo.Get(ctx, parentID)
+		if errors.Is(err, entity.ErrTaskNotFound) || errors.Is(err, entity.ErrInvalidID) {
+			// Выше непосредственного родителя цепочка могла оборваться после удаления задачи
+			if depth > 0 {
+				return nil
+			}
+
+			return entity.NewValidationError("parentId", "must be an existing task", entity.ErrInvalidParent)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get parent task: %w", err)
+		}
+
+		parentID = parent.ParentID
+	}
+
 	return nil
 }
+
+// withProgress заполняет Progress задач по их чек-листам и подзадачам
+func (t taskUsecase) withProgress(ctx context.Context, tasks []entity.Task) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(tasks))
+	for _, task := range tasks {
+		ids = append(ids, task.ID)
+	}
+
+	children, err := t.repo.Children(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("failed to get subtasks: %w", err)
+	}
+
+	byParent := make(map[string][]entity.Task)
+	for _, child := range children {
+		byParent[child.ParentID] = append(byParent[child.ParentID], child)
+	}
+
+	for i := range tasks {
+		tasks[i].Progress = entity.ProgressOf(tasks[i], byParent[tasks[i].ID])
+	}
+
+	return nil
+}
+
+// present готовит задачу к выдаче: вычисляет просрочку на момент now и помечает выходные префиксом prefix
+func present(task *entity.Task, now time.Time, loc *time.Location, prefix string) {
+	task.Overdue = task.IsOverdue(entity.StartedBy(now, loc))
+
+	markWeekend(task, loc, prefix)
+}
+
+// markWeekend добавляет prefix к заголовку задачи, если она выпадает на выходные в часовом поясе loc
+func markWeekend(task *entity.Task, loc *time.Location, prefix string) {
+	weekday := task.ActiveAt.Date(loc).Time().Weekday()
+	if prefix != "" && (weekday == time.Saturday || weekday == time.Sunday) {
+		task.Title = prefix + task.Title
+	}
+}
+
+// highlight экранирует текст для HTML и оборачивает слова из terms в <mark>
+func highlight(text string, terms []string) string {
+	matches := make(map[string]bool, len(terms))
+	for _, term := range terms {
+		matches[term] = true
+	}
+
+	var b strings.Builder
+
+	// Разбиваем текст на чередующиеся слова и разделители, сохраняя исходный регистр
+	runes := []rune(text)
+	for start := 0; start < len(runes); {
+		end := start
+		isWord := isWordRune(runes[start])
+		for end < len(runes) && isWordRune(runes[end]) == isWord {
+			end++
+		}
+
+		part := string(runes[start:end])
+		if isWord && matches[strings.ToLower(part)] {
+			b.WriteString("<mark>" + html.EscapeString(part) + "</mark>")
+		} else {
+			b.WriteString(html.EscapeString(part))
+		}
+
+		start = end
+	}
+
+	return b.String()
+}
+
+// snippet вырезает из текста фрагмент вокруг первого слова из terms.
+// Возвращает false, если ни одного слова в тексте нет.
+func snippet(text string, terms []string) (string, bool) {
+	runes := []rune(text)
+
+	for start := 0; start < len(runes); {
+		end := start
+		isWord := isWordRune(runes[start])
+		for end < len(runes) && isWordRune(runes[end]) == isWord {
+			end++
+		}
+
+		if isWord && slices.Contains(terms, strings.ToLower(string(runes[start:end]))) {
+			from, to := max(start-snippetRadius, 0), min(end+snippetRadius, len(runes))
+
+			fragment := string(runes[from:to])
+			if from > 0 {
+				fragment = "…" + fragment
+			}
+			if to < len(runes) {
+				fragment += "…"
+			}
+
+			return fragment, true
+		}
+
+		start = end
+	}
+
+	return "", false
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
@@ -36,6 +36,36 @@ func (m *MocktaskRepo) EXPECT() *MocktaskRepoMockRecorder {
 	return m.recorder
 }
 
+// BulkWrite mocks base method.
+func (m *MocktaskRepo) BulkWrite(ctx context.Context, writes []entity.TaskWrite, atomic bool) ([]entity.BatchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkWrite", ctx, writes, atomic)
+	ret0, _ := ret[0].([]entity.BatchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkWrite indicates an expected call of BulkWrite.
+func (mr *MocktaskRepoMockRecorder) BulkWrite(ctx, writes, atomic interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkWrite", reflect.TypeOf((*MocktaskRepo)(nil).BulkWrite), ctx, writes, atomic)
+}
+
+// Children mocks base method.
+func (m *MocktaskRepo) Children(ctx context.Context, parentIDs []string) ([]entity.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Children", ctx, parentIDs)
+	ret0, _ := ret[0].([]entity.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Children indicates an expected call of Children.
+func (mr *MocktaskRepoMockRecorder) Children(ctx, parentIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Children", reflect.TypeOf((*MocktaskRepo)(nil).Children), ctx, parentIDs)
+}
+
 // Create mocks base method.
 func (m *MocktaskRepo) Create(ctx context.Context, task entity.Task) (string, error) {
 	m.ctrl.T.Helper()
@@ -52,46 +82,176 @@ func (mr *MocktaskRepoMockRecorder) Create(ctx, task interface{}) *gomock.Call {
 }
 
 // Delete mocks base method.
-func (m *MocktaskRepo) Delete(ctx context.Context, id string) error {
+func (m *MocktaskRepo) Delete(ctx context.Context, id string, version int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret := m.ctrl.Call(m, "Delete", ctx, id, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MocktaskRepoMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+func (mr *MocktaskRepoMockRecorder) Delete(ctx, id, version interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MocktaskRepo)(nil).Delete), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MocktaskRepo)(nil).Delete), ctx, id, version)
 }
 
-// List mocks base method.
-func (m *MocktaskRepo) List(ctx context.Context, status string, now time.Time) ([]entity.Task, error) {
+// DeleteByProject mocks base method.
+func (m *MocktaskRepo) DeleteByProject(ctx context.Context, projectID string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByProject", ctx, projectID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteByProject indicates an expected call of DeleteByProject.
+func (mr *MocktaskRepoMockRecorder) DeleteByProject(ctx, projectID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByProject", reflect.TypeOf((*MocktaskRepo)(nil).DeleteByProject), ctx, projectID)
+}
+
+// Get mocks base method.
+func (m *MocktaskRepo) Get(ctx context.Context, id string) (entity.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(entity.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MocktaskRepoMockRecorder) Get(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MocktaskRepo)(nil).Get), ctx, id)
+}
+
+// GetMany mocks base method.
+func (m *MocktaskRepo) GetMany(ctx context.Context, ids []string) ([]entity.Task, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "List", ctx, status, now)
+	ret := m.ctrl.Call(m, "GetMany", ctx, ids)
 	ret0, _ := ret[0].([]entity.Task)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
+// GetMany indicates an expected call of GetMany.
+func (mr *MocktaskRepoMockRecorder) GetMany(ctx, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMany", reflect.TypeOf((*MocktaskRepo)(nil).GetMany), ctx, ids)
+}
+
+// List mocks base method.
+func (m *MocktaskRepo) List(ctx context.Context, filter entity.TaskFilter, page entity.PageRequest) (entity.TaskPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, filter, page)
+	ret0, _ := ret[0].(entity.TaskPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
 // List indicates an expected call of List.
-func (mr *MocktaskRepoMockRecorder) List(ctx, status, now interface{}) *gomock.Call {
+func (mr *MocktaskRepoMockRecorder) List(ctx, filter, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MocktaskRepo)(nil).List), ctx, filter, page)
+}
+
+// Patch mocks base method.
+func (m *MocktaskRepo) Patch(ctx context.Context, id string, version int64, patch entity.TaskPatch) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Patch", ctx, id, version, patch)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Patch indicates an expected call of Patch.
+func (mr *MocktaskRepoMockRecorder) Patch(ctx, id, version, patch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Patch", reflect.TypeOf((*MocktaskRepo)(nil).Patch), ctx, id, version, patch)
+}
+
+// Search mocks base method.
+func (m *MocktaskRepo) Search(ctx context.Context, query string, limit int) ([]entity.SearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, query, limit)
+	ret0, _ := ret[0].([]entity.SearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MocktaskRepoMockRecorder) Search(ctx, query, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MocktaskRepo)(nil).Search), ctx, query, limit)
+}
+
+// SetAssignees mocks base method.
+func (m *MocktaskRepo) SetAssignees(ctx context.Context, id string, assignment entity.Assignment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAssignees", ctx, id, assignment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetAssignees indicates an expected call of SetAssignees.
+func (mr *MocktaskRepoMockRecorder) SetAssignees(ctx, id, assignment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAssignees", reflect.TypeOf((*MocktaskRepo)(nil).SetAssignees), ctx, id, assignment)
+}
+
+// SetBlockers mocks base method.
+func (m *MocktaskRepo) SetBlockers(ctx context.Context, id string, blockedBy []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetBlockers", ctx, id, blockedBy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetBlockers indicates an expected call of SetBlockers.
+func (mr *MocktaskRepoMockRecorder) SetBlockers(ctx, id, blockedBy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBlockers", reflect.TypeOf((*MocktaskRepo)(nil).SetBlockers), ctx, id, blockedBy)
+}
+
+// SetChecklist mocks base method.
+func (m *MocktaskRepo) SetChecklist(ctx context.Context, id string, checklist []entity.ChecklistItem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetChecklist", ctx, id, checklist)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetChecklist indicates an expected call of SetChecklist.
+func (mr *MocktaskRepoMockRecorder) SetChecklist(ctx, id, checklist interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetChecklist", reflect.TypeOf((*MocktaskRepo)(nil).SetChecklist), ctx, id, checklist)
+}
+
+// SetProject mocks base method.
+func (m *MocktaskRepo) SetProject(ctx context.Context, id, projectID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetProject", ctx, id, projectID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetProject indicates an expected call of SetProject.
+func (mr *MocktaskRepoMockRecorder) SetProject(ctx, id, projectID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MocktaskRepo)(nil).List), ctx, status, now)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetProject", reflect.TypeOf((*MocktaskRepo)(nil).SetProject), ctx, id, projectID)
 }
 
-// MarkDone mocks base method.
-func (m *MocktaskRepo) MarkDone(ctx context.Context, id string) error {
+// SetStatus mocks base method.
+func (m *MocktaskRepo) SetStatus(ctx context.Context, id string, version int64, status string, completedAt *time.Time) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "MarkDone", ctx, id)
+	ret := m.ctrl.Call(m, "SetStatus", ctx, id, version, status, completedAt)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// MarkDone indicates an expected call of MarkDone.
-func (mr *MocktaskRepoMockRecorder) MarkDone(ctx, id interface{}) *gomock.Call {
+// SetStatus indicates an expected call of SetStatus.
+func (mr *MocktaskRepoMockRecorder) SetStatus(ctx, id, version, status, completedAt interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkDone", reflect.TypeOf((*MocktaskRepo)(nil).MarkDone), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetStatus", reflect.TypeOf((*MocktaskRepo)(nil).SetStatus), ctx, id, version, status, completedAt)
 }
 
 // Update mocks base method.
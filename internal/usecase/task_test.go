@@ -3,6 +3,7 @@ package usecase
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
@@ -14,9 +15,8 @@ import (
 
 func Test_Create(t *testing.T) {
 	type args struct {
-		ctx      context.Context
-		title    string
-		activeAt entity.TaskDate
+		ctx  context.Context
+		task entity.Task
 	}
 
 	type fields struct {
@@ -40,9 +40,8 @@ func Test_Create(t *testing.T) {
 				set(f, "1", nil)
 			},
 			args: args{
-				ctx:      context.Background(),
-				title:    "valid",
-				activeAt: entity.TaskDate(time.Now()),
+				ctx:  context.Background(),
+				task: entity.NewTask("valid", entity.TimeOf(time.Now())),
 			},
 			wantID:  "1",
 			wantErr: nil,
@@ -51,26 +50,90 @@ func Test_Create(t *testing.T) {
 			name:  "#2 invlaid title",
 			setup: nil,
 			args: args{
-				ctx:      context.Background(),
-				title:    "loooooooooooooooooooo123ooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooo000000000000000000000000000000000000000000oong",
-				activeAt: entity.TaskDate(time.Now()),
+				ctx:  context.Background(),
+				task: entity.NewTask("loooooooooooooooooooo123ooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooo000000000000000000000000000000000000000000oong", entity.TimeOf(time.Now())),
 			},
 			wantID:  "",
 			wantErr: entity.ErrInvalidTitle,
 		},
 		{
-			name: "#3 repository error",
+			name:  "#3 empty title",
+			setup: nil,
+			args: args{
+				ctx:  context.Background(),
+				task: entity.NewTask("   ", entity.TimeOf(time.Now())),
+			},
+			wantID:  "",
+			wantErr: entity.ErrInvalidTitle,
+		},
+		{
+			name: "#4 repository error",
 			setup: func(f *fields) {
 				set(f, "", mongo.ErrNoDocuments)
 			},
 			args: args{
-				ctx:      context.Background(),
-				title:    "title",
-				activeAt: entity.TaskDate(time.Now()),
+				ctx:  context.Background(),
+				task: entity.NewTask("title", entity.TimeOf(time.Now())),
 			},
 			wantID:  "",
 			wantErr: mongo.ErrNoDocuments,
 		},
+		{
+			name:  "#5 invalid priority",
+			setup: nil,
+			args: args{
+				ctx:  context.Background(),
+				task: entity.Task{Title: "title", Priority: "asap"},
+			},
+			wantID:  "",
+			wantErr: entity.ErrInvalidPriority,
+		},
+		{
+			name:  "#6 invalid tag",
+			setup: nil,
+			args: args{
+				ctx:  context.Background(),
+				task: entity.Task{Title: "title", Tags: []string{"home", "new year"}},
+			},
+			wantID:  "",
+			wantErr: entity.ErrInvalidTags,
+		},
+		{
+			name:  "#7 description too long",
+			setup: nil,
+			args: args{
+				ctx:  context.Background(),
+				task: entity.Task{Title: "title", Description: strings.Repeat("a", maxDescriptionLen+1)},
+			},
+			wantID:  "",
+			wantErr: entity.ErrInvalidDescription,
+		},
+		{
+			name: "#8 subtask",
+			setup: func(f *fields) {
+				f.taskRepo.EXPECT().Get(gomock.Any(), "2").Return(entity.Task{ID: "2", ParentID: "3"}, nil)
+				f.taskRepo.EXPECT().Get(gomock.Any(), "3").Return(entity.Task{ID: "3"}, nil)
+				set(f, "1", nil)
+			},
+			args: args{
+				ctx:  context.Background(),
+				task: entity.Task{Title: "title", ParentID: "2"},
+			},
+			wantID:  "1",
+			wantErr: nil,
+		},
+		{
+			name: "#9 parent not found",
+			setup: func(f *fields) {
+				f.taskRepo.EXPECT().Get(gomock.Any(), "2").Return(entity.Task{}, entity.ErrTaskNotFound)
+			},
+			args: args{
+				ctx:  context.Background(),
+				task: entity.Task{Title: "title", ParentID: "2"},
+			},
+			wantID:  "",
+			wantErr: entity.ErrInvalidParent,
+		},
 	}
 
 	for _, tt := range tests {
@@ -78,7 +141,7 @@ func Test_Create(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			taskRepo := NewMocktaskRepo(ctrl)
 
-			taskUsecase := newTaskUsecase(taskRepo, nil)
+			taskUsecase := newTaskUsecase(taskRepo, nil, nil, nil)
 
 			fields := &fields{taskRepo}
 
@@ -86,7 +149,7 @@ func Test_Create(t *testing.T) {
 				tt.setup(fields)
 			}
 
-			id, err := taskUsecase.Create(tt.args.ctx, tt.args.title, tt.args.activeAt)
+			id, err := taskUsecase.Create(tt.args.ctx, tt.args.task)
 
 			assert.Equal(t, tt.wantID, id)
 			if tt.wantErr != nil {
@@ -132,7 +195,7 @@ func Test_UpdateTask(t *testing.T) {
 			},
 			args: args{
 				ctx:  context.Background(),
-				task: entity.NewTask("title", entity.TaskDate(time.Now())),
+				task: entity.NewTask("title", entity.TimeOf(time.Now())),
 			},
 			wantErr: nil,
 		},
@@ -141,7 +204,7 @@ func Test_UpdateTask(t *testing.T) {
 			setup: nil,
 			args: args{
 				ctx:  context.Background(),
-				task: entity.NewTask("loooooooooooooooooooo123ooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooo000000000000000000000000000000000000000000oong", entity.TaskDate(time.Now())),
+				task: entity.NewTask("loooooooooooooooooooo123ooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooooo000000000000000000000000000000000000000000oong", entity.TimeOf(time.Now())),
 			},
 			wantErr: entity.ErrInvalidTitle,
 		},
@@ -152,10 +215,30 @@ func Test_UpdateTask(t *testing.T) {
 			},
 			args: args{
 				ctx:  context.Background(),
-				task: entity.NewTask("title", entity.TaskDate(time.Now())),
+				task: entity.NewTask("title", entity.TimeOf(time.Now())),
 			},
 			wantErr: mongo.ErrEmptySlice,
 		},
+		{
+			name: "#4 parent is own subtask",
+			setup: func(f *fields) {
+				f.taskRepo.EXPECT().Get(gomock.Any(), "2").Return(entity.Task{ID: "2", ParentID: "1"}, nil)
+			},
+			args: args{
+				ctx:  context.Background(),
+				task: entity.Task{ID: "1", Title: "title", ParentID: "2"},
+			},
+			wantErr: entity.ErrInvalidParent,
+		},
+		{
+			name:  "#5 parent is itself",
+			setup: nil,
+			args: args{
+				ctx:  context.Background(),
+				task: entity.Task{ID: "1", Title: "title", ParentID: "1"},
+			},
+			wantErr: entity.ErrInvalidParent,
+		},
 	}
 
 	for _, tt := range tests {
@@ -163,7 +246,7 @@ func Test_UpdateTask(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			taskRepo := NewMocktaskRepo(ctrl)
 
-			taskUsecase := newTaskUsecase(taskRepo, nil)
+			taskUsecase := newTaskUsecase(taskRepo, nil, nil, nil)
 
 			fields := &fields{taskRepo}
 
@@ -188,48 +271,341 @@ func Test_UpdateTask(t *testing.T) {
 	}
 }
 
-func Test_MarkTaskDone(t *testing.T) {
+func Test_ChangeStatus(t *testing.T) {
 	type args struct {
-		ctx context.Context
-		id  string
+		ctx    context.Context
+		id     string
+		status string
 	}
 
 	type fields struct {
 		taskRepo *MocktaskRepo
 	}
 
-	set := func(field *fields, err error) {
-		field.taskRepo.EXPECT().MarkDone(gomock.Any(), gomock.Any()).Return(err)
+	get := func(field *fields, task entity.Task) {
+		field.taskRepo.EXPECT().Get(gomock.Any(), gomock.Any()).Return(task, nil)
+	}
+
+	set := func(field *fields, task entity.Task, status string, err error) {
+		get(field, task)
+		if status == entity.Done {
+			field.taskRepo.EXPECT().Children(gomock.Any(), gomock.Any()).Return(nil, nil)
+		}
+		field.taskRepo.EXPECT().SetStatus(gomock.Any(), gomock.Any(), entity.AnyVersion, status, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, _ int64, _ string, completedAt *time.Time) error {
+				// completedAt есть только у выполненной задачи
+				if (completedAt != nil) != (status == entity.Done) {
+					t.Errorf("unexpected completedAt %v for status %s", completedAt, status)
+				}
+
+				return err
+			})
+	}
+
+	monday := entity.DateOf(time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC))
+	weekly := entity.NewTask("weekly report", monday)
+	weekly.Recurrence = "FREQ=WEEKLY;BYDAY=MO,TH;COUNT=3"
+	weekly.Occurrence = 1
+
+	withStatus := func(task entity.Task, status string) entity.Task {
+		task.SetStatus(status, time.Now())
+
+		return task
 	}
 
+	parent := entity.NewTask("release", monday)
+	parent.ID = "661fbb485131cd932a981b26"
+	parent.Checklist = []entity.ChecklistItem{{ID: "a", Title: "changelog", Done: true}, {ID: "b", Title: "tag"}}
+
+	child := entity.NewTask("deploy", monday)
+	child.ID = "661fbb485131cd932a981b27"
+	child.ParentID = parent.ID
+
 	tests := []struct {
 		name    string
+		cascade bool
 		setup   func(f *fields)
 		args    args
 		wantErr error
 	}{
 		{
-			name: "#1 valid",
+			name: "#1 done",
 			setup: func(f *fields) {
-				set(f, nil)
+				set(f, entity.NewTask("title", monday), entity.Done, nil)
 			},
 			args: args{
-				ctx: context.Background(),
-				id:  "1",
+				ctx:    context.Background(),
+				id:     "1",
+				status: entity.Done,
 			},
 			wantErr: nil,
 		},
 		{
 			name: "#2 repository error",
 			setup: func(f *fields) {
-				set(f, mongo.ErrEmptySlice)
+				set(f, entity.NewTask("title", monday), entity.Done, mongo.ErrEmptySlice)
 			},
 			args: args{
-				ctx: context.Background(),
-				id:  "1",
+				ctx:    context.Background(),
+				id:     "1",
+				status: entity.Done,
 			},
 			wantErr: mongo.ErrEmptySlice,
 		},
+		{
+			name: "#3 not found",
+			setup: func(f *fields) {
+				f.taskRepo.EXPECT().Get(gomock.Any(), gomock.Any()).Return(entity.Task{}, entity.ErrTaskNotFound)
+			},
+			args: args{
+				ctx:    context.Background(),
+				id:     "1",
+				status: entity.Done,
+			},
+			wantErr: entity.ErrTaskNotFound,
+		},
+		{
+			name: "#4 recurring creates next occurrence",
+			setup: func(f *fields) {
+				set(f, weekly, entity.Done, nil)
+
+				next := entity.NewTask("weekly report", entity.DateOf(time.Date(2024, 4, 4, 0, 0, 0, 0, time.UTC)))
+				next.Recurrence = weekly.Recurrence
+				next.Occurrence = 2
+				f.taskRepo.EXPECT().Create(gomock.Any(), next).Return("2", nil)
+			},
+			args: args{
+				ctx:    context.Background(),
+				id:     "1",
+				status: entity.Done,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "#5 recurring series finished",
+			setup: func(f *fields) {
+				last := weekly
+				last.Occurrence = 3
+				set(f, last, entity.Done, nil)
+			},
+			args: args{
+				ctx:    context.Background(),
+				id:     "1",
+				status: entity.Done,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "#6 same status changes nothing",
+			setup: func(f *fields) {
+				get(f, withStatus(weekly, entity.Done))
+			},
+			args: args{
+				ctx:    context.Background(),
+				id:     "1",
+				status: entity.Done,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "#7 start",
+			setup: func(f *fields) {
+				set(f, weekly, entity.InProgress, nil)
+			},
+			args: args{
+				ctx:    context.Background(),
+				id:     "1",
+				status: entity.InProgress,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "#8 reopen done",
+			setup: func(f *fields) {
+				set(f, withStatus(weekly, entity.Done), entity.Active, nil)
+			},
+			args: args{
+				ctx:    context.Background(),
+				id:     "1",
+				status: entity.Active,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "#9 start done",
+			setup: func(f *fields) {
+				get(f, withStatus(weekly, entity.Done))
+			},
+			args: args{
+				ctx:    context.Background(),
+				id:     "1",
+				status: entity.InProgress,
+			},
+			wantErr: entity.ErrInvalidTransition,
+		},
+		{
+			name: "#10 cancel cancelled",
+			setup: func(f *fields) {
+				get(f, withStatus(weekly, entity.Cancelled))
+			},
+			args: args{
+				ctx:    context.Background(),
+				id:     "1",
+				status: entity.Cancelled,
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "#11 invalid status",
+			setup: nil,
+			args: args{
+				ctx:    context.Background(),
+				id:     "1",
+				status: "paused",
+			},
+			wantErr: entity.ErrInvalidStatus,
+		},
+		{
+			name: "#12 open subtasks refused",
+			setup: func(f *fields) {
+				get(f, parent)
+				f.taskRepo.EXPECT().Children(gomock.Any(), []string{parent.ID}).Return([]entity.Task{child}, nil)
+			},
+			args: args{
+				ctx:    context.Background(),
+				id:     parent.ID,
+				status: entity.Done,
+			},
+			wantErr: entity.ErrOpenSubtasks,
+		},
+		{
+			name:    "#13 cascade completes checklist and subtasks",
+			cascade: true,
+			setup: func(f *fields) {
+				f.taskRepo.EXPECT().Get(gomock.Any(), parent.ID).Return(parent, nil)
+				f.taskRepo.EXPECT().Children(gomock.Any(), []string{parent.ID}).Return([]entity.Task{child}, nil)
+
+				f.taskRepo.EXPECT().Get(gomock.Any(), child.ID).Return(child, nil)
+				f.taskRepo.EXPECT().Children(gomock.Any(), []string{child.ID}).Return(nil, nil)
+				f.taskRepo.EXPECT().SetStatus(gomock.Any(), child.ID, entity.AnyVersion, entity.Done, gomock.Any()).Return(nil)
+
+				checked := []entity.ChecklistItem{{ID: "a", Title: "changelog", Done: true}, {ID: "b", Title: "tag", Done: true}}
+				f.taskRepo.EXPECT().SetChecklist(gomock.Any(), parent.ID, checked).Return(nil)
+				f.taskRepo.EXPECT().SetStatus(gomock.Any(), parent.ID, entity.AnyVersion, entity.Done, gomock.Any()).Return(nil)
+			},
+			args: args{
+				ctx:    context.Background(),
+				id:     parent.ID,
+				status: entity.Done,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "#14 blocked by open task",
+			setup: func(f *fields) {
+				blocked := weekly
+				blocked.ID = "661fbb485131cd932a981b28"
+				blocked.BlockedBy = []string{parent.ID}
+
+				get(f, blocked)
+				f.taskRepo.EXPECT().GetMany(gomock.Any(), []string{parent.ID}).Return([]entity.Task{parent}, nil)
+			},
+			args: args{
+				ctx:    context.Background(),
+				id:     "661fbb485131cd932a981b28",
+				status: entity.Done,
+			},
+			wantErr: entity.ErrBlocked,
+		},
+		{
+			name: "#15 cancelled subtasks do not block",
+			setup: func(f *fields) {
+				cancelled := child
+				cancelled.Status = entity.Cancelled
+				done := parent
+				done.Checklist = []entity.ChecklistItem{{ID: "a", Title: "changelog", Done: true}}
+
+				get(f, done)
+				f.taskRepo.EXPECT().Children(gomock.Any(), []string{parent.ID}).Return([]entity.Task{cancelled}, nil)
+				f.taskRepo.EXPECT().SetStatus(gomock.Any(), parent.ID, entity.AnyVersion, entity.Done, gomock.Any()).Return(nil)
+			},
+			args: args{
+				ctx:    context.Background(),
+				id:     parent.ID,
+				status: entity.Done,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "#16 stale version",
+			setup: func(f *fields) {
+				stale := weekly
+				stale.Version = 2
+				get(f, stale)
+			},
+			args: args{
+				ctx:    entity.WithIfMatch(context.Background(), 1),
+				id:     "1",
+				status: entity.Done,
+			},
+			wantErr: entity.ErrVersionMismatch,
+		},
+		{
+			name:    "#17 cascade keeps expected version",
+			cascade: true,
+			setup: func(f *fields) {
+				versioned := parent
+				versioned.Checklist = []entity.ChecklistItem{{ID: "b", Title: "tag"}}
+				versioned.Version = 4
+				f.taskRepo.EXPECT().Get(gomock.Any(), parent.ID).Return(versioned, nil)
+				f.taskRepo.EXPECT().Children(gomock.Any(), []string{parent.ID}).Return([]entity.Task{child}, nil)
+
+				// Подзадача выполняется без проверки версии из If-Match родителя
+				f.taskRepo.EXPECT().Get(gomock.Any(), child.ID).Return(child, nil)
+				f.taskRepo.EXPECT().Children(gomock.Any(), []string{child.ID}).Return(nil, nil)
+				f.taskRepo.EXPECT().SetStatus(gomock.Any(), child.ID, entity.AnyVersion, entity.Done, gomock.Any()).Return(nil)
+
+				// Запись чек-листа увеличивает версию, статус меняется уже в следующей
+				f.taskRepo.EXPECT().SetChecklist(gomock.Any(), parent.ID, gomock.Any()).Return(nil)
+				f.taskRepo.EXPECT().SetStatus(gomock.Any(), parent.ID, int64(5), entity.Done, gomock.Any()).Return(nil)
+			},
+			args: args{
+				ctx:    entity.WithIfMatch(context.Background(), 4),
+				id:     parent.ID,
+				status: entity.Done,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "#18 next occurrence not created",
+			setup: func(f *fields) {
+				get(f, weekly)
+				f.taskRepo.EXPECT().Children(gomock.Any(), gomock.Any()).Return(nil, nil)
+
+				// Задача остаётся невыполненной: SetStatus не вызывается
+				f.taskRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return("", mongo.ErrClientDisconnected)
+			},
+			args: args{
+				ctx:    context.Background(),
+				id:     "1",
+				status: entity.Done,
+			},
+			wantErr: mongo.ErrClientDisconnected,
+		},
+		{
+			name: "#19 next occurrence removed when status is not changed",
+			setup: func(f *fields) {
+				set(f, weekly, entity.Done, entity.ErrVersionMismatch)
+				f.taskRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return("2", nil)
+				f.taskRepo.EXPECT().Delete(gomock.Any(), "2", entity.AnyVersion).Return(nil)
+			},
+			args: args{
+				ctx:    context.Background(),
+				id:     "1",
+				status: entity.Done,
+			},
+			wantErr: entity.ErrVersionMismatch,
+		},
 	}
 
 	for _, tt := range tests {
@@ -237,7 +613,8 @@ func Test_MarkTaskDone(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			taskRepo := NewMocktaskRepo(ctrl)
 
-			taskUsecase := newTaskUsecase(taskRepo, nil)
+			taskUsecase := newTaskUsecase(taskRepo, nil, nil, nil)
+			taskUsecase.cascade = tt.cascade
 
 			fields := &fields{taskRepo}
 
@@ -245,7 +622,7 @@ func Test_MarkTaskDone(t *testing.T) {
 				tt.setup(fields)
 			}
 
-			err := taskUsecase.MarkTaskDone(tt.args.ctx, tt.args.id)
+			err := taskUsecase.ChangeStatus(tt.args.ctx, tt.args.id, tt.args.status)
 			if tt.wantErr != nil {
 				if err == nil {
 					t.Errorf("\nexpected error: %v \nbut got nil error", tt.wantErr)
@@ -262,6 +639,107 @@ func Test_MarkTaskDone(t *testing.T) {
 	}
 }
 
+func Test_CascadeFailure(t *testing.T) {
+	monday := entity.DateOf(time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC))
+
+	task := func(id, parentID string) entity.Task {
+		task := entity.NewTask("task "+id, monday)
+		task.ID = id
+		task.ParentID = parentID
+
+		return task
+	}
+
+	// Дерево задач: parent -> first, second; second -> nested
+	tasks := map[string]entity.Task{
+		"parent": task("parent", ""),
+		"first":  task("first", "parent"),
+		"second": task("second", "parent"),
+		"nested": task("nested", "second"),
+	}
+	children := map[string][]entity.Task{
+		"parent": {tasks["first"], tasks["second"]},
+		"second": {tasks["nested"]},
+	}
+
+	tests := []struct {
+		name    string
+		failID  string
+		wantErr error
+	}{
+		{
+			name:   "#1 second subtask fails",
+			failID: "second",
+			wantErr: entity.CascadeError{
+				Completed: []string{"first", "nested"},
+				FailedID:  "second",
+				Err:       mongo.ErrClientDisconnected,
+			},
+		},
+		{
+			name:   "#2 nested subtask fails",
+			failID: "nested",
+			wantErr: entity.CascadeError{
+				Completed: []string{"first"},
+				FailedID:  "second",
+				Err:       mongo.ErrClientDisconnected,
+			},
+		},
+		{
+			name:   "#3 parent fails after subtasks",
+			failID: "parent",
+			wantErr: entity.CascadeError{
+				Completed: []string{"first", "second"},
+				FailedID:  "parent",
+				Err:       mongo.ErrClientDisconnected,
+			},
+		},
+		{
+			name:    "#4 first subtask fails",
+			failID:  "first",
+			wantErr: mongo.ErrClientDisconnected,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			taskRepo := NewMocktaskRepo(ctrl)
+
+			taskRepo.EXPECT().Get(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, id string) (entity.Task, error) {
+				return tasks[id], nil
+			}).AnyTimes()
+			taskRepo.EXPECT().Children(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, ids []string) ([]entity.Task, error) {
+				return children[ids[0]], nil
+			}).AnyTimes()
+			taskRepo.EXPECT().SetStatus(gomock.Any(), gomock.Any(), entity.AnyVersion, entity.Done, gomock.Any()).
+				DoAndReturn(func(_ context.Context, id string, _ int64, _ string, _ *time.Time) error {
+					if id == tt.failID {
+						return mongo.ErrClientDisconnected
+					}
+
+					return nil
+				}).AnyTimes()
+
+			taskUsecase := newTaskUsecase(taskRepo, nil, nil, nil)
+			taskUsecase.cascade = true
+
+			err := taskUsecase.ChangeStatus(context.Background(), "parent", entity.Done)
+			assert.ErrorIs(t, err, mongo.ErrClientDisconnected)
+
+			var cascadeErr entity.CascadeError
+			if want, ok := tt.wantErr.(entity.CascadeError); ok {
+				if assert.ErrorAs(t, err, &cascadeErr) {
+					assert.Equal(t, want.Completed, cascadeErr.Completed)
+					assert.Equal(t, want.FailedID, cascadeErr.FailedID)
+				}
+			} else {
+				assert.False(t, errors.As(err, &cascadeErr), "unexpected cascade error: %v", err)
+			}
+		})
+	}
+}
+
 func Test_Delete(t *testing.T) {
 	type args struct {
 		ctx context.Context
@@ -269,11 +747,12 @@ func Test_Delete(t *testing.T) {
 	}
 
 	type fields struct {
-		taskRepo *MocktaskRepo
+		taskRepo  *MocktaskRepo
+		shareRepo *MockshareRepo
 	}
 
 	set := func(field *fields, err error) {
-		field.taskRepo.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(err)
+		field.taskRepo.EXPECT().Delete(gomock.Any(), gomock.Any(), entity.AnyVersion).Return(err)
 	}
 
 	tests := []struct {
@@ -286,6 +765,7 @@ func Test_Delete(t *testing.T) {
 			name: "#1 valid",
 			setup: func(f *fields) {
 				set(f, nil)
+				f.shareRepo.EXPECT().DeleteByResource(gomock.Any(), entity.ResourceTask, "1").Return(nil)
 			},
 			args: args{
 				ctx: context.Background(),
@@ -304,16 +784,28 @@ func Test_Delete(t *testing.T) {
 			},
 			wantErr: mongo.ErrEmptySlice,
 		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			ctrl := gomock.NewController(t)
+		{
+			name: "#3 stale version",
+			setup: func(f *fields) {
+				f.taskRepo.EXPECT().Delete(gomock.Any(), "1", int64(3)).Return(entity.ErrVersionMismatch)
+			},
+			args: args{
+				ctx: entity.WithIfMatch(context.Background(), 3),
+				id:  "1",
+			},
+			wantErr: entity.ErrVersionMismatch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
 			taskRepo := NewMocktaskRepo(ctrl)
+			shareRepo := NewMockshareRepo(ctrl)
 
-			taskUsecase := newTaskUsecase(taskRepo, nil)
+			taskUsecase := newTaskUsecase(taskRepo, nil, shareRepo, nil)
 
-			fields := &fields{taskRepo}
+			fields := &fields{taskRepo, shareRepo}
 
 			if tt.setup != nil {
 				tt.setup(fields)
@@ -339,6 +831,7 @@ func Test_List(t *testing.T) {
 	type args struct {
 		ctx    context.Context
 		status string
+		page   entity.PageRequest
 	}
 
 	type fields struct {
@@ -346,7 +839,10 @@ func Test_List(t *testing.T) {
 	}
 
 	set := func(field *fields, tasks []entity.Task, err error) {
-		field.taskRepo.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(tasks, err)
+		field.taskRepo.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(entity.TaskPage{Tasks: tasks}, err)
+		if err == nil {
+			field.taskRepo.EXPECT().Children(gomock.Any(), gomock.Any()).Return(nil, nil)
+		}
 	}
 
 	tests := []struct {
@@ -370,7 +866,7 @@ func Test_List(t *testing.T) {
 				)
 			},
 			args: args{
-				ctx: context.Background(),
+				ctx:    context.Background(),
 				status: "active",
 			},
 			wantTasks: []entity.Task{
@@ -394,7 +890,7 @@ func Test_List(t *testing.T) {
 				)
 			},
 			args: args{
-				ctx: context.Background(),
+				ctx:    context.Background(),
 				status: "",
 			},
 			wantTasks: []entity.Task{
@@ -405,14 +901,14 @@ func Test_List(t *testing.T) {
 			wantErr: nil,
 		},
 		{
-			name: "#3 invalid status",
+			name:  "#3 invalid status",
 			setup: nil,
 			args: args{
-				ctx: context.Background(),
+				ctx:    context.Background(),
 				status: "invalid",
 			},
 			wantTasks: nil,
-			wantErr: entity.ErrInvalidStatus,
+			wantErr:   entity.ErrInvalidStatus,
 		},
 		{
 			name: "#4 repository error",
@@ -424,11 +920,11 @@ func Test_List(t *testing.T) {
 				)
 			},
 			args: args{
-				ctx: context.Background(),
+				ctx:    context.Background(),
 				status: "active",
 			},
 			wantTasks: nil,
-			wantErr: mongo.ErrClientDisconnected,
+			wantErr:   mongo.ErrClientDisconnected,
 		},
 		{
 			name: "#5 weekend usecase",
@@ -437,22 +933,83 @@ func Test_List(t *testing.T) {
 					f,
 					[]entity.Task{
 						{
-							Title: "BTC",
-							ActiveAt: entity.TaskDate(time.Date(2024,04,14,1,1,1,1,time.Local)),
+							Title:    "BTC",
+							ActiveAt: entity.TimeOf(time.Date(2024, 04, 14, 1, 1, 1, 1, time.Local)),
 						},
 					},
 					nil,
 				)
 			},
+			args: args{
+				ctx:    context.Background(),
+				status: "active",
+			},
+			wantTasks: []entity.Task{
+				{
+					Title:    "ВЫХОДНОЙ - BTC",
+					ActiveAt: entity.TimeOf(time.Date(2024, 04, 14, 1, 1, 1, 1, time.Local)),
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "#6 limit too big",
+			setup: nil,
+			args: args{
+				ctx:  context.Background(),
+				page: entity.PageRequest{Limit: maxLimit + 1},
+			},
+			wantTasks: nil,
+			wantErr:   entity.ErrInvalidLimit,
+		},
+		{
+			name:  "#7 invalid sort",
+			setup: nil,
+			args: args{
+				ctx:  context.Background(),
+				page: entity.PageRequest{SortBy: "status"},
+			},
+			wantTasks: nil,
+			wantErr:   entity.ErrInvalidSort,
+		},
+		{
+			name:  "#8 cursor from another sort",
+			setup: nil,
 			args: args{
 				ctx: context.Background(),
+				page: entity.PageRequest{
+					SortBy: entity.SortByActiveAt,
+					Cursor: &entity.Cursor{SortBy: entity.SortByTitle, Order: entity.OrderAsc, ID: "1"},
+				},
+			},
+			wantTasks: nil,
+			wantErr:   entity.ErrInvalidCursor,
+		},
+		{
+			name: "#9 progress",
+			setup: func(f *fields) {
+				f.taskRepo.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(entity.TaskPage{Tasks: []entity.Task{
+					{ID: "1", Title: "BTC", Checklist: []entity.ChecklistItem{{ID: "a", Done: true}, {ID: "b"}}},
+					{ID: "2", Title: "ETH"},
+				}}, nil)
+				f.taskRepo.EXPECT().Children(gomock.Any(), []string{"1", "2"}).Return([]entity.Task{
+					{ID: "3", ParentID: "1", Status: entity.Done},
+					{ID: "4", ParentID: "1", Status: entity.Active},
+					{ID: "5", ParentID: "1", Status: entity.Cancelled},
+				}, nil)
+			},
+			args: args{
+				ctx:    context.Background(),
 				status: "active",
 			},
 			wantTasks: []entity.Task{
 				{
-					Title: "ВЫХОДНОЙ - BTC",
-					ActiveAt: entity.TaskDate(time.Date(2024,04,14,1,1,1,1,time.Local)),
+					ID:        "1",
+					Title:     "BTC",
+					Checklist: []entity.ChecklistItem{{ID: "a", Done: true}, {ID: "b"}},
+					Progress:  &entity.Progress{Done: 2, Total: 4},
 				},
+				{ID: "2", Title: "ETH"},
 			},
 			wantErr: nil,
 		},
@@ -463,7 +1020,7 @@ func Test_List(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			taskRepo := NewMocktaskRepo(ctrl)
 
-			taskUsecase := newTaskUsecase(taskRepo, nil)
+			taskUsecase := newTaskUsecase(taskRepo, nil, nil, nil)
 
 			fields := &fields{taskRepo}
 
@@ -471,8 +1028,8 @@ func Test_List(t *testing.T) {
 				tt.setup(fields)
 			}
 
-			tasks, err := taskUsecase.List(tt.args.ctx, tt.args.status)
-			assert.Equal(t, tt.wantTasks, tasks)
+			page, err := taskUsecase.List(tt.args.ctx, entity.TaskFilter{Status: tt.args.status}, tt.args.page)
+			assert.Equal(t, tt.wantTasks, page.Tasks)
 			if tt.wantErr != nil {
 				if err == nil {
 					t.Errorf("\nexpected error: %v \nbut got nil error", tt.wantErr)
@@ -488,3 +1045,438 @@ func Test_List(t *testing.T) {
 		})
 	}
 }
+
+func Test_NormalizeFilter(t *testing.T) {
+	tests := []struct {
+		name       string
+		filter     entity.TaskFilter
+		wantFilter entity.TaskFilter
+		wantErr    error
+	}{
+		{
+			name:       "#1 defaults",
+			filter:     entity.TaskFilter{},
+			wantFilter: entity.TaskFilter{Status: entity.Active, TitleMatch: entity.TitleContains},
+		},
+		{
+			name:       "#2 all statuses",
+			filter:     entity.TaskFilter{Status: entity.StatusAll, TitleMatch: entity.TitlePrefix},
+			wantFilter: entity.TaskFilter{Status: entity.StatusAll, TitleMatch: entity.TitlePrefix},
+		},
+		{
+			name: "#3 reversed date range",
+			filter: entity.TaskFilter{
+				From: entity.DateOf(time.Date(2024, 4, 2, 0, 0, 0, 0, time.UTC)),
+				To:   entity.DateOf(time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)),
+			},
+			wantErr: entity.ErrInvalidDate,
+		},
+		{
+			name:    "#4 invalid title match",
+			filter:  entity.TaskFilter{TitleMatch: "regex"},
+			wantErr: entity.ErrInvalidFilter,
+		},
+		{
+			name:    "#5 invalid status",
+			filter:  entity.TaskFilter{Status: "archived"},
+			wantErr: entity.ErrInvalidStatus,
+		},
+		{
+			name:   "#6 priorities and tags",
+			filter: entity.TaskFilter{Priorities: []string{entity.PriorityHigh}, Tags: []string{"Work", "home", "work"}},
+			wantFilter: entity.TaskFilter{
+				Status:     entity.Active,
+				TitleMatch: entity.TitleContains,
+				Priorities: []string{entity.PriorityHigh},
+				Tags:       []string{"home", "work"},
+			},
+		},
+		{
+			name:    "#7 invalid priority",
+			filter:  entity.TaskFilter{Priorities: []string{"asap"}},
+			wantErr: entity.ErrInvalidFilter,
+		},
+		{
+			name:    "#8 invalid tag",
+			filter:  entity.TaskFilter{Tags: []string{"#work"}},
+			wantErr: entity.ErrInvalidFilter,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := normalizeFilter(tt.filter)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantFilter, filter)
+		})
+	}
+}
+
+func Test_NormalizePage(t *testing.T) {
+	cursor := &entity.Cursor{SortBy: entity.SortByTitle, Order: entity.OrderDesc, ID: "1"}
+
+	page, err := normalizePage(entity.PageRequest{Cursor: cursor})
+	assert.NoError(t, err)
+	assert.Equal(t, entity.PageRequest{Limit: defaultLimit, SortBy: entity.SortByTitle, Order: entity.OrderDesc, Cursor: cursor}, page)
+
+	page, err = normalizePage(entity.PageRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, entity.PageRequest{Limit: defaultLimit, SortBy: defaultSortBy, Order: defaultOrder}, page)
+}
+
+func Test_Get(t *testing.T) {
+	type args struct {
+		ctx context.Context
+		id  string
+	}
+
+	type fields struct {
+		taskRepo *MocktaskRepo
+	}
+
+	set := func(field *fields, task entity.Task, err error) {
+		field.taskRepo.EXPECT().Get(gomock.Any(), gomock.Any()).Return(task, err)
+		if err == nil {
+			field.taskRepo.EXPECT().Children(gomock.Any(), gomock.Any()).Return(nil, nil)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		setup    func(f *fields)
+		args     args
+		wantTask entity.Task
+		wantErr  error
+	}{
+		{
+			name: "#1 valid",
+			setup: func(f *fields) {
+				set(f, entity.Task{ID: "1", Title: "BTC", Status: entity.Done}, nil)
+			},
+			args: args{
+				ctx: context.Background(),
+				id:  "1",
+			},
+			wantTask: entity.Task{ID: "1", Title: "BTC", Status: entity.Done},
+			wantErr:  nil,
+		},
+		{
+			name: "#2 weekend usecase",
+			setup: func(f *fields) {
+				set(f, entity.Task{Title: "BTC", ActiveAt: entity.TimeOf(time.Date(2024, 04, 14, 1, 1, 1, 1, time.Local))}, nil)
+			},
+			args: args{
+				ctx: context.Background(),
+				id:  "1",
+			},
+			wantTask: entity.Task{Title: "ВЫХОДНОЙ - BTC", ActiveAt: entity.TimeOf(time.Date(2024, 04, 14, 1, 1, 1, 1, time.Local))},
+			wantErr:  nil,
+		},
+		{
+			name: "#3 not found",
+			setup: func(f *fields) {
+				set(f, entity.Task{}, entity.ErrTaskNotFound)
+			},
+			args: args{
+				ctx: context.Background(),
+				id:  "1",
+			},
+			wantTask: entity.Task{},
+			wantErr:  entity.ErrTaskNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			taskRepo := NewMocktaskRepo(ctrl)
+
+			taskUsecase := newTaskUsecase(taskRepo, nil, nil, nil)
+
+			fields := &fields{taskRepo}
+
+			if tt.setup != nil {
+				tt.setup(fields)
+			}
+
+			task, err := taskUsecase.Get(tt.args.ctx, tt.args.id)
+			assert.Equal(t, tt.wantTask, task)
+			if tt.wantErr != nil {
+				if err == nil {
+					t.Errorf("\nexpected error: %v \nbut got nil error", tt.wantErr)
+				} else {
+					if !errors.Is(err, tt.wantErr) {
+						t.Errorf("\nexpected error:%v \ninvalid error: %v", tt.wantErr.Error(), err.Error())
+					}
+				}
+			} else if err != nil {
+				t.Errorf("\nunexpeceted error: %v", err)
+			}
+			ctrl.Finish()
+		})
+	}
+}
+
+func Test_Search(t *testing.T) {
+	type args struct {
+		ctx   context.Context
+		query string
+		limit int
+	}
+
+	type fields struct {
+		taskRepo *MocktaskRepo
+	}
+
+	set := func(field *fields, limit int, results []entity.SearchResult, err error) {
+		field.taskRepo.EXPECT().Search(gomock.Any(), gomock.Any(), limit).Return(results, err)
+	}
+
+	tests := []struct {
+		name        string
+		setup       func(f *fields)
+		args        args
+		wantResults []entity.SearchResult
+		wantErr     error
+	}{
+		{
+			name: "#1 valid with highlights",
+			setup: func(f *fields) {
+				set(f, defaultSearchLimit, []entity.SearchResult{{Task: entity.Task{Title: "Report <draft> REPORT"}, Score: 1}}, nil)
+			},
+			args: args{
+				ctx:   context.Background(),
+				query: "report",
+			},
+			wantResults: []entity.SearchResult{
+				{
+					Task:       entity.Task{Title: "Report <draft> REPORT"},
+					Score:      1,
+					Highlights: map[string]string{"title": "<mark>Report</mark> &lt;draft&gt; <mark>REPORT</mark>"},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "#2 empty query",
+			setup: nil,
+			args: args{
+				ctx:   context.Background(),
+				query: "  -milk ",
+			},
+			wantResults: nil,
+			wantErr:     entity.ErrInvalidQuery,
+		},
+		{
+			name:  "#3 invalid limit",
+			setup: nil,
+			args: args{
+				ctx:   context.Background(),
+				query: "report",
+				limit: maxLimit + 1,
+			},
+			wantResults: nil,
+			wantErr:     entity.ErrInvalidLimit,
+		},
+		{
+			name: "#4 repository error",
+			setup: func(f *fields) {
+				set(f, 5, nil, mongo.ErrClientDisconnected)
+			},
+			args: args{
+				ctx:   context.Background(),
+				query: "report",
+				limit: 5,
+			},
+			wantResults: nil,
+			wantErr:     mongo.ErrClientDisconnected,
+		},
+		{
+			name: "#5 description snippet",
+			setup: func(f *fields) {
+				set(f, defaultSearchLimit, []entity.SearchResult{{Task: entity.Task{Title: "Q2", Description: strings.Repeat("x ", 40) + "send the report"}, Score: 1}}, nil)
+			},
+			args: args{
+				ctx:   context.Background(),
+				query: "report",
+			},
+			wantResults: []entity.SearchResult{
+				{
+					Task:  entity.Task{Title: "Q2", Description: strings.Repeat("x ", 40) + "send the report"},
+					Score: 1,
+					Highlights: map[string]string{
+						"title":       "Q2",
+						"description": "…" + strings.Repeat(" x", 25) + " send the <mark>report</mark>",
+					},
+				},
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			taskRepo := NewMocktaskRepo(ctrl)
+
+			taskUsecase := newTaskUsecase(taskRepo, nil, nil, nil)
+
+			fields := &fields{taskRepo}
+
+			if tt.setup != nil {
+				tt.setup(fields)
+			}
+
+			results, err := taskUsecase.Search(tt.args.ctx, tt.args.query, tt.args.limit)
+			assert.Equal(t, tt.wantResults, results)
+			if tt.wantErr != nil {
+				if err == nil {
+					t.Errorf("\nexpected error: %v \nbut got nil error", tt.wantErr)
+				} else {
+					if !errors.Is(err, tt.wantErr) {
+						t.Errorf("\nexpected error:%v \ninvalid error: %v", tt.wantErr.Error(), err.Error())
+					}
+				}
+			} else if err != nil {
+				t.Errorf("\nunexpeceted error: %v", err)
+			}
+			ctrl.Finish()
+		})
+	}
+}
+
+func Test_MarkWeekend(t *testing.T) {
+	almaty := time.FixedZone("UTC+5", 5*60*60)
+
+	tests := []struct {
+		name      string
+		activeAt  entity.TaskDate
+		loc       *time.Location
+		prefix    string
+		wantTitle string
+	}{
+		{
+			name:      "#1 saturday date",
+			activeAt:  entity.DateOf(time.Date(2024, 4, 6, 0, 0, 0, 0, time.UTC)),
+			loc:       almaty,
+			prefix:    entity.DefaultWeekendPrefix,
+			wantTitle: entity.DefaultWeekendPrefix + "title",
+		},
+		{
+			name:      "#2 friday evening in UTC",
+			activeAt:  entity.TimeOf(time.Date(2024, 4, 5, 20, 0, 0, 0, time.UTC)),
+			loc:       time.UTC,
+			prefix:    entity.DefaultWeekendPrefix,
+			wantTitle: "title",
+		},
+		{
+			name:      "#3 saturday night in UTC+5",
+			activeAt:  entity.TimeOf(time.Date(2024, 4, 5, 20, 0, 0, 0, time.UTC)),
+			loc:       almaty,
+			prefix:    entity.DefaultWeekendPrefix,
+			wantTitle: entity.DefaultWeekendPrefix + "title",
+		},
+		{
+			name:      "#4 tenant prefix",
+			activeAt:  entity.DateOf(time.Date(2024, 4, 6, 0, 0, 0, 0, time.UTC)),
+			loc:       time.UTC,
+			prefix:    "WEEKEND: ",
+			wantTitle: "WEEKEND: title",
+		},
+		{
+			name:      "#5 tenant without prefix",
+			activeAt:  entity.DateOf(time.Date(2024, 4, 6, 0, 0, 0, 0, time.UTC)),
+			loc:       time.UTC,
+			wantTitle: "title",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := entity.NewTask("title", tt.activeAt)
+
+			markWeekend(&task, tt.loc, tt.prefix)
+
+			assert.Equal(t, tt.wantTitle, task.Title)
+		})
+	}
+}
+
+func Test_PatchTask(t *testing.T) {
+	activeAt := entity.DateOf(time.Date(2024, 4, 10, 0, 0, 0, 0, time.UTC))
+	early := entity.DateOf(time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC))
+	stored := entity.Task{ID: "1", Title: "title", ActiveAt: activeAt, Priority: entity.PriorityHigh, Tags: []string{"home"}, Version: 3}
+
+	tests := []struct {
+		name      string
+		ifMatch   int64
+		patch     entity.TaskPatch
+		wantPatch *entity.TaskPatch
+		wantErr   error
+	}{
+		{
+			name:      "#1 normalized fields",
+			patch:     entity.TaskPatch{Tags: entity.Change([]string{"Work", "work"}), Recurrence: entity.Change("freq=weekly")},
+			wantPatch: &entity.TaskPatch{Tags: entity.Change([]string{"work"}), Recurrence: entity.Change("FREQ=WEEKLY")},
+		},
+		{
+			name:      "#2 removed priority is normal",
+			ifMatch:   3,
+			patch:     entity.TaskPatch{Priority: entity.Change(""), Description: entity.Change("")},
+			wantPatch: &entity.TaskPatch{Priority: entity.Change(entity.PriorityNormal), Description: entity.Change("")},
+		},
+		{
+			name:    "#3 due date before stored activeAt",
+			patch:   entity.TaskPatch{DueAt: entity.Change(&early)},
+			wantErr: entity.ErrInvalidDueDate,
+		},
+		{
+			name:    "#4 empty title",
+			patch:   entity.TaskPatch{Title: entity.Change(" ")},
+			wantErr: entity.ErrInvalidTitle,
+		},
+		{
+			name:    "#5 parent is itself",
+			patch:   entity.TaskPatch{ParentID: entity.Change("1")},
+			wantErr: entity.ErrInvalidParent,
+		},
+		{
+			name:    "#6 stale version",
+			ifMatch: 2,
+			patch:   entity.TaskPatch{Title: entity.Change("new title")},
+			wantErr: entity.ErrVersionMismatch,
+		},
+		{
+			name: "#7 empty patch",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			taskRepo := NewMocktaskRepo(ctrl)
+
+			taskRepo.EXPECT().Get(gomock.Any(), "1").Return(stored, nil)
+			if tt.wantPatch != nil {
+				taskRepo.EXPECT().Patch(gomock.Any(), "1", tt.ifMatch, *tt.wantPatch).Return(nil)
+			}
+
+			ctx := entity.WithIfMatch(context.Background(), tt.ifMatch)
+
+			err := newTaskUsecase(taskRepo, nil, nil, nil).PatchTask(ctx, "1", tt.patch)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
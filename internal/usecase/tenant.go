@@ -0,0 +1,46 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/skantay/todo-list/internal/entity"
+)
+
+// tenantUsecase находит рабочие пространства, заданные в конфигурации
+type tenantUsecase struct {
+	tenants map[string]entity.Tenant
+}
+
+func newTenantUsecase(tenants ...entity.Tenant) tenantUsecase {
+	byID := make(map[string]entity.Tenant, len(tenants))
+	for _, tenant := range tenants {
+		byID[tenant.ID] = tenant
+	}
+
+	return tenantUsecase{tenants: byID}
+}
+
+// Tenant возвращает рабочее пространство по id.
+// Без настроенных пространств все запросы работают в пространстве по умолчанию и id должен быть пустым,
+// с настроенными пространствами id обязателен.
+func (t tenantUsecase) Tenant(_ context.Context, id string) (entity.Tenant, error) {
+	if len(t.tenants) == 0 {
+		if id != "" {
+			return entity.Tenant{}, fmt.Errorf("%w: workspaces are not configured", entity.ErrInvalidTenant)
+		}
+
+		return entity.DefaultTenant(), nil
+	}
+
+	if id == "" {
+		return entity.Tenant{}, fmt.Errorf("%w: workspace is required", entity.ErrInvalidTenant)
+	}
+
+	tenant, ok := t.tenants[id]
+	if !ok {
+		return entity.Tenant{}, fmt.Errorf("%w: unknown workspace %q", entity.ErrInvalidTenant, id)
+	}
+
+	return tenant, nil
+}
@@ -0,0 +1,64 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/skantay/todo-list/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Tenant(t *testing.T) {
+	platform := entity.Tenant{ID: "platform", WeekendPrefix: "WEEKEND: "}
+
+	tests := []struct {
+		name    string
+		tenants []entity.Tenant
+		id      string
+		want    entity.Tenant
+		wantErr error
+	}{
+		{
+			name: "#1 without workspaces",
+			want: entity.DefaultTenant(),
+		},
+		{
+			name:    "#2 workspace without workspaces",
+			id:      "platform",
+			wantErr: entity.ErrInvalidTenant,
+		},
+		{
+			name:    "#3 known workspace",
+			tenants: []entity.Tenant{platform},
+			id:      "platform",
+			want:    platform,
+		},
+		{
+			name:    "#4 unknown workspace",
+			tenants: []entity.Tenant{platform},
+			id:      "ops",
+			wantErr: entity.ErrInvalidTenant,
+		},
+		{
+			name:    "#5 missing workspace",
+			tenants: []entity.Tenant{platform},
+			wantErr: entity.ErrInvalidTenant,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tenant, err := newTenantUsecase(tt.tenants...).Tenant(context.Background(), tt.id)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), "unexpected error: %v", err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, tenant)
+		})
+	}
+}
@@ -2,16 +2,72 @@ package usecase
 
 import (
 	"log/slog"
+	"time"
 
+	"github.com/skantay/todo-list/internal/entity"
 	"github.com/skantay/todo-list/internal/repository"
 )
 
 type Usecase struct {
-	TaskUsecase taskUsecase
+	TaskUsecase    taskUsecase
+	ProjectUsecase projectUsecase
+	UserUsecase    userUsecase
+	ShareUsecase   shareUsecase
+	TenantUsecase  tenantUsecase
 }
 
-func New(repository repository.Repository, log *slog.Logger) Usecase {
-	return Usecase{
-		TaskUsecase: newTaskUsecase(repository.TaskRepository, log),
+// Option настраивает бизнес-логику при создании.
+type Option func(*Usecase)
+
+// CascadeCompletion включает каскадное выполнение: открытые пункты чек-листа и подзадачи
+// выполняются вместе с задачей. По умолчанию выполнение такой задачи отклоняется.
+func CascadeCompletion() Option {
+	return func(u *Usecase) {
+		u.TaskUsecase.cascade = true
+	}
+}
+
+// MaxBatch задаёт, сколько операций можно передать в одном пакетном запросе.
+// Неположительное значение оставляет ограничение по умолчанию.
+func MaxBatch(size int) Option {
+	return func(u *Usecase) {
+		if size > 0 {
+			u.TaskUsecase.maxBatch = size
+		}
+	}
+}
+
+// TokenAuth задаёт ключ подписи токенов доступа и их время жизни.
+// Нулевой tokenTTL оставляет время жизни по умолчанию.
+func TokenAuth(secret string, tokenTTL time.Duration) Option {
+	return func(u *Usecase) {
+		u.UserUsecase.secret = []byte(secret)
+		if tokenTTL > 0 {
+			u.UserUsecase.tokenTTL = tokenTTL
+		}
+	}
+}
+
+// Tenants задаёт рабочие пространства команд.
+// Без них все запросы работают в пространстве по умолчанию.
+func Tenants(tenants ...entity.Tenant) Option {
+	return func(u *Usecase) {
+		u.TenantUsecase = newTenantUsecase(tenants...)
+	}
+}
+
+func New(repository repository.Repository, log *slog.Logger, opts ...Option) Usecase {
+	usecase := Usecase{
+		TaskUsecase:    newTaskUsecase(repository.TaskRepository, repository.ProjectRepository, repository.ShareRepository, log),
+		ProjectUsecase: newProjectUsecase(repository.ProjectRepository, repository.TaskRepository, repository.ShareRepository, log),
+		UserUsecase:    newUserUsecase(repository.UserRepository, repository.APIKeyRepository, log),
+		ShareUsecase:   newShareUsecase(repository.ShareRepository, repository.TaskRepository, repository.ProjectRepository, repository.UserRepository, log),
+		TenantUsecase:  newTenantUsecase(),
+	}
+
+	for _, opt := range opts {
+		opt(&usecase)
 	}
+
+	return usecase
 }
@@ -0,0 +1,235 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/mail"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/skantay/todo-list/internal/entity"
+
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Константы аутентификации
+const (
+	minPasswordLen  = 8
+	maxPasswordLen  = 72 // bcrypt учитывает только первые 72 байта пароля
+	maxEmailLen     = 254
+	defaultTokenTTL = 24 * time.Hour
+	tokenType       = "Bearer"
+)
+
+// userRepo определяет интерфейс для repository пользователей
+type userRepo interface {
+	Create(ctx context.Context, user entity.User) (string, error)
+	Get(ctx context.Context, id string) (entity.User, error)
+	GetByEmail(ctx context.Context, email string) (entity.User, error)
+	AddTenant(ctx context.Context, id, tenantID string) error
+	Activate(ctx context.Context, id, passwordHash string) error
+}
+
+// tokenClaims - claims токена доступа.
+// Tenant - рабочее пространство, в котором пользователь вошёл, токен действует только в нём.
+type tokenClaims struct {
+	jwt.RegisteredClaims
+	Tenant string `json:"tenant,omitempty"`
+}
+
+type userUsecase struct {
+	repo    userRepo
+	apiKeys apiKeyRepo
+	log     *slog.Logger
+	// secret - ключ подписи JWT (HS256)
+	secret []byte
+	// tokenTTL - время жизни выданного токена
+	tokenTTL time.Duration
+}
+
+func newUserUsecase(userRepo userRepo, apiKeyRepo apiKeyRepo, log *slog.Logger) userUsecase {
+	return userUsecase{
+		repo:     userRepo,
+		apiKeys:  apiKeyRepo,
+		log:      log,
+		tokenTTL: defaultTokenTTL,
+	}
+}
+
+// Register регистрирует пользователя с email и паролем и возвращает его id.
+// Пользователь становится участником рабочего пространства из контекста.
+// Учётная запись без пароля, созданная миграцией для владельца старых задач, активируется паролем.
+func (u userUsecase) Register(ctx context.Context, email, password string) (string, error) {
+	email, err := normalizeEmail(email)
+	if err != nil {
+		return "", err
+	}
+
+	if err := validatePassword(password); err != nil {
+		return "", err
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	user := entity.User{
+		Email:        email,
+		PasswordHash: string(hash),
+	}
+	if tenantID := entity.TenantIDFromContext(ctx); tenantID != "" {
+		user.Tenants = []string{tenantID}
+	}
+
+	id, err := u.repo.Create(ctx, user)
+	if errors.Is(err, entity.ErrUserExists) {
+		return u.activate(ctx, email, string(hash))
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to create user: %w", err)
+	}
+
+	return id, nil
+}
+
+// activate задаёт пароль существующей учётной записи без пароля,
+// у учётной записи с паролем возвращает entity.ErrUserExists
+func (u userUsecase) activate(ctx context.Context, email, passwordHash string) (string, error) {
+	user, err := u.repo.GetByEmail(ctx, email)
+	if err != nil {
+		return "", fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if user.PasswordHash != "" {
+		return "", fmt.Errorf("failed to create user: %w", entity.ErrUserExists)
+	}
+
+	if err := u.repo.Activate(ctx, user.ID, passwordHash); err != nil {
+		return "", fmt.Errorf("failed to activate user: %w", err)
+	}
+
+	if tenantID := entity.TenantIDFromContext(ctx); tenantID != "" {
+		if err := u.repo.AddTenant(ctx, user.ID, tenantID); err != nil {
+			return "", fmt.Errorf("failed to add user tenant: %w", err)
+		}
+	}
+
+	return user.ID, nil
+}
+
+// checkMember проверяет, что пользователь userID - участник рабочего пространства из контекста
+func (u userUsecase) checkMember(ctx context.Context, userID string) error {
+	tenantID := entity.TenantIDFromContext(ctx)
+	if tenantID == "" {
+		return nil
+	}
+
+	user, err := u.repo.Get(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if !user.IsMember(tenantID) {
+		return fmt.Errorf("%w: not a member of workspace %q", entity.ErrForbidden, tenantID)
+	}
+
+	return nil
+}
+
+// Login проверяет email и пароль и выдаёт подписанный токен доступа.
+// Токен привязывается к рабочему пространству из контекста, если оно указано,
+// в чужое пространство войти нельзя.
+// Неизвестный email и неверный пароль неотличимы для клиента.
+func (u userUsecase) Login(ctx context.Context, email, password string) (entity.Token, error) {
+	user, err := u.repo.GetByEmail(ctx, strings.ToLower(strings.TrimSpace(email)))
+	if errors.Is(err, entity.ErrUserNotFound) {
+		return entity.Token{}, entity.ErrInvalidCredentials
+	}
+	if err != nil {
+		return entity.Token{}, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
+		return entity.Token{}, entity.ErrInvalidCredentials
+	}
+
+	if tenantID := entity.TenantIDFromContext(ctx); !user.IsMember(tenantID) {
+		return entity.Token{}, fmt.Errorf("%w: not a member of workspace %q", entity.ErrForbidden, tenantID)
+	}
+
+	now := time.Now().UTC()
+	expiresAt := now.Add(u.tokenTTL).Truncate(time.Second)
+
+	claims := tokenClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   user.ID,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+		Tenant: entity.TenantIDFromContext(ctx),
+	}
+
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(u.secret)
+	if err != nil {
+		return entity.Token{}, fmt.Errorf("failed to sign token: %w", err)
+	}
+
+	return entity.Token{
+		AccessToken: signed,
+		TokenType:   tokenType,
+		ExpiresAt:   expiresAt,
+	}, nil
+}
+
+// Authenticate проверяет подпись и срок действия токена и возвращает пользователя и пространство токена
+func (u userUsecase) Authenticate(_ context.Context, token string) (entity.Identity, error) {
+	var claims tokenClaims
+
+	_, err := jwt.ParseWithClaims(token, &claims, func(*jwt.Token) (any, error) {
+		return u.secret, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithExpirationRequired())
+	if err != nil {
+		return entity.Identity{}, fmt.Errorf("%w: %w", entity.ErrUnauthorized, err)
+	}
+
+	if claims.Subject == "" {
+		return entity.Identity{}, fmt.Errorf("%w: token has no subject", entity.ErrUnauthorized)
+	}
+
+	return entity.Identity{UserID: claims.Subject, TenantID: claims.Tenant}, nil
+}
+
+// normalizeEmail проверяет email и приводит его к нижнему регистру
+func normalizeEmail(email string) (string, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+
+	if utf8.RuneCountInString(email) > maxEmailLen {
+		return "", entity.NewValidationError("email", fmt.Sprintf("must not exceed %d characters", maxEmailLen), entity.ErrInvalidEmail)
+	}
+
+	// Имя вида "Name <addr>" не допускается, только сам адрес
+	address, err := mail.ParseAddress(email)
+	if err != nil || address.Address != email {
+		return "", entity.NewValidationError("email", "must be a valid email address", entity.ErrInvalidEmail)
+	}
+
+	return email, nil
+}
+
+// validatePassword проверяет длину пароля
+func validatePassword(password string) error {
+	if utf8.RuneCountInString(password) < minPasswordLen {
+		return entity.NewValidationError("password", fmt.Sprintf("must be at least %d characters", minPasswordLen), entity.ErrInvalidPassword)
+	}
+
+	if len(password) > maxPasswordLen {
+		return entity.NewValidationError("password", fmt.Sprintf("must not exceed %d bytes", maxPasswordLen), entity.ErrInvalidPassword)
+	}
+
+	return nil
+}
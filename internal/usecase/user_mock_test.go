@@ -0,0 +1,109 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/usecase/user.go
+
+// Package mock_usecase is a generated GoMock package.
+package usecase
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entity "github.com/skantay/todo-list/internal/entity"
+)
+
+// MockuserRepo is a mock of userRepo interface.
+type MockuserRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockuserRepoMockRecorder
+}
+
+// MockuserRepoMockRecorder is the mock recorder for MockuserRepo.
+type MockuserRepoMockRecorder struct {
+	mock *MockuserRepo
+}
+
+// NewMockuserRepo creates a new mock instance.
+func NewMockuserRepo(ctrl *gomock.Controller) *MockuserRepo {
+	mock := &MockuserRepo{ctrl: ctrl}
+	mock.recorder = &MockuserRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockuserRepo) EXPECT() *MockuserRepoMockRecorder {
+	return m.recorder
+}
+
+// Activate mocks base method.
+func (m *MockuserRepo) Activate(ctx context.Context, id, passwordHash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Activate", ctx, id, passwordHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Activate indicates an expected call of Activate.
+func (mr *MockuserRepoMockRecorder) Activate(ctx, id, passwordHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Activate", reflect.TypeOf((*MockuserRepo)(nil).Activate), ctx, id, passwordHash)
+}
+
+// AddTenant mocks base method.
+func (m *MockuserRepo) AddTenant(ctx context.Context, id, tenantID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTenant", ctx, id, tenantID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddTenant indicates an expected call of AddTenant.
+func (mr *MockuserRepoMockRecorder) AddTenant(ctx, id, tenantID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTenant", reflect.TypeOf((*MockuserRepo)(nil).AddTenant), ctx, id, tenantID)
+}
+
+// Create mocks base method.
+func (m *MockuserRepo) Create(ctx context.Context, user entity.User) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, user)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockuserRepoMockRecorder) Create(ctx, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockuserRepo)(nil).Create), ctx, user)
+}
+
+// Get mocks base method.
+func (m *MockuserRepo) Get(ctx context.Context, id string) (entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockuserRepoMockRecorder) Get(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockuserRepo)(nil).Get), ctx, id)
+}
+
+// GetByEmail mocks base method.
+func (m *MockuserRepo) GetByEmail(ctx context.Context, email string) (entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByEmail", ctx, email)
+	ret0, _ := ret[0].(entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByEmail indicates an expected call of GetByEmail.
+func (mr *MockuserRepoMockRecorder) GetByEmail(ctx, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByEmail", reflect.TypeOf((*MockuserRepo)(nil).GetByEmail), ctx, email)
+}
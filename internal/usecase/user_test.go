@@ -0,0 +1,273 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/skantay/todo-list/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const userID = "661fbb485131cd932a981b28"
+
+func Test_Register(t *testing.T) {
+	tests := []struct {
+		name     string
+		email    string
+		password string
+		tenantID string
+		setup    func(m *MockuserRepo)
+		wantErr  error
+	}{
+		{
+			name:     "#1 valid",
+			email:    "  User@Example.com ",
+			password: "password",
+			setup: func(m *MockuserRepo) {
+				m.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, user entity.User) (string, error) {
+					assert.Equal(t, "user@example.com", user.Email)
+					assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte("password")))
+					assert.Empty(t, user.Tenants)
+
+					return userID, nil
+				})
+			},
+		},
+		{
+			name:     "#2 invalid email",
+			email:    "user",
+			password: "password",
+			wantErr:  entity.ErrInvalidEmail,
+		},
+		{
+			name:     "#3 email with name",
+			email:    "User <user@example.com>",
+			password: "password",
+			wantErr:  entity.ErrInvalidEmail,
+		},
+		{
+			name:     "#4 short password",
+			email:    "user@example.com",
+			password: "short",
+			wantErr:  entity.ErrInvalidPassword,
+		},
+		{
+			name:     "#5 long password",
+			email:    "user@example.com",
+			password: strings.Repeat("a", maxPasswordLen+1),
+			wantErr:  entity.ErrInvalidPassword,
+		},
+		{
+			name:     "#6 already exists",
+			email:    "user@example.com",
+			password: "password",
+			setup: func(m *MockuserRepo) {
+				m.EXPECT().Create(gomock.Any(), gomock.Any()).Return("", entity.ErrUserExists)
+				m.EXPECT().GetByEmail(gomock.Any(), "user@example.com").Return(entity.User{ID: userID, Email: "user@example.com", PasswordHash: "hash"}, nil)
+			},
+			wantErr: entity.ErrUserExists,
+		},
+		{
+			name:     "#7 activate legacy owner",
+			email:    "user@example.com",
+			password: "password",
+			setup: func(m *MockuserRepo) {
+				m.EXPECT().Create(gomock.Any(), gomock.Any()).Return("", entity.ErrUserExists)
+				m.EXPECT().GetByEmail(gomock.Any(), "user@example.com").Return(entity.User{ID: userID, Email: "user@example.com"}, nil)
+				m.EXPECT().Activate(gomock.Any(), userID, gomock.Any()).DoAndReturn(func(_ context.Context, _, passwordHash string) error {
+					assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(passwordHash), []byte("password")))
+
+					return nil
+				})
+			},
+		},
+		{
+			name:     "#8 legacy owner activated concurrently",
+			email:    "user@example.com",
+			password: "password",
+			setup: func(m *MockuserRepo) {
+				m.EXPECT().Create(gomock.Any(), gomock.Any()).Return("", entity.ErrUserExists)
+				m.EXPECT().GetByEmail(gomock.Any(), "user@example.com").Return(entity.User{ID: userID, Email: "user@example.com"}, nil)
+				m.EXPECT().Activate(gomock.Any(), userID, gomock.Any()).Return(entity.ErrUserExists)
+			},
+			wantErr: entity.ErrUserExists,
+		},
+		{
+			name:     "#9 member of workspace",
+			email:    "user@example.com",
+			password: "password",
+			tenantID: "platform",
+			setup: func(m *MockuserRepo) {
+				m.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, user entity.User) (string, error) {
+					assert.Equal(t, []string{"platform"}, user.Tenants)
+
+					return userID, nil
+				})
+			},
+		},
+		{
+			name:     "#10 activate legacy owner in workspace",
+			email:    "user@example.com",
+			password: "password",
+			tenantID: "platform",
+			setup: func(m *MockuserRepo) {
+				m.EXPECT().Create(gomock.Any(), gomock.Any()).Return("", entity.ErrUserExists)
+				m.EXPECT().GetByEmail(gomock.Any(), "user@example.com").Return(entity.User{ID: userID, Email: "user@example.com"}, nil)
+				m.EXPECT().Activate(gomock.Any(), userID, gomock.Any()).Return(nil)
+				m.EXPECT().AddTenant(gomock.Any(), userID, "platform").Return(nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			userRepo := NewMockuserRepo(ctrl)
+
+			if tt.setup != nil {
+				tt.setup(userRepo)
+			}
+
+			ctx := context.Background()
+			if tt.tenantID != "" {
+				ctx = entity.WithTenant(ctx, entity.Tenant{ID: tt.tenantID})
+			}
+
+			id, err := newUserUsecase(userRepo, nil, nil).Register(ctx, tt.email, tt.password)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), "unexpected error: %v", err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, userID, id)
+		})
+	}
+}
+
+func Test_LoginAuthenticate(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	user := entity.User{ID: userID, Email: "user@example.com", PasswordHash: string(hash), Tenants: []string{"platform"}}
+
+	newUsecase := func(t *testing.T, secret string, tokenTTL time.Duration) userUsecase {
+		ctrl := gomock.NewController(t)
+		userRepo := NewMockuserRepo(ctrl)
+		userRepo.EXPECT().GetByEmail(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, email string) (entity.User, error) {
+			if email != user.Email {
+				return entity.User{}, entity.ErrUserNotFound
+			}
+
+			return user, nil
+		}).AnyTimes()
+
+		u := newUserUsecase(userRepo, nil, nil)
+		u.secret = []byte(secret)
+		u.tokenTTL = tokenTTL
+
+		return u
+	}
+
+	tests := []struct {
+		name     string
+		email    string
+		password string
+		// verifier проверяет токен, по умолчанию тем же usecase, что выдал его
+		verifier func(t *testing.T) userUsecase
+		tokenTTL time.Duration
+		tenantID string
+		wantErr  error
+	}{
+		{
+			name:     "#1 valid",
+			email:    "USER@example.com",
+			password: "password",
+		},
+		{
+			name:     "#2 wrong password",
+			email:    "user@example.com",
+			password: "wrong password",
+			wantErr:  entity.ErrInvalidCredentials,
+		},
+		{
+			name:     "#3 unknown email",
+			email:    "other@example.com",
+			password: "password",
+			wantErr:  entity.ErrInvalidCredentials,
+		},
+		{
+			name:     "#4 expired token",
+			email:    "user@example.com",
+			password: "password",
+			tokenTTL: -time.Minute,
+			wantErr:  entity.ErrUnauthorized,
+		},
+		{
+			name:     "#5 another secret",
+			email:    "user@example.com",
+			password: "password",
+			verifier: func(t *testing.T) userUsecase { return newUsecase(t, "another secret", time.Hour) },
+			wantErr:  entity.ErrUnauthorized,
+		},
+		{
+			name:     "#6 token of workspace",
+			email:    "user@example.com",
+			password: "password",
+			tenantID: "platform",
+		},
+		{
+			name:     "#7 foreign workspace",
+			email:    "user@example.com",
+			password: "password",
+			tenantID: "foreign",
+			wantErr:  entity.ErrForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.tokenTTL == 0 {
+				tt.tokenTTL = time.Hour
+			}
+
+			u := newUsecase(t, "secret", tt.tokenTTL)
+
+			ctx := context.Background()
+			if tt.tenantID != "" {
+				ctx = entity.WithTenant(ctx, entity.Tenant{ID: tt.tenantID})
+			}
+
+			token, err := u.Login(ctx, tt.email, tt.password)
+			if errors.Is(tt.wantErr, entity.ErrInvalidCredentials) || errors.Is(tt.wantErr, entity.ErrForbidden) {
+				assert.True(t, errors.Is(err, tt.wantErr), "unexpected error: %v", err)
+
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "Bearer", token.TokenType)
+
+			verifier := u
+			if tt.verifier != nil {
+				verifier = tt.verifier(t)
+			}
+
+			identity, err := verifier.Authenticate(context.Background(), token.AccessToken)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), "unexpected error: %v", err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, entity.Identity{UserID: userID, TenantID: tt.tenantID}, identity)
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/skantay/todo-list/internal/entity"
+)
+
+// tagPattern - допустимые символы тега: буквы, цифры, "-" и "_", начинается с буквы или цифры
+var tagPattern = regexp.MustCompile(`^[\p{L}\p{N}][\p{L}\p{N}_-]*$`)
+
+// normalizeTask проверяет поля задачи и приводит их к каноническому виду.
+// Даты без времени сравниваются в часовом поясе пользователя loc.
+func normalizeTask(task entity.Task, loc *time.Location) (entity.Task, error) {
+	if err := validateTitle(task.Title); err != nil {
+		return task, err
+	}
+
+	// Срок на весь день может совпадать с днём начала
+	if task.DueAt != nil && task.DueAt.End(loc).Before(task.ActiveAt.Instant(loc)) {
+		return task, entity.NewValidationError("dueAt", "must not be before activeAt", entity.ErrInvalidDueDate)
+	}
+
+	if utf8.RuneCountInString(task.Description) > maxDescriptionLen {
+		return task, entity.NewValidationError("description", fmt.Sprintf("must not exceed %d characters", maxDescriptionLen), entity.ErrInvalidDescription)
+	}
+
+	if task.Priority == "" {
+		task.Priority = entity.PriorityNormal
+	}
+
+	if err := validatePriority(task.Priority); err != nil {
+		return task, err
+	}
+
+	tags, err := normalizeTags(task.Tags)
+	if err != nil {
+		return task, err
+	}
+	task.Tags = tags
+
+	if task.Recurrence != "" {
+		rule, err := entity.ParseRecurrence(task.Recurrence)
+		if err != nil {
+			return task, err
+		}
+		task.Recurrence = rule.String()
+	}
+
+	return task, nil
+}
+
+// validateTitle проверяет, что заголовок не пустой и не превышает maxTitleLen символов
+func validateTitle(title string) error {
+	if strings.TrimSpace(title) == "" {
+		return entity.NewValidationError("title", "must not be empty", entity.ErrInvalidTitle)
+	}
+
+	if utf8.RuneCountInString(title) > maxTitleLen {
+		return entity.NewValidationError("title", fmt.Sprintf("must not exceed %d characters", maxTitleLen), entity.ErrInvalidTitle)
+	}
+
+	return nil
+}
+
+// validatePriority проверяет, что приоритет один из entity.Priorities
+func validatePriority(priority string) error {
+	if !slices.Contains(entity.Priorities, priority) {
+		return entity.NewValidationError("priority", "must be one of "+strings.Join(entity.Priorities, ", "), entity.ErrInvalidPriority)
+	}
+
+	return nil
+}
+
+// normalizeTags приводит теги к нижнему регистру, убирает повторы и сортирует их
+func normalizeTags(tags []string) ([]string, error) {
+	if len(tags) == 0 {
+		return nil, nil
+	}
+
+	result := make([]string, 0, len(tags))
+
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+
+		if utf8.RuneCountInString(tag) > maxTagLen || !tagPattern.MatchString(tag) {
+			return nil, entity.NewValidationError("tags", fmt.Sprintf("%q must be up to %d letters, digits, '-' or '_'", tag, maxTagLen), entity.ErrInvalidTags)
+		}
+
+		result = append(result, tag)
+	}
+
+	slices.Sort(result)
+	result = slices.Compact(result)
+
+	if len(result) > maxTags {
+		return nil, entity.NewValidationError("tags", fmt.Sprintf("must not contain more than %d tags", maxTags), entity.ErrInvalidTags)
+	}
+
+	return result, nil
+}
+
+// filterError переносит ошибку валидации поля задачи на параметр фильтра param.
+// Некорректный фильтр - ошибка запроса, а не данных задачи, поэтому оборачивается в ErrInvalidFilter.
+func filterError(param string, err error) error {
+	var validationErr entity.ValidationError
+	if !errors.As(err, &validationErr) {
+		return err
+	}
+
+	return entity.NewValidationError(param, validationErr.Reason, entity.ErrInvalidFilter)
+}
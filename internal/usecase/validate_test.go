@@ -0,0 +1,105 @@
+package usecase
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/skantay/todo-list/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NormalizeTask(t *testing.T) {
+	tooManyTags := make([]string, maxTags+1)
+	for i := range tooManyTags {
+		tooManyTags[i] = "tag" + strconv.Itoa(i)
+	}
+
+	date := func(day int) *entity.TaskDate {
+		d := entity.DateOf(time.Date(2024, 4, day, 0, 0, 0, 0, time.UTC))
+
+		return &d
+	}
+
+	tests := []struct {
+		name     string
+		task     entity.Task
+		wantTask entity.Task
+		wantErr  error
+	}{
+		{
+			name:     "#1 defaults",
+			task:     entity.Task{Title: "title"},
+			wantTask: entity.Task{Title: "title", Priority: entity.PriorityNormal},
+		},
+		{
+			name: "#2 tags normalized",
+			task: entity.Task{Title: "title", Priority: entity.PriorityUrgent, Tags: []string{" Work", "дом", "work", "q1_2024"}},
+			wantTask: entity.Task{
+				Title:    "title",
+				Priority: entity.PriorityUrgent,
+				Tags:     []string{"q1_2024", "work", "дом"},
+			},
+		},
+		{
+			name:    "#3 invalid priority",
+			task:    entity.Task{Title: "title", Priority: "Normal"},
+			wantErr: entity.ErrInvalidPriority,
+		},
+		{
+			name:    "#4 tag too long",
+			task:    entity.Task{Title: "title", Tags: []string{strings.Repeat("a", maxTagLen+1)}},
+			wantErr: entity.ErrInvalidTags,
+		},
+		{
+			name:    "#5 tag starts with dash",
+			task:    entity.Task{Title: "title", Tags: []string{"-work"}},
+			wantErr: entity.ErrInvalidTags,
+		},
+		{
+			name:    "#6 too many tags",
+			task:    entity.Task{Title: "title", Tags: tooManyTags},
+			wantErr: entity.ErrInvalidTags,
+		},
+		{
+			name:    "#7 empty title",
+			task:    entity.Task{Priority: entity.PriorityLow},
+			wantErr: entity.ErrInvalidTitle,
+		},
+		{
+			name:     "#8 due the same day",
+			task:     entity.Task{Title: "title", ActiveAt: entity.TimeOf(time.Date(2024, 4, 1, 15, 0, 0, 0, time.UTC)), DueAt: date(1)},
+			wantTask: entity.Task{Title: "title", ActiveAt: entity.TimeOf(time.Date(2024, 4, 1, 15, 0, 0, 0, time.UTC)), DueAt: date(1), Priority: entity.PriorityNormal},
+		},
+		{
+			name:    "#9 due before active",
+			task:    entity.Task{Title: "title", ActiveAt: *date(2), DueAt: date(1)},
+			wantErr: entity.ErrInvalidDueDate,
+		},
+		{
+			name:     "#10 recurrence normalized",
+			task:     entity.Task{Title: "title", Recurrence: "rrule:freq=weekly;byday=we,mo"},
+			wantTask: entity.Task{Title: "title", Priority: entity.PriorityNormal, Recurrence: "FREQ=WEEKLY;BYDAY=MO,WE"},
+		},
+		{
+			name:    "#11 invalid recurrence",
+			task:    entity.Task{Title: "title", Recurrence: "FREQ=MONTHLY;BYDAY=MO"},
+			wantErr: entity.ErrInvalidRecurrence,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task, err := normalizeTask(tt.task, time.UTC)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantTask, task)
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	_ "modernc.org/sqlite" // Драйвер SQLite без cgo
+)
+
+const (
+	driverName = "sqlite"
+)
+
+// Конструктор открывает (или создаёт) файл базы SQLite
+func Open(ctx context.Context, path string) (*sql.DB, error) {
+	db, err := sql.Open(driverName, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open sqlite: %w", err)
+	}
+
+	// SQLite допускает только одного писателя, а база ":memory:" живёт в рамках одного соединения
+	db.SetMaxOpenConns(1)
+
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+
+		return nil, fmt.Errorf("failed to ping sqlite: %w", err)
+	}
+
+	return db, nil
+}